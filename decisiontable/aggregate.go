@@ -0,0 +1,182 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+	"unicode/utf8"
+)
+
+// aggregateFamilies are the prefixes of the aggregate and string length operators.
+var aggregateFamilies = []string{"SUM", "COUNT", "MIN", "MAX", "LENGTH"}
+
+// aggregateComparisons are the comparisons an aggregate operator may end with.
+var aggregateComparisons = []OperatorType{OperatorEqual, OperatorGreater, OperatorGreaterOrEqual, OperatorLess, OperatorLessOrEqual}
+
+// splitAggregateOperator splits an operator such as SUM_GT into its aggregate and comparison.
+func splitAggregateOperator(op OperatorType) (string, OperatorType, bool) {
+	for _, agg := range aggregateFamilies {
+		rest, found := strings.CutPrefix(string(op), agg+"_")
+		if !found {
+			continue
+		}
+		for _, cmp := range aggregateComparisons {
+			if OperatorType(rest) == cmp {
+				return agg, cmp, true
+			}
+		}
+	}
+	return "", "", false
+}
+
+func isAggregateOperator(op OperatorType) bool {
+	_, _, ok := splitAggregateOperator(op)
+	return ok
+}
+
+// isStringLengthOperator reports whether op is one of the LENGTH_* operators, which compare a scalar
+// string rather than fold a list.
+func isStringLengthOperator(op OperatorType) bool {
+	agg, _, ok := splitAggregateOperator(op)
+	return ok && agg == "LENGTH"
+}
+
+// parseAggregateToken resolves SUM_GT, sum_gt or sumGt to an aggregate operator.
+func parseAggregateToken(token string) (OperatorType, bool) {
+	want := strings.ReplaceAll(normalizeKeyword(token), "_", "")
+	for _, agg := range aggregateFamilies {
+		for _, cmp := range aggregateComparisons {
+			op := OperatorType(agg + "_" + string(cmp))
+			if strings.ReplaceAll(string(op), "_", "") == want {
+				return op, true
+			}
+		}
+	}
+	return "", false
+}
+
+// sanitizeAggregateValue checks the column type and coerces the expected aggregate: a decimal for the
+// sum, minimum and maximum of a LIST_DECIMAL column and an integer otherwise.
+func sanitizeAggregateValue(dt DataType, op OperatorType, raw any) (any, error) {
+	agg, _, _ := splitAggregateOperator(op)
+	switch {
+	case dt == DataTypeListInteger, dt == DataTypeListDecimal:
+	case dt == DataTypeListString && agg == "COUNT":
+	case dt == DataTypeString && agg == "LENGTH":
+	default:
+		return nil, fmt.Errorf("operator %s not supported for %s columns", op, dt)
+	}
+	if raw == nil {
+		return nil, fmt.Errorf("operator %s requires a value", op)
+	}
+	if dt == DataTypeListDecimal && agg != "COUNT" {
+		return coercePrimitive(DataTypeDecimal, raw)
+	}
+	return toInt64(raw)
+}
+
+// evaluateAggregate folds the actual list of a dt column and compares the result with expected. The
+// sum and count of an empty list are 0; MIN and MAX of an empty list have no value and never match.
+// A null input never matches.
+func evaluateAggregate(dt DataType, op OperatorType, actual []any, expected any) (bool, error) {
+	if actual == nil {
+		return false, nil
+	}
+	agg, cmp, _ := splitAggregateOperator(op)
+	var result any
+	resultType := DataTypeInteger
+	switch {
+	case agg == "COUNT":
+		result = int64(len(actual))
+	case (agg == "MIN" || agg == "MAX") && len(actual) == 0:
+		return false, nil
+	case dt == DataTypeListDecimal:
+		folded, err := foldDecimals(op, agg, actual)
+		if err != nil {
+			return false, err
+		}
+		result, resultType = folded, DataTypeDecimal
+	default:
+		folded, err := foldIntegers(op, agg, actual)
+		if err != nil {
+			return false, err
+		}
+		result = folded
+	}
+	if cmp == OperatorEqual {
+		return equals(resultType, result, expected)
+	}
+	return compare(resultType, cmp, result, expected)
+}
+
+// foldIntegers returns the sum, minimum or maximum of a non-empty list of int64 elements.
+func foldIntegers(op OperatorType, agg string, actual []any) (int64, error) {
+	var result int64
+	for i, v := range actual {
+		n, ok := v.(int64)
+		if !ok {
+			return 0, fmt.Errorf("operator %s expects integer elements, got %T", op, v)
+		}
+		switch {
+		case agg == "SUM":
+			if (n > 0 && result > math.MaxInt64-n) || (n < 0 && result < math.MinInt64-n) {
+				return 0, fmt.Errorf("operator %s: sum overflows int64", op)
+			}
+			result += n
+		case i == 0 || (agg == "MIN" && n < result) || (agg == "MAX" && n > result):
+			result = n
+		}
+	}
+	return result, nil
+}
+
+// foldDecimals returns the sum, minimum or maximum of a non-empty list of *big.Float elements. The
+// result is a new value; the elements are not modified.
+func foldDecimals(op OperatorType, agg string, actual []any) (*big.Float, error) {
+	result := new(big.Float).SetPrec(decimalPrecision)
+	for i, v := range actual {
+		f, ok := v.(*big.Float)
+		if !ok {
+			return nil, fmt.Errorf("operator %s expects decimal elements, got %T", op, v)
+		}
+		switch {
+		case agg == "SUM":
+			result.Add(result, f)
+		case i == 0 || (agg == "MIN" && f.Cmp(result) < 0) || (agg == "MAX" && f.Cmp(result) > 0):
+			result.Set(f)
+		}
+	}
+	return result, nil
+}
+
+// evaluateStringLength compares the rune count of actual with expected. A null input never matches.
+func evaluateStringLength(op OperatorType, actual any, expected any) (bool, error) {
+	if actual == nil {
+		return false, nil
+	}
+	s, ok := actual.(string)
+	if !ok {
+		return false, fmt.Errorf("operator %s expects a string input, got %T", op, actual)
+	}
+	_, cmp, _ := splitAggregateOperator(op)
+	length := int64(utf8.RuneCountInString(s))
+	if cmp == OperatorEqual {
+		return equals(DataTypeInteger, length, expected)
+	}
+	return compare(DataTypeInteger, cmp, length, expected)
+}
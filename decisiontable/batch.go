@@ -0,0 +1,199 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+)
+
+// EvaluateBatch evaluates each input in turn as Evaluate would and returns the matches in input
+// order. The first failing input stops the batch and its index is reported. With WithCoercionCache,
+// input values repeated across the batch are coerced once.
+func (dt *DecisionTable) EvaluateBatch(inputs []map[string]any, defaultReturn map[string]any) ([][]MatchedRow, error) {
+	var cache *coercionCache
+	var columns map[string]DataType
+	if dt.coercionCacheSize > 0 {
+		cache = newCoercionCache(dt.coercionCacheSize)
+		columns = dt.cacheableColumns()
+	}
+	results := make([][]MatchedRow, len(inputs))
+	for i, input := range inputs {
+		var provider InputProvider = MapInput(input)
+		if cache != nil {
+			provider = cachedInput{MapInput: MapInput(input), cache: cache, columns: columns}
+		}
+		matches, err := dt.EvaluateProvider(provider, defaultReturn)
+		if err != nil {
+			return nil, fmt.Errorf("input %d: %w", i, err)
+		}
+		results[i] = matches
+	}
+	return results, nil
+}
+
+// EvaluateAll evaluates the same input against every table concurrently, one goroutine per table, and
+// collects the results by table key. A failing or nil table only records its error; the other tables
+// still report their matches. The error map is nil when every table succeeded. Tables must not be
+// modified while EvaluateAll runs.
+func EvaluateAll(input map[string]any, tables map[string]*DecisionTable) (map[string][]MatchedRow, map[string]error) {
+	type outcome struct {
+		key     string
+		matches []MatchedRow
+		err     error
+	}
+	outcomes := make(chan outcome, len(tables))
+	var wg sync.WaitGroup
+	for key, dt := range tables {
+		if dt == nil {
+			outcomes <- outcome{key: key, err: fmt.Errorf("decision table is nil")}
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			matches, err := dt.Evaluate(input, nil)
+			outcomes <- outcome{key: key, matches: matches, err: err}
+		}()
+	}
+	wg.Wait()
+	close(outcomes)
+
+	results := make(map[string][]MatchedRow, len(tables))
+	var errs map[string]error
+	for o := range outcomes {
+		if o.err != nil {
+			if errs == nil {
+				errs = make(map[string]error)
+			}
+			errs[o.key] = o.err
+			continue
+		}
+		results[o.key] = o.matches
+	}
+	return results, errs
+}
+
+// cacheableColumns returns the condition columns whose cells all coerce their input with
+// sanitizeActualValue, so handing them an already coerced value changes nothing. Columns read by
+// custom, collection or TEXT_EQ operators see the raw input and are left out, as are STRING columns,
+// whose coercion costs no more than a lookup.
+func (dt *DecisionTable) cacheableColumns() map[string]DataType {
+	columns := make(map[string]DataType, len(dt.conditionOrder))
+	for _, col := range dt.conditionOrder {
+		if col.DataType != DataTypeString && !isListDataType(col.DataType) && col.Path == "" {
+			columns[col.Name] = col.DataType
+		}
+	}
+	// a key that a Path starts from holds a nested value, not the column's own input
+	for _, path := range dt.inputPaths {
+		delete(columns, path.root)
+	}
+	for _, row := range dt.rows {
+		for _, cell := range row.EvalCells {
+			if isCustomOperator(cell.Operator) || expectsActualCollection(cell.Operator) || cell.Operator == OperatorTextEqual {
+				delete(columns, cell.Column)
+			}
+		}
+	}
+	return columns
+}
+
+// cachedInput serves coerced values for cacheable columns and raw values for everything else.
+// Values that fail to coerce are passed through so the evaluation reports the usual error.
+type cachedInput struct {
+	MapInput
+	cache   *coercionCache
+	columns map[string]DataType
+}
+
+// Get implements InputProvider.
+func (c cachedInput) Get(column string) (any, bool) {
+	raw, ok := c.MapInput[column]
+	dt, cacheable := c.columns[column]
+	if !ok || !cacheable {
+		return raw, ok
+	}
+	key, ok := coercionKeyFor(dt, raw)
+	if !ok {
+		return raw, true
+	}
+	if v, hit := c.cache.get(key); hit {
+		return v, true
+	}
+	v, err := sanitizeActualValue(dt, raw)
+	if err != nil {
+		return raw, true
+	}
+	c.cache.put(key, v)
+	return v, true
+}
+
+type coercionKey struct {
+	dataType DataType
+	raw      any
+}
+
+// coercionKeyFor only keys scalar raw values, which are comparable and cannot be mutated by the
+// caller after they are cached.
+func coercionKeyFor(dt DataType, raw any) (coercionKey, bool) {
+	switch raw.(type) {
+	case string, bool, int, int32, int64, float64:
+		return coercionKey{dataType: dt, raw: raw}, true
+	default:
+		return coercionKey{}, false
+	}
+}
+
+// coercionCache is a least-recently-used map of coerced values. Cached values such as *big.Float
+// are shared between evaluations, which is safe because operators never modify their inputs.
+type coercionCache struct {
+	size    int
+	order   *list.List
+	entries map[coercionKey]*list.Element
+}
+
+type coercionEntry struct {
+	key   coercionKey
+	value any
+}
+
+func newCoercionCache(size int) *coercionCache {
+	return &coercionCache{size: size, order: list.New(), entries: make(map[coercionKey]*list.Element, size)}
+}
+
+func (c *coercionCache) get(key coercionKey) (any, bool) {
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*coercionEntry).value, true
+}
+
+func (c *coercionCache) put(key coercionKey, value any) {
+	if elem, ok := c.entries[key]; ok {
+		elem.Value.(*coercionEntry).value = value
+		c.order.MoveToFront(elem)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&coercionEntry{key: key, value: value})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*coercionEntry).key)
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func buildBatchTable(t testing.TB, opts ...Option) *DecisionTable {
+	t.Helper()
+	dt, err := NewDecisionTable("shipping",
+		[]Column{
+			{Name: "shipped", Type: ColumnTypeCondition, DataType: DataTypeDate},
+			{Name: "amount", Type: ColumnTypeCondition, DataType: DataTypeDecimal},
+		},
+		[]Column{{Name: "fee", Type: ColumnTypeConclusion, DataType: DataTypeString}},
+		append([]Option{WithMatchPolicy(MatchPolicyFirst), WithNoMatchPolicy(NoMatchPolicyReturnDefault)}, opts...)...,
+	)
+	if err != nil {
+		t.Fatalf("failed to build table: %v", err)
+	}
+	rows := []Row{
+		{RuleID: "late-large", EvalCells: []EvalCell{
+			{Column: "shipped", Operator: OperatorGreaterOrEqual, Value: "2025-06-01"},
+			{Column: "amount", Operator: OperatorGreater, Value: "100.50"},
+		}, ReturnCells: []ReturnCell{{Column: "fee", Value: "high"}}},
+		{RuleID: "late", EvalCells: []EvalCell{
+			{Column: "shipped", Operator: OperatorGreaterOrEqual, Value: "2025-06-01"},
+		}, ReturnCells: []ReturnCell{{Column: "fee", Value: "standard"}}},
+	}
+	for _, row := range rows {
+		if err := dt.AddRow(row); err != nil {
+			t.Fatalf("failed to add row %s: %v", row.RuleID, err)
+		}
+	}
+	if err := dt.SetDefaultRow(Row{ReturnCells: []ReturnCell{{Column: "fee", Value: "none"}}}); err != nil {
+		t.Fatalf("failed to set default row: %v", err)
+	}
+	return dt
+}
+
+func batchInputs(n int) []map[string]any {
+	dates := []string{"2025-05-30", "2025-06-01", "2025-06-15"}
+	amounts := []string{"99.99", "100.50", "250.00"}
+	inputs := make([]map[string]any, n)
+	for i := range inputs {
+		inputs[i] = map[string]any{"shipped": dates[i%len(dates)], "amount": amounts[(i/len(dates))%len(amounts)]}
+	}
+	return inputs
+}
+
+func TestEvaluateBatchMatchesEvaluate(t *testing.T) {
+	plain := buildBatchTable(t)
+	cached := buildBatchTable(t, WithCoercionCache(2))
+	inputs := batchInputs(30)
+
+	want, err := plain.EvaluateBatch(inputs, nil)
+	if err != nil {
+		t.Fatalf("evaluate batch returned error: %v", err)
+	}
+	got, err := cached.EvaluateBatch(inputs, nil)
+	if err != nil {
+		t.Fatalf("cached evaluate batch returned error: %v", err)
+	}
+	for i, input := range inputs {
+		single, err := plain.Evaluate(input, nil)
+		if err != nil {
+			t.Fatalf("evaluate %v returned error: %v", input, err)
+		}
+		if len(single) != 1 || want[i][0].Values["fee"] != single[0].Values["fee"] || got[i][0].Values["fee"] != single[0].Values["fee"] {
+			t.Fatalf("input %d %v: expected %v, got batch %v and cached %v", i, input, single, want[i], got[i])
+		}
+	}
+}
+
+func TestEvaluateBatchReportsFailingInput(t *testing.T) {
+	dt := buildBatchTable(t, WithCoercionCache(8))
+	inputs := batchInputs(3)
+	inputs[2]["shipped"] = "not a date"
+	if _, err := dt.EvaluateBatch(inputs, nil); err == nil || !strings.Contains(err.Error(), "input 2:") {
+		t.Fatalf("expected failing input to be reported, got %v", err)
+	}
+	if _, err := NewDecisionTable("bad", nil, nil, WithCoercionCache(-1)); err == nil || !strings.Contains(err.Error(), "coercion cache") {
+		t.Fatalf("expected negative cache size to fail, got %v", err)
+	}
+}
+
+func TestEvaluateAllCollectsPerTableResults(t *testing.T) {
+	strict, err := NewDecisionTable("strict",
+		[]Column{{Name: "amount", Type: ColumnTypeCondition, DataType: DataTypeDecimal}},
+		[]Column{{Name: "flag", Type: ColumnTypeConclusion, DataType: DataTypeString}},
+		WithNoMatchPolicy(NoMatchPolicyThrowError),
+	)
+	if err != nil {
+		t.Fatalf("failed to build table: %v", err)
+	}
+	if err := strict.AddRow(Row{
+		RuleID:      "huge",
+		EvalCells:   []EvalCell{{Column: "amount", Operator: OperatorGreater, Value: "10000"}},
+		ReturnCells: []ReturnCell{{Column: "flag", Value: "review"}},
+	}); err != nil {
+		t.Fatalf("failed to add row: %v", err)
+	}
+	tables := map[string]*DecisionTable{
+		"pricing": buildBatchTable(t),
+		"cached":  buildBatchTable(t, WithCoercionCache(4)),
+		"fraud":   strict,
+		"missing": nil,
+	}
+
+	results, errs := EvaluateAll(map[string]any{"shipped": "2025-06-15", "amount": "250.00"}, tables)
+	if len(results) != 2 || results["pricing"][0].Values["fee"] != "high" || results["cached"][0].Values["fee"] != "high" {
+		t.Fatalf("unexpected results %v", results)
+	}
+	if len(errs) != 2 || errs["fraud"] == nil || errs["missing"] == nil {
+		t.Fatalf("expected fraud and missing to fail, got %v", errs)
+	}
+
+	results, errs = EvaluateAll(map[string]any{"shipped": "2025-06-15", "amount": "20000"}, map[string]*DecisionTable{"fraud": strict})
+	if errs != nil || results["fraud"][0].Values["flag"] != "review" {
+		t.Fatalf("expected fraud to match without errors, got %v %v", results, errs)
+	}
+}
+
+func TestCoercionCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	cache := newCoercionCache(2)
+	key := func(raw string) coercionKey { return coercionKey{dataType: DataTypeDate, raw: raw} }
+	cache.put(key("a"), 1)
+	cache.put(key("b"), 2)
+	if _, ok := cache.get(key("a")); !ok {
+		t.Fatalf("expected a to be cached")
+	}
+	cache.put(key("c"), 3)
+	if _, ok := cache.get(key("b")); ok {
+		t.Fatalf("expected b to be evicted")
+	}
+	if v, ok := cache.get(key("a")); !ok || v != 1 {
+		t.Fatalf("expected a to survive eviction, got %v", v)
+	}
+	if len(cache.entries) != 2 || cache.order.Len() != 2 {
+		t.Fatalf("expected cache bounded to 2 entries, got %d", len(cache.entries))
+	}
+}
+
+func BenchmarkEvaluateBatch(b *testing.B) {
+	inputs := batchInputs(1000)
+	for _, size := range []int{0, 64} {
+		b.Run(fmt.Sprintf("cache=%d", size), func(b *testing.B) {
+			dt := buildBatchTable(b, WithCoercionCache(size))
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, err := dt.EvaluateBatch(inputs, nil); err != nil {
+					b.Fatalf("evaluate batch returned error: %v", err)
+				}
+			}
+		})
+	}
+}
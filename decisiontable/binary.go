@@ -0,0 +1,518 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// binaryFormat versions the MarshalBinary encoding; UnmarshalBinary rejects any other version.
+const binaryFormat = 1
+
+type binaryTable struct {
+	Format        int
+	Name          string
+	Version       string
+	Conditions    []binaryColumn
+	Outputs       []binaryColumn
+	MatchPolicy   MatchPolicy
+	NoMatchPolicy NoMatchPolicy
+	RowValidation RowValidationPolicy
+
+	AllowEmptyCollections bool
+	RejectUnknownKeys     bool
+	AllowNoConditions     bool
+	AllowRepeatedColumns  bool
+	DecimalOutputScale    int
+	RoundDecimalOutputs   bool
+	SparseOutput          bool
+	EchoInput             bool
+	SharedValues          bool
+	RequireExplicitAny    bool
+	MaxStringLength       int
+	VacuousAllEqual       bool
+	ThreeValuedNulls      bool
+	UniquePerGroup        bool
+	ValidateUniqueness    bool
+	CoercionCacheSize     int
+
+	Rows       []binaryRow
+	DefaultRow *binaryRow
+	Guarded    []binaryRow
+}
+
+type binaryColumn struct {
+	Name     string
+	Label    string
+	Type     ColumnType
+	DataType DataType
+	Group    string
+	Min      string
+	Max      string
+	Template bool
+	// HasDefault distinguishes an empty STRING default from no default.
+	HasDefault     bool
+	Default        string
+	Unit           string
+	Required       bool
+	Scale          int
+	Path           string
+	Missing        MissingPolicy
+	Currency       string
+	CurrencyColumn string
+}
+
+type binaryRow struct {
+	RuleID     string
+	Comments   string
+	Number     int
+	Priority   int
+	Disabled   bool
+	Metadata   map[string]string
+	ValidFrom  time.Time
+	ValidUntil time.Time
+	AutoID     bool
+	Group      string
+	Conditions []binaryCondition
+	Returns    []binaryReturn
+}
+
+type binaryCondition struct {
+	Column    string
+	Operator  OperatorType
+	Value     binaryValue
+	Weight    float64
+	Flags     string
+	Interval  string
+	Transform string
+	ValueRef  string
+}
+
+type binaryReturn struct {
+	Column   string
+	Value    binaryValue
+	Modifier ReturnModifier
+}
+
+type binaryKind uint8
+
+const (
+	binaryNil binaryKind = iota
+	binaryString
+	binaryInt
+	binaryBool
+	binaryFloat
+	binaryDecimal
+	binaryTime
+	binaryDuration
+	binaryList
+	binaryRegex
+)
+
+// binaryValue carries one sanitized cell value. Regular expressions travel as their source pattern and
+// are recompiled on decode.
+type binaryValue struct {
+	Kind  binaryKind
+	Text  string
+	Int   int64
+	Float float64
+	Bytes []byte
+	List  []binaryValue
+}
+
+// MarshalBinary encodes the columns, policies, settings and sanitized rows of the table so that a
+// parsed table can be cached and restored with UnmarshalBinary or LoadBinary. Function-valued options
+// (rule ID generator, string normalizer, observer) are not encoded.
+func (dt *DecisionTable) MarshalBinary() ([]byte, error) {
+	if dt == nil {
+		return nil, fmt.Errorf("decision table is nil")
+	}
+	snapshot := binaryTable{
+		Format:                binaryFormat,
+		Name:                  dt.Name,
+		Version:               dt.version,
+		Conditions:            encodeColumns(dt.conditionOrder),
+		Outputs:               encodeColumns(dt.outputOrder),
+		MatchPolicy:           dt.matchPolicy,
+		NoMatchPolicy:         dt.noMatchPolicy,
+		RowValidation:         dt.rowValidation,
+		AllowEmptyCollections: dt.allowEmptyCollections,
+		RejectUnknownKeys:     dt.rejectUnknownKeys,
+		AllowNoConditions:     dt.allowNoConditions,
+		AllowRepeatedColumns:  dt.allowRepeatedColumns,
+		DecimalOutputScale:    dt.decimalOutputScale,
+		RoundDecimalOutputs:   dt.roundDecimalOutputs,
+		SparseOutput:          dt.sparseOutput,
+		EchoInput:             dt.echoInput,
+		SharedValues:          dt.sharedValues,
+		RequireExplicitAny:    dt.requireExplicitAny,
+		MaxStringLength:       dt.maxStringLength,
+		VacuousAllEqual:       dt.vacuousAllEqual,
+		ThreeValuedNulls:      dt.threeValuedNulls,
+		UniquePerGroup:        dt.uniquePerGroup,
+		ValidateUniqueness:    dt.validateUniqueness,
+		CoercionCacheSize:     dt.coercionCacheSize,
+		Rows:                  make([]binaryRow, len(dt.rows)),
+	}
+	for i, row := range dt.rows {
+		encoded, err := encodeRow(row)
+		if err != nil {
+			return nil, fmt.Errorf("rule %s: %w", row.RuleID, err)
+		}
+		snapshot.Rows[i] = encoded
+	}
+	if dt.defaultRow != nil {
+		encoded, err := encodeRow(*dt.defaultRow)
+		if err != nil {
+			return nil, fmt.Errorf("default row: %w", err)
+		}
+		snapshot.DefaultRow = &encoded
+	}
+	for i, row := range dt.guardedDefaults {
+		encoded, err := encodeRow(row)
+		if err != nil {
+			return nil, fmt.Errorf("guarded default %d: %w", i+1, err)
+		}
+		snapshot.Guarded = append(snapshot.Guarded, encoded)
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(snapshot); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary replaces the table with one decoded from MarshalBinary output. Function-valued options
+// already set on the receiver are kept.
+func (dt *DecisionTable) UnmarshalBinary(data []byte) error {
+	if dt == nil {
+		return fmt.Errorf("decision table is nil")
+	}
+	keep := *dt
+	decoded, err := LoadBinary(data, func(target *DecisionTable) {
+		target.ruleIDGenerator = keep.ruleIDGenerator
+		target.stringNormalizer = keep.stringNormalizer
+		target.observer = keep.observer
+	})
+	if err != nil {
+		return err
+	}
+	*dt = *decoded
+	return nil
+}
+
+// LoadBinary restores a table encoded by MarshalBinary. Rows are sanitized again on the way in, so a
+// corrupted payload fails like an invalid source file would. opts are applied after the encoded settings
+// and are the way to supply function-valued options such as WithStringNormalizer.
+func LoadBinary(data []byte, opts ...Option) (*DecisionTable, error) {
+	var snapshot binaryTable
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&snapshot); err != nil {
+		return nil, fmt.Errorf("decode decision table: %w", err)
+	}
+	if snapshot.Format != binaryFormat {
+		return nil, fmt.Errorf("unsupported binary format %d", snapshot.Format)
+	}
+	restore := func(dt *DecisionTable) {
+		dt.version = snapshot.Version
+		dt.matchPolicy = snapshot.MatchPolicy
+		dt.noMatchPolicy = snapshot.NoMatchPolicy
+		dt.rowValidation = snapshot.RowValidation
+		dt.allowEmptyCollections = snapshot.AllowEmptyCollections
+		dt.rejectUnknownKeys = snapshot.RejectUnknownKeys
+		dt.allowNoConditions = snapshot.AllowNoConditions
+		dt.allowRepeatedColumns = snapshot.AllowRepeatedColumns
+		dt.decimalOutputScale = snapshot.DecimalOutputScale
+		dt.roundDecimalOutputs = snapshot.RoundDecimalOutputs
+		dt.sparseOutput = snapshot.SparseOutput
+		dt.echoInput = snapshot.EchoInput
+		dt.sharedValues = snapshot.SharedValues
+		dt.requireExplicitAny = snapshot.RequireExplicitAny
+		dt.maxStringLength = snapshot.MaxStringLength
+		dt.vacuousAllEqual = snapshot.VacuousAllEqual
+		dt.threeValuedNulls = snapshot.ThreeValuedNulls
+		dt.uniquePerGroup = snapshot.UniquePerGroup
+		dt.validateUniqueness = snapshot.ValidateUniqueness
+		dt.coercionCacheSize = snapshot.CoercionCacheSize
+	}
+	dt, err := NewDecisionTable(snapshot.Name, decodeColumns(snapshot.Conditions), decodeColumns(snapshot.Outputs), append([]Option{restore}, opts...)...)
+	if err != nil {
+		return nil, err
+	}
+	for _, encoded := range snapshot.Rows {
+		row, err := decodeRow(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("rule %s: %w", encoded.RuleID, err)
+		}
+		if err := dt.AddRow(row); err != nil {
+			return nil, fmt.Errorf("rule %s: %w", encoded.RuleID, err)
+		}
+	}
+	if snapshot.DefaultRow != nil {
+		row, err := decodeRow(*snapshot.DefaultRow)
+		if err != nil {
+			return nil, fmt.Errorf("default row: %w", err)
+		}
+		if err := dt.SetDefaultRow(row); err != nil {
+			return nil, fmt.Errorf("default row: %w", err)
+		}
+	}
+	for i, encoded := range snapshot.Guarded {
+		row, err := decodeRow(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("guarded default %d: %w", i+1, err)
+		}
+		if err := dt.AddGuardedDefault(row.EvalCells, row.ReturnCells); err != nil {
+			return nil, err
+		}
+	}
+	return dt, nil
+}
+
+func encodeColumns(cols []Column) []binaryColumn {
+	out := make([]binaryColumn, len(cols))
+	for i, col := range cols {
+		out[i] = binaryColumn{
+			Name:           col.Name,
+			Label:          col.Label,
+			Type:           col.Type,
+			DataType:       col.DataType,
+			Group:          col.Group,
+			Min:            formatBound(col, col.Min),
+			Max:            formatBound(col, col.Max),
+			Template:       col.Template,
+			Unit:           col.Unit,
+			Required:       col.Required,
+			Scale:          col.Scale,
+			Path:           col.Path,
+			Missing:        col.MissingPolicy,
+			Currency:       col.Currency,
+			CurrencyColumn: col.CurrencyColumn,
+		}
+		if col.Default != nil {
+			out[i].HasDefault, out[i].Default = true, formatBound(col, col.Default)
+		}
+	}
+	return out
+}
+
+func decodeColumns(cols []binaryColumn) []Column {
+	out := make([]Column, len(cols))
+	for i, col := range cols {
+		out[i] = Column{
+			Name:           col.Name,
+			Label:          col.Label,
+			Type:           col.Type,
+			DataType:       col.DataType,
+			Group:          col.Group,
+			Template:       col.Template,
+			Unit:           col.Unit,
+			Required:       col.Required,
+			Scale:          col.Scale,
+			Path:           col.Path,
+			MissingPolicy:  col.Missing,
+			Currency:       col.Currency,
+			CurrencyColumn: col.CurrencyColumn,
+		}
+		if col.Min != "" {
+			out[i].Min = col.Min
+		}
+		if col.Max != "" {
+			out[i].Max = col.Max
+		}
+		if col.HasDefault {
+			out[i].Default = col.Default
+		}
+	}
+	return out
+}
+
+func encodeRow(row Row) (binaryRow, error) {
+	out := binaryRow{
+		RuleID:     row.RuleID,
+		Comments:   row.Comments,
+		Number:     row.Number,
+		Priority:   row.Priority,
+		Disabled:   row.Disabled,
+		Metadata:   row.Metadata,
+		Group:      row.Group,
+		ValidFrom:  row.ValidFrom,
+		ValidUntil: row.ValidUntil,
+		AutoID:     row.autoID,
+		Conditions: make([]binaryCondition, len(row.EvalCells)),
+		Returns:    make([]binaryReturn, len(row.ReturnCells)),
+	}
+	for i, cell := range row.EvalCells {
+		value, err := encodeValue(cell.Value)
+		if err != nil {
+			return binaryRow{}, fmt.Errorf("column %s: %w", cell.Column, err)
+		}
+		if re, ok := cell.Value.(*regexp.Regexp); ok && cell.Flags != "" {
+			// the flags are re-applied when the pattern is compiled again
+			value.Text = strings.TrimPrefix(re.String(), "(?"+cell.Flags+")")
+		}
+		out.Conditions[i] = binaryCondition{
+			Column:    cell.Column,
+			Operator:  cell.Operator,
+			Value:     value,
+			Weight:    cell.Weight,
+			Flags:     cell.Flags,
+			Interval:  cell.Interval,
+			Transform: cell.Transform,
+			ValueRef:  cell.ValueRef,
+		}
+	}
+	for i, cell := range row.ReturnCells {
+		value, err := encodeValue(cell.Value)
+		if err != nil {
+			return binaryRow{}, fmt.Errorf("return column %s: %w", cell.Column, err)
+		}
+		out.Returns[i] = binaryReturn{Column: cell.Column, Value: value, Modifier: cell.Modifier}
+	}
+	return out, nil
+}
+
+func decodeRow(row binaryRow) (Row, error) {
+	out := Row{
+		RuleID:     row.RuleID,
+		Comments:   row.Comments,
+		Number:     row.Number,
+		Priority:   row.Priority,
+		Disabled:   row.Disabled,
+		Metadata:   row.Metadata,
+		Group:      row.Group,
+		ValidFrom:  row.ValidFrom,
+		ValidUntil: row.ValidUntil,
+		autoID:     row.AutoID,
+	}
+	for _, cell := range row.Conditions {
+		value, err := decodeValue(cell.Value)
+		if err != nil {
+			return Row{}, fmt.Errorf("column %s: %w", cell.Column, err)
+		}
+		out.EvalCells = append(out.EvalCells, EvalCell{
+			Column:    cell.Column,
+			Operator:  cell.Operator,
+			Value:     value,
+			Weight:    cell.Weight,
+			Flags:     cell.Flags,
+			Interval:  cell.Interval,
+			Transform: cell.Transform,
+			ValueRef:  cell.ValueRef,
+		})
+	}
+	for _, cell := range row.Returns {
+		value, err := decodeValue(cell.Value)
+		if err != nil {
+			return Row{}, fmt.Errorf("return column %s: %w", cell.Column, err)
+		}
+		out.ReturnCells = append(out.ReturnCells, ReturnCell{Column: cell.Column, Value: value, Modifier: cell.Modifier})
+	}
+	return out, nil
+}
+
+func encodeValue(v any) (binaryValue, error) {
+	switch val := v.(type) {
+	case nil:
+		return binaryValue{Kind: binaryNil}, nil
+	case string:
+		return binaryValue{Kind: binaryString, Text: val}, nil
+	case int64:
+		return binaryValue{Kind: binaryInt, Int: val}, nil
+	case int:
+		return binaryValue{Kind: binaryInt, Int: int64(val)}, nil
+	case bool:
+		if val {
+			return binaryValue{Kind: binaryBool, Int: 1}, nil
+		}
+		return binaryValue{Kind: binaryBool}, nil
+	case float64:
+		return binaryValue{Kind: binaryFloat, Float: val}, nil
+	case *big.Float:
+		data, err := val.GobEncode()
+		if err != nil {
+			return binaryValue{}, err
+		}
+		return binaryValue{Kind: binaryDecimal, Bytes: data}, nil
+	case time.Time:
+		data, err := val.MarshalBinary()
+		if err != nil {
+			return binaryValue{}, err
+		}
+		return binaryValue{Kind: binaryTime, Bytes: data}, nil
+	case time.Duration:
+		return binaryValue{Kind: binaryDuration, Int: int64(val)}, nil
+	case *regexp.Regexp:
+		return binaryValue{Kind: binaryRegex, Text: val.String()}, nil
+	case []any:
+		list := make([]binaryValue, len(val))
+		for i, item := range val {
+			encoded, err := encodeValue(item)
+			if err != nil {
+				return binaryValue{}, err
+			}
+			list[i] = encoded
+		}
+		return binaryValue{Kind: binaryList, List: list}, nil
+	default:
+		return binaryValue{}, fmt.Errorf("cannot encode value of type %T", v)
+	}
+}
+
+func decodeValue(v binaryValue) (any, error) {
+	switch v.Kind {
+	case binaryNil:
+		return nil, nil
+	case binaryString, binaryRegex:
+		return v.Text, nil
+	case binaryInt:
+		return v.Int, nil
+	case binaryBool:
+		return v.Int != 0, nil
+	case binaryFloat:
+		return v.Float, nil
+	case binaryDecimal:
+		f := new(big.Float)
+		if err := f.GobDecode(v.Bytes); err != nil {
+			return nil, err
+		}
+		return f, nil
+	case binaryTime:
+		var ts time.Time
+		if err := ts.UnmarshalBinary(v.Bytes); err != nil {
+			return nil, err
+		}
+		return ts, nil
+	case binaryDuration:
+		return time.Duration(v.Int), nil
+	case binaryList:
+		list := make([]any, len(v.List))
+		for i, item := range v.List {
+			decoded, err := decodeValue(item)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = decoded
+		}
+		return list, nil
+	default:
+		return nil, fmt.Errorf("unknown value kind %d", v.Kind)
+	}
+}
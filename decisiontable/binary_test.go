@@ -0,0 +1,131 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestBinaryRoundTripPreservesEvaluation(t *testing.T) {
+	dt, err := NewDecisionTable("offers",
+		[]Column{
+			{Name: "score", Type: ColumnTypeCondition, DataType: DataTypeInteger, Min: 0, Max: 1000},
+			{Name: "ratio", Type: ColumnTypeCondition, DataType: DataTypeDecimal},
+			{Name: "code", Type: ColumnTypeCondition, DataType: DataTypeString},
+			{Name: "since", Type: ColumnTypeCondition, DataType: DataTypeDate},
+			{Name: "wait", Type: ColumnTypeCondition, DataType: DataTypeDuration},
+		},
+		[]Column{
+			{Name: "rate", Type: ColumnTypeConclusion, DataType: DataTypeDecimal, Group: "pricing"},
+			{Name: "tags", Type: ColumnTypeConclusion, DataType: DataTypeListString},
+		},
+		WithMatchPolicy(MatchPolicyPriority), WithVersion("2024.1"), WithDecimalOutputScale(2))
+	if err != nil {
+		t.Fatalf("new table: %v", err)
+	}
+	rows := []Row{
+		{RuleID: "vip", Priority: 5, EvalCells: []EvalCell{
+			{Column: "score", Operator: OperatorBetween, Value: []any{700, 1000}},
+			{Column: "code", Operator: OperatorMatchesRegex, Value: "^vip-", Flags: "i"},
+		}, ReturnCells: []ReturnCell{{Column: "rate", Value: "0.0125"}, {Column: "tags", Value: []any{"vip", "gold"}}}},
+		{RuleID: "loyal", Priority: 3, EvalCells: []EvalCell{
+			{Column: "since", Operator: OperatorLess, Value: "2020-01-01"},
+			{Column: "ratio", Operator: OperatorIn, Value: []any{"0.1", "0.2"}},
+		}, ReturnCells: []ReturnCell{{Column: "rate", Value: "0.02"}}},
+		{RuleID: "fast", Priority: 9, Disabled: true, EvalCells: []EvalCell{
+			{Column: "wait", Operator: OperatorLess, Value: "1s"},
+		}, ReturnCells: []ReturnCell{{Column: "rate", Value: "0.5"}}},
+	}
+	for _, row := range rows {
+		if err := dt.AddRow(row); err != nil {
+			t.Fatalf("add row: %v", err)
+		}
+	}
+	if err := dt.SetDefaultRow(Row{RuleID: "base", ReturnCells: []ReturnCell{{Column: "rate", Value: "0.05"}}}); err != nil {
+		t.Fatalf("set default: %v", err)
+	}
+
+	data, err := dt.MarshalBinary()
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	restored := &DecisionTable{}
+	if err := restored.UnmarshalBinary(data); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if err := dt.EqualErr(restored); err != nil {
+		t.Fatalf("restored table differs: %v", err)
+	}
+
+	since := time.Date(2019, 5, 1, 0, 0, 0, 0, time.UTC)
+	inputs := []map[string]any{
+		{"score": 750, "ratio": "0.1", "code": "VIP-7", "since": since, "wait": "10ms"},
+		{"score": 750, "ratio": "0.2", "code": "std", "since": since, "wait": "10ms"},
+		{"score": 100, "ratio": "0.3", "code": "std", "since": since, "wait": "10ms"},
+		{"score": 2000},
+	}
+	for _, input := range inputs {
+		want, wantErr := dt.Evaluate(input, nil)
+		got, gotErr := restored.Evaluate(input, nil)
+		if fmt.Sprint(wantErr) != fmt.Sprint(gotErr) {
+			t.Fatalf("input %v: error %v, want %v", input, gotErr, wantErr)
+		}
+		if summarize(got) != summarize(want) {
+			t.Fatalf("input %v: got %s, want %s", input, summarize(got), summarize(want))
+		}
+	}
+
+	unique, err := NewDecisionTable("bands",
+		[]Column{{Name: "score", Type: ColumnTypeCondition, DataType: DataTypeInteger}},
+		[]Column{{Name: "band", Type: ColumnTypeConclusion, DataType: DataTypeString}},
+		WithMatchPolicy(MatchPolicyUnique), WithValidateUniqueness(), WithCoercionCache(64))
+	if err != nil {
+		t.Fatalf("new unique table: %v", err)
+	}
+	if err := unique.AddRow(Row{RuleID: "high", EvalCells: []EvalCell{{Column: "score", Operator: OperatorGreater, Value: 10}},
+		ReturnCells: []ReturnCell{{Column: "band", Value: "high"}}}); err != nil {
+		t.Fatalf("add row: %v", err)
+	}
+	data, err = unique.MarshalBinary()
+	if err != nil {
+		t.Fatalf("marshal unique table: %v", err)
+	}
+	reloaded, err := LoadBinary(data)
+	if err != nil {
+		t.Fatalf("load unique table: %v", err)
+	}
+	if err := reloaded.AddRow(Row{RuleID: "higher", EvalCells: []EvalCell{{Column: "score", Operator: OperatorGreater, Value: 20}},
+		ReturnCells: []ReturnCell{{Column: "band", Value: "higher"}}}); err == nil {
+		t.Fatalf("expected the reloaded table to keep rejecting overlapping rules")
+	}
+	if reloaded.coercionCacheSize != 64 {
+		t.Fatalf("expected the coercion cache size to survive, got %d", reloaded.coercionCacheSize)
+	}
+
+	if _, err := LoadBinary([]byte("not gob")); err == nil {
+		t.Fatalf("expected garbage input to fail")
+	}
+}
+
+func summarize(rows []MatchedRow) string {
+	var out string
+	for _, row := range rows {
+		out += fmt.Sprintf("%s|%d|%v|%s|%s|%s;", row.RuleID, row.RowNumber, row.IsDefault,
+			formatValue(DataTypeDecimal, row.Values["rate"]), formatValue(DataTypeListString, row.Values["tags"]), row.Explain())
+	}
+	return out
+}
@@ -0,0 +1,135 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+)
+
+// splitReturnModifier extracts the modifier of a return cell, either set explicitly or written as a
+// "+= 0.05" style string on an INTEGER or DECIMAL column, and returns the remaining operand.
+func splitReturnModifier(dt DataType, cell ReturnCell) (ReturnModifier, any, error) {
+	switch cell.Modifier {
+	case ModifierNone:
+	case ModifierAdd, ModifierSubtract, ModifierMultiply:
+		if dt != DataTypeInteger && dt != DataTypeDecimal {
+			return ModifierNone, nil, fmt.Errorf("modifier %s requires a numeric column", cell.Modifier)
+		}
+		return cell.Modifier, cell.Value, nil
+	default:
+		return ModifierNone, nil, fmt.Errorf("unknown modifier %q", cell.Modifier)
+	}
+
+	str, ok := cell.Value.(string)
+	if !ok || (dt != DataTypeInteger && dt != DataTypeDecimal) {
+		return ModifierNone, cell.Value, nil
+	}
+	trimmed := strings.TrimSpace(str)
+	for _, mod := range []ReturnModifier{ModifierAdd, ModifierSubtract, ModifierMultiply} {
+		if strings.HasPrefix(trimmed, string(mod)) {
+			operand := strings.TrimSpace(strings.TrimPrefix(trimmed, string(mod)))
+			if operand == "" {
+				return ModifierNone, nil, fmt.Errorf("modifier %s requires an operand", mod)
+			}
+			return mod, operand, nil
+		}
+	}
+	return ModifierNone, cell.Value, nil
+}
+
+// collectRows folds matched rows in table order. Every column starts unset; a plain cell replaces the
+// accumulated value and a modifier cell adjusts it, treating an unset or null value as zero.
+func collectRows(rows []Row) (MatchedRow, error) {
+	result := MatchedRow{
+		Values:       make(map[string]any),
+		MatchedRules: make([]string, 0, len(rows)),
+	}
+	for _, row := range rows {
+		for _, cell := range row.ReturnCells {
+			if cell.Modifier == ModifierNone {
+				result.Values[cell.Column] = cloneValueForType(cell.Value, cell.dataType)
+				continue
+			}
+			next, err := applyModifier(cell.dataType, cell.Modifier, result.Values[cell.Column], cell.Value)
+			if err != nil {
+				return MatchedRow{}, fmt.Errorf("row %d column %s: %w", row.Number, cell.Column, err)
+			}
+			result.Values[cell.Column] = next
+		}
+		result.MatchedRules = append(result.MatchedRules, row.RuleID)
+	}
+	return result, nil
+}
+
+func applyModifier(dt DataType, mod ReturnModifier, current any, operand any) (any, error) {
+	switch dt {
+	case DataTypeInteger:
+		var acc int64
+		if current != nil {
+			v, ok := current.(int64)
+			if !ok {
+				return nil, fmt.Errorf("accumulated value not int64: %T", current)
+			}
+			acc = v
+		}
+		delta, ok := operand.(int64)
+		if !ok {
+			return nil, fmt.Errorf("operand not int64: %T", operand)
+		}
+		switch mod {
+		case ModifierAdd:
+			if (delta > 0 && acc > math.MaxInt64-delta) || (delta < 0 && acc < math.MinInt64-delta) {
+				return nil, fmt.Errorf("modifier %s overflows int64", mod)
+			}
+			return acc + delta, nil
+		case ModifierSubtract:
+			if (delta < 0 && acc > math.MaxInt64+delta) || (delta > 0 && acc < math.MinInt64+delta) {
+				return nil, fmt.Errorf("modifier %s overflows int64", mod)
+			}
+			return acc - delta, nil
+		case ModifierMultiply:
+			product := acc * delta
+			if acc != 0 && (product/acc != delta || (acc == -1 && delta == math.MinInt64)) {
+				return nil, fmt.Errorf("modifier %s overflows int64", mod)
+			}
+			return product, nil
+		}
+	case DataTypeDecimal:
+		acc := new(big.Float).SetPrec(decimalPrecision)
+		if current != nil {
+			v, ok := current.(*big.Float)
+			if !ok {
+				return nil, fmt.Errorf("accumulated value not decimal: %T", current)
+			}
+			acc.Set(v)
+		}
+		delta, ok := operand.(*big.Float)
+		if !ok {
+			return nil, fmt.Errorf("operand not decimal: %T", operand)
+		}
+		switch mod {
+		case ModifierAdd:
+			return acc.Add(acc, delta), nil
+		case ModifierSubtract:
+			return acc.Sub(acc, delta), nil
+		case ModifierMultiply:
+			return acc.Mul(acc, delta), nil
+		}
+	}
+	return nil, fmt.Errorf("modifier %s not supported for data type %s", mod, dt)
+}
@@ -0,0 +1,138 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import (
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func buildCollectTable(t *testing.T) *DecisionTable {
+	t.Helper()
+	evalCols := []Column{
+		{Name: "tier", Type: ColumnTypeCondition, DataType: DataTypeString},
+		{Name: "years", Type: ColumnTypeCondition, DataType: DataTypeInteger},
+	}
+	retCols := []Column{
+		{Name: "discount", Type: ColumnTypeConclusion, DataType: DataTypeDecimal},
+		{Name: "points", Type: ColumnTypeConclusion, DataType: DataTypeInteger},
+	}
+	dt, err := NewDecisionTable("loyalty", evalCols, retCols, WithMatchPolicy(MatchPolicyCollect))
+	if err != nil {
+		t.Fatalf("failed to build table: %v", err)
+	}
+	rows := []Row{
+		{
+			RuleID:    "base",
+			EvalCells: []EvalCell{{Column: "years", Operator: OperatorGreaterOrEqual, Value: 0}},
+			ReturnCells: []ReturnCell{
+				{Column: "discount", Value: "0.10"},
+				{Column: "points", Value: 100},
+			},
+		},
+		{
+			RuleID:    "vip",
+			EvalCells: []EvalCell{{Column: "tier", Operator: OperatorEqual, Value: "VIP"}},
+			ReturnCells: []ReturnCell{
+				{Column: "discount", Value: "+= 0.05"},
+				{Column: "points", Value: 2, Modifier: ModifierMultiply},
+			},
+		},
+		{
+			RuleID:    "veteran",
+			EvalCells: []EvalCell{{Column: "years", Operator: OperatorGreater, Value: 5}},
+			ReturnCells: []ReturnCell{
+				{Column: "discount", Value: "-= 0.01"},
+				{Column: "points", Value: "+= 50"},
+			},
+		},
+	}
+	for _, row := range rows {
+		if err := dt.AddRow(row); err != nil {
+			t.Fatalf("failed to add row %s: %v", row.RuleID, err)
+		}
+	}
+	return dt
+}
+
+func TestCollectAccumulatesModifiers(t *testing.T) {
+	dt := buildCollectTable(t)
+
+	rows, err := dt.Evaluate(map[string]any{"tier": "VIP", "years": 7}, nil)
+	if err != nil {
+		t.Fatalf("evaluate returned error: %v", err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("expected a single collected row, got %d", len(rows))
+	}
+	if !reflect.DeepEqual(rows[0].MatchedRules, []string{"base", "vip", "veteran"}) {
+		t.Fatalf("unexpected contributing rules %v", rows[0].MatchedRules)
+	}
+	discount, ok := rows[0].Values["discount"].(*big.Float)
+	want, _ := new(big.Float).SetPrec(decimalPrecision).SetString("0.14")
+	if !ok || discount.Text('f', 2) != want.Text('f', 2) {
+		t.Fatalf("expected discount 0.14, got %v", rows[0].Values["discount"])
+	}
+	if rows[0].Values["points"] != int64(250) {
+		t.Fatalf("expected points 250, got %v", rows[0].Values["points"])
+	}
+}
+
+func TestCollectModifierStartsFromZero(t *testing.T) {
+	dt := buildCollectTable(t)
+	dt.rows = dt.rows[1:]
+
+	rows, err := dt.Evaluate(map[string]any{"tier": "VIP", "years": 7}, nil)
+	if err != nil {
+		t.Fatalf("evaluate returned error: %v", err)
+	}
+	if rows[0].Values["points"] != int64(50) {
+		t.Fatalf("expected points to start from zero, got %v", rows[0].Values["points"])
+	}
+}
+
+func TestModifierRequiresCollectPolicy(t *testing.T) {
+	dt := buildSampleTable(t)
+	err := dt.AddRow(Row{
+		EvalCells:   []EvalCell{{Column: "age", Operator: OperatorGreater, Value: 1}},
+		ReturnCells: []ReturnCell{{Column: "discount", Value: "+= 0.05"}},
+	})
+	if err == nil || !strings.Contains(err.Error(), "COLLECT") {
+		t.Fatalf("expected modifier to be rejected outside COLLECT, got %v", err)
+	}
+}
+
+func TestCollectDefaultRowAppliesModifiers(t *testing.T) {
+	dt := buildCollectTable(t)
+	if err := dt.SetDefaultRow(Row{ReturnCells: []ReturnCell{
+		{Column: "discount", Value: "0.00"},
+		{Column: "points", Value: "-= 10"},
+	}}); err != nil {
+		t.Fatalf("set default row: %v", err)
+	}
+
+	rows, err := dt.Evaluate(map[string]any{"tier": "NEW", "years": -1}, nil)
+	if err != nil {
+		t.Fatalf("evaluate returned error: %v", err)
+	}
+	if len(rows) != 1 || !rows[0].IsDefault {
+		t.Fatalf("expected the default row, got %#v", rows)
+	}
+	if rows[0].Values["points"] != int64(-10) {
+		t.Fatalf("expected the modifier to apply to an unset value, got %v", rows[0].Values["points"])
+	}
+}
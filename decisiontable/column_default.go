@@ -0,0 +1,40 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import "fmt"
+
+// defaultValue coerces the column's Default through its data type and checks it against the column
+// range. It returns nil when the column declares no default.
+func (c Column) defaultValue(valueRange *columnRange) (any, error) {
+	if c.Default == nil {
+		return nil, nil
+	}
+	if c.Type != ColumnTypeCondition {
+		return nil, fmt.Errorf("column %s: only condition columns can declare a default", c.Name)
+	}
+	raw := c.Default
+	if unit, _ := c.unitParser(); unit != nil {
+		raw = applyUnit(unit, raw)
+	}
+	value, err := sanitizeExpectedValue(c.DataType, OperatorEqual, raw, "", "", false)
+	if err != nil {
+		return nil, fmt.Errorf("column %s: default: %w", c.Name, err)
+	}
+	if err := valueRange.check(value); err != nil {
+		return nil, fmt.Errorf("column %s: default: %w", c.Name, err)
+	}
+	return value, nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// columnRange holds the inclusive Min/Max bounds of a numeric column. A nil bound is open.
+type columnRange struct {
+	min *big.Float
+	max *big.Float
+}
+
+// valueRange coerces the column's Min and Max through its data type. It returns nil when the column
+// declares neither bound.
+func (c Column) valueRange() (*columnRange, error) {
+	if c.Min == nil && c.Max == nil {
+		return nil, nil
+	}
+	if c.DataType != DataTypeInteger && c.DataType != DataTypeDecimal {
+		return nil, fmt.Errorf("column %s: min/max require an INTEGER or DECIMAL data type, got %s", c.Name, c.DataType)
+	}
+	r := &columnRange{}
+	var err error
+	if r.min, err = rangeBound(c.DataType, c.Min); err != nil {
+		return nil, fmt.Errorf("column %s: min: %w", c.Name, err)
+	}
+	if r.max, err = rangeBound(c.DataType, c.Max); err != nil {
+		return nil, fmt.Errorf("column %s: max: %w", c.Name, err)
+	}
+	if r.min != nil && r.max != nil && r.min.Cmp(r.max) > 0 {
+		return nil, fmt.Errorf("column %s: min %s is greater than max %s", c.Name, r.min.Text('g', -1), r.max.Text('g', -1))
+	}
+	return r, nil
+}
+
+func rangeBound(dt DataType, raw any) (*big.Float, error) {
+	if raw == nil {
+		return nil, nil
+	}
+	coerced, err := coercePrimitive(dt, raw)
+	if err != nil {
+		return nil, err
+	}
+	return toBigFloat(coerced)
+}
+
+// check reports an error when v, or any element of a list value, lies outside the range.
+// Values are expected to be coerced already; nil and non-numeric values are ignored.
+func (r *columnRange) check(v any) error {
+	if r == nil {
+		return nil
+	}
+	switch val := v.(type) {
+	case []any:
+		for _, elem := range val {
+			if err := r.check(elem); err != nil {
+				return err
+			}
+		}
+		return nil
+	case int64, *big.Float:
+		f, err := toBigFloat(val)
+		if err != nil {
+			return err
+		}
+		if (r.min != nil && f.Cmp(r.min) < 0) || (r.max != nil && f.Cmp(r.max) > 0) {
+			return fmt.Errorf("value %s outside column range %s", f.Text('g', -1), r)
+		}
+		return nil
+	default:
+		return nil
+	}
+}
+
+func (r *columnRange) String() string {
+	lo, hi := "-inf", "+inf"
+	if r.min != nil {
+		lo = r.min.Text('g', -1)
+	}
+	if r.max != nil {
+		hi = r.max.Text('g', -1)
+	}
+	return fmt.Sprintf("[%s, %s]", lo, hi)
+}
@@ -0,0 +1,120 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import (
+	"fmt"
+	"slices"
+)
+
+var builtinDataTypes = []DataType{
+	DataTypeString,
+	DataTypeInteger,
+	DataTypeBoolean,
+	DataTypeDecimal,
+	DataTypeDate,
+	DataTypeDateTime,
+	DataTypeDuration,
+	DataTypeListString,
+	DataTypeListInteger,
+	DataTypeListDecimal,
+}
+
+// SupportedDataTypes lists the column data types the engine understands.
+func SupportedDataTypes() []DataType {
+	return slices.Clone(builtinDataTypes)
+}
+
+// SupportedOperators lists the built-in operators, then every aggregate and LENGTH comparison, then
+// the operators registered with RegisterOperator in name order.
+func SupportedOperators() []OperatorType {
+	ops := slices.Clone(builtinOperators)
+	for _, agg := range aggregateFamilies {
+		for _, cmp := range aggregateComparisons {
+			ops = append(ops, OperatorType(agg+"_"+string(cmp)))
+		}
+	}
+	operatorRegistry.RLock()
+	custom := make([]OperatorType, 0, len(operatorRegistry.funcs))
+	for name := range operatorRegistry.funcs {
+		custom = append(custom, name)
+	}
+	operatorRegistry.RUnlock()
+	slices.Sort(custom)
+	return append(ops, custom...)
+}
+
+// CompatibleOperators lists the operators of SupportedOperators that a column of type dt accepts, as
+// the loaders check them. It returns nil for an unknown data type.
+func CompatibleOperators(dt DataType) []OperatorType {
+	if !slices.Contains(builtinDataTypes, dt) {
+		return nil
+	}
+	var ops []OperatorType
+	for _, op := range SupportedOperators() {
+		if checkOperatorDataType(op, dt) == nil {
+			ops = append(ops, op)
+		}
+	}
+	return ops
+}
+
+// checkOperatorDataType reports whether a built-in operator can be applied to a column of type dt,
+// so that the loaders reject a rule such as CONTAINS_ALL on a STRING column when it is read instead
+// of when it is first evaluated. Custom operators accept every data type.
+func checkOperatorDataType(op OperatorType, dt DataType) error {
+	if isCustomOperator(op) {
+		return nil
+	}
+	list := isListDataType(dt)
+	var ok bool
+	switch op {
+	case OperatorEqual, OperatorNotEqual, OperatorIsNull, OperatorIsNotNull, OperatorDefault:
+		ok = true
+	case OperatorGreater, OperatorGreaterOrEqual, OperatorLess, OperatorLessOrEqual, OperatorBetween, OperatorNotBetween:
+		ok = isOrderedDataType(dt)
+	case OperatorIn, OperatorNotIn:
+		ok = !list
+	case OperatorMatchesRegex:
+		ok = dt == DataTypeString
+	case OperatorTextEqual:
+		ok = dt == DataTypeDecimal
+	case OperatorAnyContained, OperatorNotAnyContained, OperatorAllContained, OperatorNotAllContained,
+		OperatorContainsAll, OperatorNotContainsAll, OperatorSetEquals, OperatorMultisetContained, OperatorAllEqual:
+		ok = list
+	default:
+		agg, _, isAggregate := splitAggregateOperator(op)
+		switch {
+		case !isAggregate:
+		case agg == "LENGTH":
+			ok = dt == DataTypeString
+		default:
+			ok = dt == DataTypeListInteger || dt == DataTypeListDecimal || (agg == "COUNT" && dt == DataTypeListString)
+		}
+	}
+	if !ok {
+		return fmt.Errorf("operator %s not supported for %s columns", op, dt)
+	}
+	return nil
+}
+
+func isOrderedDataType(dt DataType) bool {
+	switch dt {
+	case DataTypeInteger, DataTypeDecimal, DataTypeDate, DataTypeDateTime, DataTypeDuration:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,267 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// SaveCSVFile writes the table to path using the CSV layout understood by LoadCSVFile.
+func SaveCSVFile(dt *DecisionTable, path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("create csv file %s: %w", path, err)
+	}
+	if err := WriteCSV(dt, f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// WriteCSV renders the table in the CSV layout understood by LoadCSV. Condition cells are written in
+// their textual operator form and null return values as empty fields. Tables with cell weights, or
+// with a rule Priority that no priority metadata column holds, are rejected rather than written lossily.
+func WriteCSV(dt *DecisionTable, w io.Writer) error {
+	if dt == nil {
+		return fmt.Errorf("decision table is nil")
+	}
+	if len(dt.guardedDefaults) > 0 {
+		return fmt.Errorf("guarded default rows cannot be written to the csv layout")
+	}
+	ordered := append(dt.ConditionColumns(), dt.OutputColumns()...)
+
+	names := []string{csvNameMarker, csvRuleIDHeader, csvDescriptionHeader}
+	types := []string{csvTypeMarker, "", ""}
+	dataTypes := []string{csvDataTypeMarker, "", ""}
+	labels := []string{attributeLabel, "", ""}
+	mins := []string{attributeMin, "", ""}
+	maxes := []string{attributeMax, "", ""}
+	templates := []string{attributeTemplate, "", ""}
+	defaults := []string{attributeDefault, "", ""}
+	units := []string{attributeUnit, "", ""}
+	required := []string{attributeRequired, "", ""}
+	scales := []string{attributeScale, "", ""}
+	paths := []string{attributePath, "", ""}
+	missing := []string{attributeMissing, "", ""}
+	currencies := []string{attributeCurrency, "", ""}
+	currencyColumns := []string{attributeCurrencyColumn, "", ""}
+	hasLabels, hasMin, hasMax, hasTemplates, hasDefaults, hasUnits, hasRequired, hasScales, hasPaths, hasMissing, hasCurrencies := false, false, false, false, false, false, false, false, false, false, false
+	for _, col := range ordered {
+		names = append(names, col.Name)
+		types = append(types, string(col.Type))
+		dataTypes = append(dataTypes, string(col.DataType))
+		labels = append(labels, col.Label)
+		mins = append(mins, formatBound(col, col.Min))
+		maxes = append(maxes, formatBound(col, col.Max))
+		templates = append(templates, formatTemplateFlag(col))
+		defaults = append(defaults, formatBound(col, col.Default))
+		units = append(units, col.Unit)
+		required = append(required, formatRequiredFlag(col))
+		scales = append(scales, formatScale(col))
+		paths = append(paths, col.Path)
+		missing = append(missing, string(col.MissingPolicy))
+		currencies = append(currencies, col.Currency)
+		currencyColumns = append(currencyColumns, col.CurrencyColumn)
+		hasLabels = hasLabels || col.Label != ""
+		hasMin = hasMin || col.Min != nil
+		hasMax = hasMax || col.Max != nil
+		hasTemplates = hasTemplates || col.Template
+		hasDefaults = hasDefaults || col.Default != nil
+		hasUnits = hasUnits || col.Unit != ""
+		hasRequired = hasRequired || col.Required
+		hasScales = hasScales || col.Scale != 0
+		hasPaths = hasPaths || col.Path != ""
+		hasMissing = hasMissing || col.MissingPolicy != ""
+		hasCurrencies = hasCurrencies || col.Currency != ""
+	}
+
+	records := [][]string{
+		{csvTableMarker, dt.Name},
+		{csvMatchPolicyMarker, dt.matchPolicy.String()},
+		{csvNoMatchMarker, dt.noMatchPolicy.String()},
+		names,
+		types,
+		dataTypes,
+	}
+	if hasLabels {
+		records = append(records, labels)
+	}
+	if hasMin {
+		records = append(records, mins)
+	}
+	if hasMax {
+		records = append(records, maxes)
+	}
+	if hasTemplates {
+		records = append(records, templates)
+	}
+	if hasDefaults {
+		records = append(records, defaults)
+	}
+	if hasUnits {
+		records = append(records, units)
+	}
+	if hasRequired {
+		records = append(records, required)
+	}
+	if hasScales {
+		records = append(records, scales)
+	}
+	if hasPaths {
+		records = append(records, paths)
+	}
+	if hasMissing {
+		records = append(records, missing)
+	}
+	if hasCurrencies {
+		records = append(records, currencies, currencyColumns)
+	}
+	for _, row := range dt.rows {
+		record, err := formatTabularRow(csvRuleMarker, row, dt.conditionOrder, dt.outputOrder, dt.requiresExplicitAny())
+		if err != nil {
+			return fmt.Errorf("rule %s: %w", row.RuleID, err)
+		}
+		records = append(records, record)
+	}
+	if dt.defaultRow != nil {
+		record, err := formatTabularRow(csvDefaultRowMarker, *dt.defaultRow, dt.conditionOrder, dt.outputOrder, false)
+		if err != nil {
+			return fmt.Errorf("default row: %w", err)
+		}
+		records = append(records, record)
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.WriteAll(records); err != nil {
+		return fmt.Errorf("write csv: %w", err)
+	}
+	return nil
+}
+
+// formatTabularRow lays out a row's cells aligned to the given column order, prefixed by the marker,
+// rule ID and description fields. Unconstrained condition cells are blank, or * when explicitAny is set.
+// A Priority is only kept through a "priority" METADATA column, and cell weights have no tabular form.
+func formatTabularRow(marker string, row Row, conditions, outputs []Column, explicitAny bool) ([]string, error) {
+	if row.Priority != 0 && !carriesPriority(row, outputs) {
+		return nil, fmt.Errorf("priority %d cannot be exported without a priority metadata column holding it", row.Priority)
+	}
+	record := []string{marker, row.RuleID, row.Comments}
+	seen := make(map[string]struct{}, len(row.EvalCells))
+	for _, cell := range row.EvalCells {
+		if _, dup := seen[cell.Column]; dup {
+			return nil, fmt.Errorf("column %s has more than one condition and cannot be exported", cell.Column)
+		}
+		seen[cell.Column] = struct{}{}
+	}
+	cells := row.ConditionsByColumn(conditions)
+	for i, col := range conditions {
+		cell := cells[i]
+		if cell == nil {
+			if explicitAny {
+				record = append(record, anyMarker)
+			} else {
+				record = append(record, "")
+			}
+			continue
+		}
+		if cell.ValueRef != "" {
+			return nil, fmt.Errorf("column %s: value reference %s cannot be exported", col.Name, cell.ValueRef)
+		}
+		if cell.Transform != "" {
+			return nil, fmt.Errorf("column %s: transform %s cannot be exported", col.Name, cell.Transform)
+		}
+		if cell.Weight != 0 {
+			return nil, fmt.Errorf("column %s: weight %g cannot be exported", col.Name, cell.Weight)
+		}
+		text, err := formatConditionString(*cell)
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %w", col.Name, err)
+		}
+		record = append(record, text)
+	}
+	for _, col := range outputs {
+		text := ""
+		for _, cell := range row.ReturnCells {
+			if cell.Column == col.Name {
+				if cell.Modifier != ModifierNone {
+					text = string(cell.Modifier) + " " + formatValue(cell.dataType, cell.Value)
+				} else {
+					text = formatValue(cell.dataType, cell.Value)
+				}
+				break
+			}
+		}
+		record = append(record, text)
+	}
+	return record, nil
+}
+
+// carriesPriority reports whether a "priority" METADATA column of the row holds its Priority, so a
+// loader reads it back.
+func carriesPriority(row Row, outputs []Column) bool {
+	for _, col := range outputs {
+		if col.Type != ColumnTypeMetadata || !strings.EqualFold(col.Name, "priority") {
+			continue
+		}
+		for _, cell := range row.ReturnCells {
+			if cell.Column != col.Name {
+				continue
+			}
+			n, err := toInt64(cell.Value)
+			return err == nil && n == int64(row.Priority)
+		}
+	}
+	return false
+}
+
+// formatBound renders a column's Min or Max in its canonical form; the bound was validated with the table.
+func formatBound(col Column, raw any) string {
+	v, err := coercePrimitive(col.DataType, raw)
+	if err != nil {
+		return fmt.Sprint(raw)
+	}
+	return formatValue(col.DataType, v)
+}
+
+// formatTemplateFlag renders the Template attribute cell of a column, blank unless it is set.
+func formatTemplateFlag(col Column) string {
+	return formatFlag(col.Template)
+}
+
+// formatRequiredFlag renders the Required attribute cell of a column, blank unless it is set.
+func formatRequiredFlag(col Column) string {
+	return formatFlag(col.Required)
+}
+
+func formatFlag(set bool) string {
+	if set {
+		return "true"
+	}
+	return ""
+}
+
+// formatScale renders the Scale attribute cell of a column, blank unless it is set.
+func formatScale(col Column) string {
+	if col.Scale == 0 {
+		return ""
+	}
+	return strconv.Itoa(col.Scale)
+}
@@ -0,0 +1,318 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// CSV layout markers. The first column holds a marker, the second the rule ID and the third the
+// rule description; decision table columns start at the fourth CSV column.
+const (
+	csvTableMarker       = "Decision Table"
+	csvMatchPolicyMarker = "Match Policy"
+	csvNoMatchMarker     = "No Match Policy"
+	csvNameMarker        = "Name"
+	csvTypeMarker        = "Type"
+	csvDataTypeMarker    = "Data Type"
+	csvRuleMarker        = "Rule"
+	csvDefaultRowMarker  = "Default Row"
+	csvRuleIDHeader      = "Rule ID"
+	csvDescriptionHeader = "Description"
+	csvFirstColumn       = 3
+)
+
+// LoadCSVFile loads a decision table from a CSV file that follows the CSV layout written by WriteCSV.
+// Options are applied before the policies declared in the file.
+func LoadCSVFile(path string, opts ...Option) (*DecisionTable, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open csv file %s: %w", path, err)
+	}
+	defer f.Close()
+	return LoadCSV(path, f, opts...)
+}
+
+// LoadCSV loads a decision table from an io.Reader containing CSV data.
+func LoadCSV(name string, r io.Reader, opts ...Option) (*DecisionTable, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("read csv %s: %w", name, err)
+	}
+	return buildCSVTable(name, records, opts)
+}
+
+func buildCSVTable(sourceName string, records [][]string, opts []Option) (*DecisionTable, error) {
+	if len(records) < 6 {
+		return nil, fmt.Errorf("csv requires header rows for %s, %s, %s, %s, %s and %s",
+			csvTableMarker, csvMatchPolicyMarker, csvNoMatchMarker, csvNameMarker, csvTypeMarker, csvDataTypeMarker)
+	}
+
+	name, err := expectCSVLabel(records, 0, csvTableMarker)
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		name = sourceName
+	}
+	matchRaw, err := expectCSVLabel(records, 1, csvMatchPolicyMarker)
+	if err != nil {
+		return nil, err
+	}
+	mp, err := parseMatchPolicyString(matchRaw)
+	if err != nil {
+		return nil, err
+	}
+	noMatchRaw, err := expectCSVLabel(records, 2, csvNoMatchMarker)
+	if err != nil {
+		return nil, err
+	}
+	nmp, err := parseNoMatchPolicyString(noMatchRaw)
+	if err != nil {
+		return nil, err
+	}
+
+	ordered, conditions, outputs, err := readCSVColumns(records[3], records[4], records[5])
+	if err != nil {
+		return nil, err
+	}
+
+	firstRule := 6
+	seenAttrs := make(map[string]struct{})
+	for len(records) > firstRule {
+		attr, ok := columnAttribute(csvField(records[firstRule], 0))
+		if !ok {
+			break
+		}
+		if _, dup := seenAttrs[attr]; dup {
+			return nil, fmt.Errorf("duplicate %s row at line %d", attr, firstRule+1)
+		}
+		seenAttrs[attr] = struct{}{}
+		for i := range ordered {
+			if err := setColumnAttribute(&ordered[i], attr, csvField(records[firstRule], csvFirstColumn+i)); err != nil {
+				return nil, fmt.Errorf("column %s: %w", ordered[i].Name, err)
+			}
+		}
+		firstRule++
+	}
+	if len(seenAttrs) > 0 {
+		conditions, outputs = splitColumnsByType(ordered)
+	}
+
+	dt, err := NewDecisionTable(name, conditions, outputs, withDeclaredPolicies(opts, mp, nmp)...)
+	if err != nil {
+		return nil, err
+	}
+
+	ruleIDs := make(map[string]struct{})
+	var defaultRow *Row
+	rowNumber := 0
+	for idx := firstRule; idx < len(records); idx++ {
+		record := records[idx]
+		marker := strings.TrimSpace(csvField(record, 0))
+		switch {
+		case marker == "" && isBlankCSVRecord(record):
+			continue
+		case strings.EqualFold(marker, csvRuleMarker):
+		case strings.EqualFold(marker, csvDefaultRowMarker):
+			if defaultRow != nil {
+				return nil, fmt.Errorf("csv line %d: duplicate %s marker", idx+1, csvDefaultRowMarker)
+			}
+		default:
+			return nil, fmt.Errorf("csv line %d: expected %s or %s marker, got %q", idx+1, csvRuleMarker, csvDefaultRowMarker, marker)
+		}
+
+		rowNumber++
+		isDefault := strings.EqualFold(marker, csvDefaultRowMarker)
+		row, err := convertCSVRecord(record, ordered, rowNumber, dt.requiresExplicitAny() && !isDefault)
+		if err != nil {
+			return nil, fmt.Errorf("csv line %d: %w", idx+1, err)
+		}
+		if err := ensureUniqueRuleID(&row, rowNumber, ruleIDs, dt.generateRuleID); err != nil {
+			return nil, fmt.Errorf("csv line %d: %w", idx+1, err)
+		}
+		if isDefault {
+			if len(row.EvalCells) > 0 {
+				return nil, fmt.Errorf("csv line %d: default row cannot contain evaluation cells", idx+1)
+			}
+			defaultRow = &row
+			continue
+		}
+		if defaultRow != nil {
+			return nil, fmt.Errorf("csv line %d: rules must precede the %s", idx+1, csvDefaultRowMarker)
+		}
+		if err := dt.AddRow(row); err != nil {
+			return nil, fmt.Errorf("csv line %d: %w", idx+1, err)
+		}
+	}
+
+	if defaultRow != nil {
+		if err := dt.SetDefaultRow(*defaultRow); err != nil {
+			return nil, err
+		}
+	} else if nmp == NoMatchPolicyReturnDefault {
+		return nil, fmt.Errorf("default row is required for RETURN_DEFAULT policy")
+	}
+	return dt, nil
+}
+
+func expectCSVLabel(records [][]string, line int, label string) (string, error) {
+	record := records[line]
+	if !strings.EqualFold(strings.TrimSpace(csvField(record, 0)), label) {
+		return "", fmt.Errorf("expected %s marker in csv line %d", label, line+1)
+	}
+	value := strings.TrimSpace(csvField(record, 1))
+	if value == "" && label != csvTableMarker {
+		return "", fmt.Errorf("%s value missing in csv line %d", label, line+1)
+	}
+	return value, nil
+}
+
+func readCSVColumns(names, types, dataTypes []string) ([]Column, []Column, []Column, error) {
+	if !strings.EqualFold(strings.TrimSpace(csvField(names, 0)), csvNameMarker) {
+		return nil, nil, nil, fmt.Errorf("expected %s marker in csv line 4", csvNameMarker)
+	}
+	if !strings.EqualFold(strings.TrimSpace(csvField(types, 0)), csvTypeMarker) {
+		return nil, nil, nil, fmt.Errorf("expected %s marker in csv line 5", csvTypeMarker)
+	}
+	if !strings.EqualFold(strings.TrimSpace(csvField(dataTypes, 0)), csvDataTypeMarker) {
+		return nil, nil, nil, fmt.Errorf("expected %s marker in csv line 6", csvDataTypeMarker)
+	}
+
+	var ordered, conditions, outputs []Column
+	seen := make(map[string]struct{})
+	for idx := csvFirstColumn; idx < len(names); idx++ {
+		name := strings.TrimSpace(names[idx])
+		if name == "" {
+			return nil, nil, nil, fmt.Errorf("column name missing in csv column %d", idx+1)
+		}
+		if _, exists := seen[name]; exists {
+			return nil, nil, nil, fmt.Errorf("duplicate column %s", name)
+		}
+		seen[name] = struct{}{}
+		colType, err := parseColumnTypeString(csvField(types, idx))
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("column %s: %w", name, err)
+		}
+		dataType, err := parseDataTypeString(csvField(dataTypes, idx))
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("column %s: %w", name, err)
+		}
+		column := Column{Name: name, Type: colType, DataType: dataType}
+		ordered = append(ordered, column)
+		switch colType {
+		case ColumnTypeCondition:
+			conditions = append(conditions, column)
+		case ColumnTypeConclusion, ColumnTypeMetadata:
+			outputs = append(outputs, column)
+		}
+	}
+	if len(outputs) == 0 {
+		return nil, nil, nil, fmt.Errorf("csv table must define output columns")
+	}
+	return ordered, conditions, outputs, nil
+}
+
+func convertCSVRecord(record []string, ordered []Column, rowNumber int, requireAny bool) (Row, error) {
+	if len(record) > csvFirstColumn+len(ordered) {
+		return Row{}, fmt.Errorf("expected at most %d fields, got %d", csvFirstColumn+len(ordered), len(record))
+	}
+	row := Row{
+		Number:   rowNumber,
+		RuleID:   strings.TrimSpace(csvField(record, 1)),
+		Comments: strings.TrimSpace(csvField(record, 2)),
+	}
+	for idx, column := range ordered {
+		raw := csvField(record, csvFirstColumn+idx)
+		trimmed := strings.TrimSpace(raw)
+		switch column.Type {
+		case ColumnTypeCondition:
+			present, err := checkConditionCell(trimmed, column, requireAny)
+			if err != nil {
+				return Row{}, err
+			}
+			if !present {
+				continue
+			}
+			op, operand, err := parseConditionString(trimmed, column.DataType)
+			if err != nil {
+				return Row{}, fmt.Errorf("column %s: %w", column.Name, err)
+			}
+			if err := checkOperatorDataType(op, column.DataType); err != nil {
+				return Row{}, fmt.Errorf("column %s: %w", column.Name, err)
+			}
+			row.EvalCells = append(row.EvalCells, EvalCell{
+				Column:   column.Name,
+				Operator: op,
+				Value:    operand,
+			})
+		case ColumnTypeConclusion, ColumnTypeMetadata:
+			var value any
+			switch {
+			case trimmed == "":
+				value = nil
+			case isListDataType(column.DataType):
+				value = splitList(trimmed)
+			default:
+				value = raw
+			}
+			row.ReturnCells = append(row.ReturnCells, ReturnCell{
+				Column: column.Name,
+				Value:  value,
+			})
+		}
+	}
+	fillCommentsFromMetadata(&row, ordered)
+	fillMetadataMap(&row, ordered)
+	if err := fillRowFromMetadata(&row, ordered); err != nil {
+		return Row{}, err
+	}
+	return row, nil
+}
+
+func splitColumnsByType(ordered []Column) ([]Column, []Column) {
+	var conditions, outputs []Column
+	for _, column := range ordered {
+		switch column.Type {
+		case ColumnTypeCondition:
+			conditions = append(conditions, column)
+		case ColumnTypeConclusion, ColumnTypeMetadata:
+			outputs = append(outputs, column)
+		}
+	}
+	return conditions, outputs
+}
+
+func csvField(record []string, idx int) string {
+	if idx < 0 || idx >= len(record) {
+		return ""
+	}
+	return record[idx]
+}
+
+func isBlankCSVRecord(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
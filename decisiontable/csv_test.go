@@ -0,0 +1,200 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const csvFixture = `Decision Table,eligibility
+Match Policy,FIRST
+No Match Policy,RETURN_DEFAULT
+Name,Rule ID,Description,age,country,segments,tier,discount
+Type,,,CONDITION,CONDITION,CONDITION,CONCLUSION,CONCLUSION
+Data Type,,,INTEGER,STRING,LIST_STRING,STRING,DECIMAL
+Rule,vip,"VIP segment, any age",,,"ANY_CONTAINED_IN vip,gold",vip,0.2
+Rule,adult-na,,>= 18,"IN US,CA",,standard,0.05
+Rule,,,< 18,NOT_EQ US,,minor-abroad,
+Default Row,default,fallback,,,,none,0
+`
+
+func TestLoadCSV(t *testing.T) {
+	dt, err := LoadCSV("eligibility.csv", strings.NewReader(csvFixture))
+	if err != nil {
+		t.Fatalf("load csv: %v", err)
+	}
+	if dt.Name != "eligibility" || dt.RowCount() != 3 {
+		t.Fatalf("unexpected table %s with %d rows", dt.Name, dt.RowCount())
+	}
+
+	cases := []struct {
+		input map[string]any
+		rule  string
+	}{
+		{input: map[string]any{"age": 30, "country": "FR", "segments": []string{"gold"}}, rule: "vip"},
+		{input: map[string]any{"age": 30, "country": "CA"}, rule: "adult-na"},
+		{input: map[string]any{"age": 10, "country": "FR"}, rule: "3"},
+		{input: map[string]any{"age": 10, "country": "US"}, rule: "default"},
+	}
+	for _, tc := range cases {
+		rows, err := dt.Evaluate(tc.input, nil)
+		if err != nil {
+			t.Fatalf("evaluate %v returned error: %v", tc.input, err)
+		}
+		if len(rows) != 1 || rows[0].RuleID != tc.rule {
+			t.Fatalf("input %v: expected rule %s, got %#v", tc.input, tc.rule, rows)
+		}
+	}
+	rows, _ := dt.Evaluate(map[string]any{"age": 10, "country": "FR"}, nil)
+	if rows[0].Values["discount"] != nil {
+		t.Fatalf("expected blank decimal output to be nil, got %#v", rows[0].Values["discount"])
+	}
+}
+
+func TestWriteCSVRoundTrip(t *testing.T) {
+	dt, err := LoadCSV("eligibility.csv", strings.NewReader(csvFixture))
+	if err != nil {
+		t.Fatalf("load csv: %v", err)
+	}
+
+	var first bytes.Buffer
+	if err := WriteCSV(dt, &first); err != nil {
+		t.Fatalf("write csv: %v", err)
+	}
+	reloaded, err := LoadCSV("roundtrip.csv", bytes.NewReader(first.Bytes()))
+	if err != nil {
+		t.Fatalf("reload csv: %v\n%s", err, first.String())
+	}
+	var second bytes.Buffer
+	if err := WriteCSV(reloaded, &second); err != nil {
+		t.Fatalf("write csv: %v", err)
+	}
+	if first.String() != second.String() {
+		t.Fatalf("round trip not stable:\n%s\n---\n%s", first.String(), second.String())
+	}
+
+	rows, err := reloaded.Evaluate(map[string]any{"age": 30, "country": "US"}, nil)
+	if err != nil {
+		t.Fatalf("evaluate returned error: %v", err)
+	}
+	if len(rows) != 1 || rows[0].RuleID != "adult-na" {
+		t.Fatalf("unexpected reloaded match %#v", rows)
+	}
+}
+
+func TestWriteCSVKeepsPrioritiesAndRejectsWeights(t *testing.T) {
+	const doc = `Decision Table,ranked
+Match Policy,PRIORITY
+No Match Policy,THROW_ERROR
+Name,Rule ID,Description,age,tier,priority
+Type,,,CONDITION,CONCLUSION,METADATA
+Data Type,,,INTEGER,STRING,INTEGER
+Rule,adult,,>= 18,adult,1
+Rule,senior,,>= 65,senior,5
+`
+	dt, err := LoadCSV("ranked.csv", strings.NewReader(doc))
+	if err != nil {
+		t.Fatalf("load csv: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := WriteCSV(dt, &buf); err != nil {
+		t.Fatalf("write csv: %v", err)
+	}
+	reloaded, err := LoadCSV("ranked.csv", &buf)
+	if err != nil {
+		t.Fatalf("reload csv: %v", err)
+	}
+	if err := dt.EqualErr(reloaded); err != nil {
+		t.Fatalf("expected priorities to survive the round trip: %v", err)
+	}
+
+	// a priority with no metadata column to hold it, or a weight, would be lost
+	row, _ := dt.GetRow("senior")
+	row.Priority = 7
+	if err := dt.UpdateRow("senior", row); err != nil {
+		t.Fatalf("update row: %v", err)
+	}
+	if err := WriteCSV(dt, &bytes.Buffer{}); err == nil || !strings.Contains(err.Error(), "priority 7 cannot be exported") {
+		t.Fatalf("expected an unheld priority to be rejected, got %v", err)
+	}
+	row.Priority = 5
+	row.EvalCells[0].Weight = 2
+	if err := dt.UpdateRow("senior", row); err != nil {
+		t.Fatalf("update row: %v", err)
+	}
+	if err := WriteCSV(dt, &bytes.Buffer{}); err == nil || !strings.Contains(err.Error(), "weight 2 cannot be exported") {
+		t.Fatalf("expected a weighted cell to be rejected, got %v", err)
+	}
+}
+
+func TestSaveCSVFileFromProgrammaticTable(t *testing.T) {
+	dt := buildSampleTable(t, WithMatchPolicy(MatchPolicyFirst))
+	path := filepath.Join(t.TempDir(), "sample.csv")
+	if err := SaveCSVFile(dt, path); err != nil {
+		t.Fatalf("save csv: %v", err)
+	}
+	loaded, err := LoadCSVFile(path)
+	if err != nil {
+		t.Fatalf("load csv: %v", err)
+	}
+	input := map[string]any{"age": 40, "country": "US", "segments": []string{"vip"}}
+	want, err := dt.Evaluate(input, nil)
+	if err != nil {
+		t.Fatalf("evaluate returned error: %v", err)
+	}
+	got, err := loaded.Evaluate(input, nil)
+	if err != nil {
+		t.Fatalf("evaluate returned error: %v", err)
+	}
+	if len(got) != 1 || got[0].RuleID != want[0].RuleID {
+		t.Fatalf("expected %s after round trip, got %#v", want[0].RuleID, got)
+	}
+}
+
+func TestCSVExplicitAnyCells(t *testing.T) {
+	if _, err := LoadCSV("eligibility.csv", strings.NewReader(csvFixture), WithExplicitAnyCells()); err == nil || !strings.Contains(err.Error(), "column age: blank condition cell") {
+		t.Fatalf("expected blank condition cell error, got %v", err)
+	}
+	if _, err := LoadCSV("eligibility.csv", strings.NewReader(csvFixture), WithExplicitAnyCells(), WithRowValidationPolicy(RowValidationLenient)); err != nil {
+		t.Fatalf("expected lenient table to ignore explicit any requirement: %v", err)
+	}
+
+	explicit := strings.NewReplacer(
+		`Rule,vip,"VIP segment, any age",,,`, `Rule,vip,"VIP segment, any age",ANY,*,`,
+		`"IN US,CA",,standard`, `"IN US,CA",any,standard`,
+		`NOT_EQ US,,minor-abroad`, `NOT_EQ US,*,minor-abroad`,
+	).Replace(csvFixture)
+	dt, err := LoadCSV("eligibility.csv", strings.NewReader(explicit), WithExplicitAnyCells())
+	if err != nil {
+		t.Fatalf("load csv with explicit any: %v", err)
+	}
+	if row, _ := dt.GetRow("vip"); len(row.EvalCells) != 1 {
+		t.Fatalf("expected ANY markers to leave only the segments condition, got %#v", row.EvalCells)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCSV(dt, &buf); err != nil {
+		t.Fatalf("write csv: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Rule,vip,\"VIP segment, any age\",*,*,") {
+		t.Fatalf("expected exporter to write * for unconstrained cells:\n%s", buf.String())
+	}
+	if _, err := LoadCSV("eligibility.csv", &buf, WithExplicitAnyCells()); err != nil {
+		t.Fatalf("expected exported csv to reload under explicit any: %v", err)
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import (
+	"fmt"
+	"strings"
+)
+
+// checkCurrencyColumns validates the money columns of a table: each names a sibling STRING condition
+// column that carries the currency of its input.
+func checkCurrencyColumns(conditions map[string]Column) error {
+	for _, col := range conditions {
+		if col.CurrencyColumn == "" {
+			continue
+		}
+		sibling, ok := conditions[col.CurrencyColumn]
+		switch {
+		case !ok:
+			return fmt.Errorf("column %s: currency column %q is not a condition column", col.Name, col.CurrencyColumn)
+		case sibling.Name == col.Name:
+			return fmt.Errorf("column %s: currency column refers to its own column", col.Name)
+		case sibling.DataType != DataTypeString:
+			return fmt.Errorf("column %s: currency column %s has data type %s, want STRING", col.Name, sibling.Name, sibling.DataType)
+		}
+	}
+	return nil
+}
+
+// checkCurrency verifies that a money cell's input amount is in the column's currency, so a EUR amount
+// is never compared with USD rule values. A null amount is left to the operator.
+func (c EvalCell) checkCurrency(input InputProvider, actual any) error {
+	if c.currency == "" || actual == nil {
+		return nil
+	}
+	raw, _ := lookupInput(input, c.currencyColumn, c.currencyPath)
+	if raw == nil {
+		return fmt.Errorf("%w: column %s needs the input currency in %s", ErrCurrencyMismatch, c.Column, c.currencyColumn)
+	}
+	tag, ok := raw.(string)
+	if !ok {
+		return fmt.Errorf("%w: currency %s must be a string, got %T", ErrCurrencyMismatch, c.currencyColumn, raw)
+	}
+	if !strings.EqualFold(strings.TrimSpace(tag), c.currency) {
+		return fmt.Errorf("%w: column %s is in %s, input is in %s", ErrCurrencyMismatch, c.Column, c.currency, strings.TrimSpace(tag))
+	}
+	return nil
+}
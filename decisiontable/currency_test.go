@@ -0,0 +1,71 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMoneyColumnsRejectOtherCurrencies(t *testing.T) {
+	const doc = `
+{
+  "decisionTable": {
+    "name": "limits",
+    "policies": {"matchPolicy": "FIRST", "noMatchPolicy": "RETURN_DEFAULT"},
+    "columns": [
+      {"name": "amount", "type": "CONDITION", "dataType": "DECIMAL", "currency": "USD", "currencyColumn": "currency"},
+      {"name": "currency", "type": "CONDITION", "dataType": "STRING"},
+      {"name": "action", "type": "CONCLUSION", "dataType": "STRING"}
+    ],
+    "rules": [
+      {"id": "large", "when": [{"operator": "greaterThan", "value": "1000"}, null], "then": ["review"]}
+    ],
+    "defaultRule": {"then": ["approve"]}
+  }
+}`
+	dt, err := LoadJSON([]byte(doc), "limits.json")
+	if err != nil {
+		t.Fatalf("load json: %v", err)
+	}
+	matches, err := dt.Evaluate(map[string]any{"amount": "1200", "currency": "usd"}, nil)
+	if err != nil || len(matches) != 1 || matches[0].Values["action"] != "review" {
+		t.Fatalf("expected a USD amount to be compared, got %#v (err %v)", matches, err)
+	}
+	_, err = dt.Evaluate(map[string]any{"amount": "1200", "currency": "EUR"}, nil)
+	if !errors.Is(err, ErrCurrencyMismatch) || !strings.Contains(err.Error(), "column amount is in USD, input is in EUR") {
+		t.Fatalf("expected a EUR amount to be rejected, got %v", err)
+	}
+	_, err = dt.Evaluate(map[string]any{"amount": "900"}, nil)
+	if !errors.Is(err, ErrCurrencyMismatch) {
+		t.Fatalf("expected an amount without a currency to be rejected, got %v", err)
+	}
+	matches, err = dt.Evaluate(map[string]any{"currency": "EUR"}, nil)
+	if err != nil || len(matches) != 1 || matches[0].Values["action"] != "approve" {
+		t.Fatalf("expected a null amount to skip the currency check, got %#v (err %v)", matches, err)
+	}
+
+	for _, tc := range []struct{ from, to, want string }{
+		{`"currencyColumn": "currency"`, `"currencyColumn": "ccy"`, `currency column "ccy" is not a condition column`},
+		{`"currencyColumn": "currency"}`, `"currencyColumn": "currency"}, {"name": "x", "type": "CONDITION", "dataType": "STRING", "currency": "EUR"}`, "currency and currency column must be set together"},
+		{`"dataType": "DECIMAL", "currency"`, `"dataType": "STRING", "currency"`, "only INTEGER and DECIMAL condition columns can have a currency"},
+	} {
+		_, err := LoadJSON([]byte(strings.Replace(doc, tc.from, tc.to, 1)), "limits.json")
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("expected %q, got %v", tc.want, err)
+		}
+	}
+}
@@ -0,0 +1,275 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import (
+	"math"
+	"math/big"
+	"time"
+)
+
+// DeadRuleReport describes a rule that can never match because some of its conditions contradict
+// each other. Cells holds the offending cells, all of which target Column.
+type DeadRuleReport struct {
+	RuleID    string
+	RowNumber int
+	Column    string
+	Cells     []EvalCell
+	Reason    string
+}
+
+// DetectDeadRules statically checks every rule for conditions that cannot be satisfied together:
+// empty IN sets, conflicting ranges, disjoint IN/EQ and NOT_IN/NOT_EQ sets, and null checks combined
+// with value comparisons on the same column. Pairs of cells are compared, so contradictions that
+// only appear across three or more cells are not reported. Custom and list operators are skipped.
+func (dt *DecisionTable) DetectDeadRules() []DeadRuleReport {
+	if dt == nil {
+		return nil
+	}
+	var reports []DeadRuleReport
+	for _, row := range dt.rows {
+		if report, dead := deadRuleReport(row); dead {
+			reports = append(reports, report)
+		}
+	}
+	return reports
+}
+
+func deadRuleReport(row Row) (DeadRuleReport, bool) {
+	report := DeadRuleReport{RuleID: row.RuleID, RowNumber: row.Number}
+	for i, a := range row.EvalCells {
+		if a.Operator == OperatorIn || a.Operator == OperatorAnyContained {
+			if values, ok := a.Value.([]any); ok && len(values) == 0 {
+				report.Column = a.Column
+				report.Cells = []EvalCell{a}
+				report.Reason = describeCell(a) + " has an empty value set"
+				return report, true
+			}
+		}
+		for _, b := range row.EvalCells[i+1:] {
+			if a.Column != b.Column {
+				continue
+			}
+			if contradicts(a, b) {
+				report.Column = a.Column
+				report.Cells = []EvalCell{a, b}
+				report.Reason = describeCell(a) + " contradicts " + describeCell(b)
+				return report, true
+			}
+		}
+	}
+	return report, false
+}
+
+func describeCell(cell EvalCell) string {
+	text, err := formatConditionString(cell)
+	if err != nil {
+		return string(cell.Operator)
+	}
+	return text
+}
+
+func contradicts(a, b EvalCell) bool {
+	if isCustomOperator(a.Operator) || isCustomOperator(b.Operator) {
+		return false
+	}
+	if a.ValueRef != "" || b.ValueRef != "" {
+		// the compared value is only known at evaluation time
+		return false
+	}
+	if a.Operator == OperatorIsNull || b.Operator == OperatorIsNull {
+		other := b
+		if b.Operator == OperatorIsNull {
+			other = a
+		}
+		return other.Operator != OperatorIsNull && requiresNonNullActual(other)
+	}
+	if isListDataType(a.dataType) || expectsActualCollection(a.Operator) || expectsActualCollection(b.Operator) {
+		return false
+	}
+
+	switch {
+	case allowedSet(a) != nil && allowedSet(b) != nil:
+		return !intersects(a.dataType, allowedSet(a), allowedSet(b))
+	case allowedSet(a) != nil && excludedSet(b) != nil:
+		return subsetOf(a.dataType, allowedSet(a), excludedSet(b))
+	case excludedSet(a) != nil && allowedSet(b) != nil:
+		return subsetOf(a.dataType, allowedSet(b), excludedSet(a))
+	case allowedSet(a) != nil && isRangeOperator(b.Operator):
+		return !anySatisfies(a.dataType, allowedSet(a), b)
+	case isRangeOperator(a.Operator) && allowedSet(b) != nil:
+		return !anySatisfies(a.dataType, allowedSet(b), a)
+	case isRangeOperator(a.Operator) && isRangeOperator(b.Operator):
+		return emptyRange(a, b)
+	}
+	return false
+}
+
+// requiresNonNullActual reports whether the cell can only match a non-nil input.
+func requiresNonNullActual(cell EvalCell) bool {
+	switch cell.Operator {
+	case OperatorIsNotNull:
+		return true
+	case OperatorEqual, OperatorIn, OperatorMatchesRegex, OperatorTextEqual, OperatorBetween,
+		OperatorGreater, OperatorGreaterOrEqual, OperatorLess, OperatorLessOrEqual:
+		return cell.Value != nil
+	default:
+		return false
+	}
+}
+
+func isListDataType(dt DataType) bool {
+	return dt == DataTypeListString || dt == DataTypeListInteger || dt == DataTypeListDecimal
+}
+
+func isRangeOperator(op OperatorType) bool {
+	switch op {
+	case OperatorGreater, OperatorGreaterOrEqual, OperatorLess, OperatorLessOrEqual:
+		return true
+	default:
+		return false
+	}
+}
+
+func allowedSet(cell EvalCell) []any {
+	switch cell.Operator {
+	case OperatorEqual:
+		if cell.Value != nil {
+			return []any{cell.Value}
+		}
+	case OperatorIn:
+		if values, ok := cell.Value.([]any); ok && len(values) > 0 {
+			return values
+		}
+	}
+	return nil
+}
+
+func excludedSet(cell EvalCell) []any {
+	switch cell.Operator {
+	case OperatorNotEqual:
+		if cell.Value != nil {
+			return []any{cell.Value}
+		}
+	case OperatorNotIn:
+		if values, ok := cell.Value.([]any); ok && len(values) > 0 {
+			return values
+		}
+	}
+	return nil
+}
+
+func intersects(dt DataType, a, b []any) bool {
+	for _, v := range a {
+		if match, err := containsValue(dt, b, v); err != nil || match {
+			return true
+		}
+	}
+	return false
+}
+
+func subsetOf(dt DataType, values, of []any) bool {
+	for _, v := range values {
+		if match, err := containsValue(dt, of, v); err != nil || !match {
+			return false
+		}
+	}
+	return true
+}
+
+func anySatisfies(dt DataType, values []any, bound EvalCell) bool {
+	for _, v := range values {
+		if match, err := compare(dt, bound.Operator, v, bound.Value); err != nil || match {
+			return true
+		}
+	}
+	return false
+}
+
+// emptyRange reports whether a lower and an upper bound leave no value between them.
+func emptyRange(a, b EvalCell) bool {
+	lower, upper := a, b
+	if isUpperBound(lower.Operator) {
+		lower, upper = b, a
+	}
+	if isUpperBound(lower.Operator) || !isUpperBound(upper.Operator) {
+		return false
+	}
+	cmp, ok := orderValues(lower.Value, upper.Value)
+	if !ok {
+		return false
+	}
+	if l, isInt := lower.Value.(int64); isInt {
+		// integers are discrete, so GREATER_THAN 5 and LESS_THAN 6 also leave nothing in between
+		u := upper.Value.(int64)
+		if lower.Operator == OperatorGreater {
+			if l == math.MaxInt64 {
+				return true
+			}
+			l++
+		}
+		if upper.Operator == OperatorLess {
+			if u == math.MinInt64 {
+				return true
+			}
+			u--
+		}
+		return l > u
+	}
+	if lower.Operator == OperatorGreaterOrEqual && upper.Operator == OperatorLessOrEqual {
+		return cmp > 0
+	}
+	return cmp >= 0
+}
+
+func isUpperBound(op OperatorType) bool {
+	return op == OperatorLess || op == OperatorLessOrEqual
+}
+
+func orderValues(a, b any) (int, bool) {
+	switch l := a.(type) {
+	case int64:
+		r, ok := b.(int64)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case l < r:
+			return -1, true
+		case l > r:
+			return 1, true
+		}
+		return 0, true
+	case time.Duration:
+		r, ok := b.(time.Duration)
+		if !ok {
+			return 0, false
+		}
+		return orderValues(int64(l), int64(r))
+	case *big.Float:
+		r, ok := b.(*big.Float)
+		if !ok {
+			return 0, false
+		}
+		return l.Cmp(r), true
+	case time.Time:
+		r, ok := b.(time.Time)
+		if !ok {
+			return 0, false
+		}
+		return l.Compare(r), true
+	}
+	return 0, false
+}
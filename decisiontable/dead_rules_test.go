@@ -0,0 +1,120 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import (
+	"strings"
+	"testing"
+)
+
+func buildDeadRuleTable(t *testing.T, rows ...Row) *DecisionTable {
+	t.Helper()
+	dt, err := NewDecisionTable("dead",
+		[]Column{
+			{Name: "amount", Type: ColumnTypeCondition, DataType: DataTypeInteger},
+			{Name: "country", Type: ColumnTypeCondition, DataType: DataTypeString},
+			{Name: "score", Type: ColumnTypeCondition, DataType: DataTypeDecimal},
+		},
+		[]Column{{Name: "result", Type: ColumnTypeConclusion, DataType: DataTypeString}},
+		WithRepeatedConditionColumns(),
+		WithAllowEmptyCollections(),
+	)
+	if err != nil {
+		t.Fatalf("failed to build table: %v", err)
+	}
+	for _, row := range rows {
+		row.ReturnCells = []ReturnCell{{Column: "result", Value: row.RuleID}}
+		if err := dt.AddRow(row); err != nil {
+			t.Fatalf("failed to add row %s: %v", row.RuleID, err)
+		}
+	}
+	return dt
+}
+
+func TestDetectDeadRules(t *testing.T) {
+	dt := buildDeadRuleTable(t,
+		Row{RuleID: "live-range", EvalCells: []EvalCell{
+			{Column: "amount", Operator: OperatorGreater, Value: 50},
+			{Column: "amount", Operator: OperatorLess, Value: 100},
+		}},
+		Row{RuleID: "dead-range", EvalCells: []EvalCell{
+			{Column: "amount", Operator: OperatorGreater, Value: 100},
+			{Column: "amount", Operator: OperatorLess, Value: 50},
+		}},
+		Row{RuleID: "dead-integer-gap", EvalCells: []EvalCell{
+			{Column: "amount", Operator: OperatorGreater, Value: 5},
+			{Column: "amount", Operator: OperatorLess, Value: 6},
+		}},
+		Row{RuleID: "live-decimal-gap", EvalCells: []EvalCell{
+			{Column: "score", Operator: OperatorGreater, Value: 5},
+			{Column: "score", Operator: OperatorLess, Value: 6},
+		}},
+		Row{RuleID: "dead-in-not-in", EvalCells: []EvalCell{
+			{Column: "country", Operator: OperatorIn, Value: []string{"US"}},
+			{Column: "country", Operator: OperatorNotIn, Value: []string{"US", "CA"}},
+		}},
+		Row{RuleID: "dead-null", EvalCells: []EvalCell{
+			{Column: "country", Operator: OperatorIsNull},
+			{Column: "country", Operator: OperatorEqual, Value: "US"},
+		}},
+		Row{RuleID: "live-null", EvalCells: []EvalCell{
+			{Column: "country", Operator: OperatorIsNull},
+			{Column: "country", Operator: OperatorNotEqual, Value: "US"},
+		}},
+		Row{RuleID: "dead-eq-range", EvalCells: []EvalCell{
+			{Column: "amount", Operator: OperatorIn, Value: []int{1, 2}},
+			{Column: "amount", Operator: OperatorGreaterOrEqual, Value: 3},
+		}},
+		Row{RuleID: "dead-empty-in", EvalCells: []EvalCell{
+			{Column: "country", Operator: OperatorIn, Value: []string{}},
+		}},
+		Row{RuleID: "live-other-columns", EvalCells: []EvalCell{
+			{Column: "amount", Operator: OperatorGreater, Value: 100},
+			{Column: "score", Operator: OperatorLess, Value: 50},
+		}},
+	)
+
+	reports := dt.DetectDeadRules()
+	var ids []string
+	for _, report := range reports {
+		ids = append(ids, report.RuleID)
+	}
+	want := "dead-range,dead-integer-gap,dead-in-not-in,dead-null,dead-eq-range,dead-empty-in"
+	if got := strings.Join(ids, ","); got != want {
+		t.Fatalf("expected dead rules %s, got %s", want, got)
+	}
+
+	first := reports[0]
+	if first.Column != "amount" || len(first.Cells) != 2 || first.RowNumber != 2 {
+		t.Fatalf("unexpected report: %#v", first)
+	}
+	if first.Reason != "GT 100 contradicts LT 50" {
+		t.Fatalf("unexpected reason: %q", first.Reason)
+	}
+}
+
+func TestRepeatedConditionColumnsRequireOption(t *testing.T) {
+	dt := buildSampleTable(t)
+	err := dt.AddRow(Row{
+		EvalCells: []EvalCell{
+			{Column: "age", Operator: OperatorGreater, Value: 10},
+			{Column: "age", Operator: OperatorLess, Value: 20},
+		},
+		ReturnCells: []ReturnCell{{Column: "tier", Value: "teen"}},
+	})
+	if err == nil {
+		t.Fatalf("expected repeated column to be rejected without option")
+	}
+}
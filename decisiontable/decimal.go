@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"strconv"
 	"strings"
 )
 
@@ -30,8 +31,17 @@ func toBigFloat(raw any) (*big.Float, error) {
 
 	switch v := raw.(type) {
 	case *big.Float:
+		if v == nil {
+			return nil, nil
+		}
+		if v.IsInf() {
+			return nil, fmt.Errorf("decimal value %s must be finite", v.String())
+		}
 		return cloneDecimal(v), nil
 	case big.Float:
+		if v.IsInf() {
+			return nil, fmt.Errorf("decimal value %s must be finite", v.String())
+		}
 		return cloneDecimal(&v), nil
 	case string:
 		trim := strings.TrimSpace(v)
@@ -42,41 +52,109 @@ func toBigFloat(raw any) (*big.Float, error) {
 		if !ok {
 			return nil, fmt.Errorf("cannot convert %q to decimal", v)
 		}
+		if f.IsInf() {
+			return nil, fmt.Errorf("decimal value %q must be finite", v)
+		}
 		return f, nil
 	case fmt.Stringer:
 		return toBigFloat(v.String())
 	case int:
-		return big.NewFloat(float64(v)).SetPrec(decimalPrecision), nil
+		return new(big.Float).SetPrec(decimalPrecision).SetInt64(int64(v)), nil
 	case int8:
-		return big.NewFloat(float64(v)).SetPrec(decimalPrecision), nil
+		return new(big.Float).SetPrec(decimalPrecision).SetInt64(int64(v)), nil
 	case int16:
-		return big.NewFloat(float64(v)).SetPrec(decimalPrecision), nil
+		return new(big.Float).SetPrec(decimalPrecision).SetInt64(int64(v)), nil
 	case int32:
-		return big.NewFloat(float64(v)).SetPrec(decimalPrecision), nil
+		return new(big.Float).SetPrec(decimalPrecision).SetInt64(int64(v)), nil
 	case int64:
 		return new(big.Float).SetPrec(decimalPrecision).SetInt64(v), nil
 	case uint:
-		return big.NewFloat(float64(v)).SetPrec(decimalPrecision), nil
+		return new(big.Float).SetPrec(decimalPrecision).SetUint64(uint64(v)), nil
 	case uint8:
-		return big.NewFloat(float64(v)).SetPrec(decimalPrecision), nil
+		return new(big.Float).SetPrec(decimalPrecision).SetUint64(uint64(v)), nil
 	case uint16:
-		return big.NewFloat(float64(v)).SetPrec(decimalPrecision), nil
+		return new(big.Float).SetPrec(decimalPrecision).SetUint64(uint64(v)), nil
 	case uint32:
-		return big.NewFloat(float64(v)).SetPrec(decimalPrecision), nil
+		return new(big.Float).SetPrec(decimalPrecision).SetUint64(uint64(v)), nil
 	case uint64:
 		if v > math.MaxInt64 {
 			return nil, fmt.Errorf("value %d overflows decimal converter", v)
 		}
-		return new(big.Float).SetPrec(decimalPrecision).SetInt64(int64(v)), nil
+		return new(big.Float).SetPrec(decimalPrecision).SetUint64(v), nil
 	case float32:
-		return new(big.Float).SetPrec(decimalPrecision).SetFloat64(float64(v)), nil
+		return floatToBigFloat(float64(v))
 	case float64:
-		return new(big.Float).SetPrec(decimalPrecision).SetFloat64(v), nil
+		return floatToBigFloat(v)
 	default:
 		return nil, fmt.Errorf("cannot convert %T to decimal", raw)
 	}
 }
 
+// floatToBigFloat rejects NaN and infinities, which can never compare meaningfully.
+func floatToBigFloat(v float64) (*big.Float, error) {
+	if math.IsNaN(v) {
+		return nil, fmt.Errorf("decimal value NaN is not supported")
+	}
+	if math.IsInf(v, 0) {
+		return nil, fmt.Errorf("decimal value %v must be finite", v)
+	}
+	return new(big.Float).SetPrec(decimalPrecision).SetFloat64(v), nil
+}
+
+// decimalText returns the normalized textual form of a decimal value, preserving its written scale.
+// Whitespace, a leading plus sign and redundant leading zeros are removed; trailing zeros are kept.
+// Values that carry no textual form (floats, *big.Float) use their shortest representation.
+func decimalText(raw any) (string, error) {
+	var text string
+	switch v := raw.(type) {
+	case string:
+		text = v
+	case *big.Float:
+		// String keeps only ten significant digits
+		if v == nil {
+			return "", fmt.Errorf("decimal value is nil")
+		}
+		text = v.Text('f', -1)
+	case big.Float:
+		text = v.Text('f', -1)
+	case fmt.Stringer:
+		text = v.String()
+	case float32:
+		text = strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case float64:
+		text = strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		text = fmt.Sprint(v)
+	}
+	text = strings.TrimPrefix(strings.TrimSpace(text), "+")
+	if _, err := toBigFloat(text); err != nil {
+		return "", err
+	}
+	sign := ""
+	if strings.HasPrefix(text, "-") {
+		sign = "-"
+		text = text[1:]
+	}
+	trimmed := strings.TrimLeft(text, "0")
+	if trimmed == "" || trimmed[0] == '.' || trimmed[0] == 'e' || trimmed[0] == 'E' {
+		trimmed = "0" + trimmed
+	}
+	return sign + trimmed, nil
+}
+
+// roundDecimal rounds v half to even at the given number of decimal places. The result is parsed back
+// from its decimal text so it prints without binary noise such as 3.4999999999.
+func roundDecimal(v *big.Float, digits int) *big.Float {
+	if v == nil {
+		return nil
+	}
+	rounded, ok := new(big.Float).SetPrec(decimalPrecision).SetString(v.Text('f', digits))
+	if !ok {
+		return cloneDecimal(v)
+	}
+	return rounded
+}
+
 func cloneDecimal(src *big.Float) *big.Float {
 	if src == nil {
 		return nil
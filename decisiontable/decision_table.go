@@ -14,7 +14,21 @@
 
 package decisiontable
 
-import "fmt"
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"math/big"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
 
 // DecisionTable is the in-memory representation of a decision table ready for evaluation.
 type DecisionTable struct {
@@ -22,12 +36,38 @@ type DecisionTable struct {
 
 	conditionColumns map[string]Column
 	outputColumns    map[string]Column
+	conditionOrder   []Column
+	outputOrder      []Column
 	rows             []Row
 	defaultRow       *Row
+	guardedDefaults  []Row
 
+	version       string
 	matchPolicy   MatchPolicy
 	noMatchPolicy NoMatchPolicy
 	rowValidation RowValidationPolicy
+
+	ruleIDGenerator       func(rowNumber int) string
+	allowEmptyCollections bool
+	rejectUnknownKeys     bool
+	allowNoConditions     bool
+	stringNormalizer      func(string) string
+	allowRepeatedColumns  bool
+	decimalOutputScale    int
+	roundDecimalOutputs   bool
+	sparseOutput          bool
+	observer              Observer
+	echoInput             bool
+	requireExplicitAny    bool
+	maxStringLength       int
+	validateUniqueness    bool
+	coercionCacheSize     int
+	vacuousAllEqual       bool
+	threeValuedNulls      bool
+	inputPaths            map[string]*inputPath
+	sharedValues          bool
+	uniquePerGroup        bool
+	outputTemplates       map[outputTemplateKey]*template.Template
 }
 
 // NewDecisionTable constructs a decision table given separate evaluation and return columns.
@@ -36,7 +76,28 @@ func NewDecisionTable(name string, conditionCols, outputCols []Column, opts ...O
 		return nil, fmt.Errorf("table name must not be empty")
 	}
 
-	if len(conditionCols) == 0 {
+	dt := &DecisionTable{
+		Name:          name,
+		matchPolicy:   MatchPolicyAll,
+		noMatchPolicy: NoMatchPolicyThrowError,
+		rowValidation: RowValidationStrict,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(dt)
+		}
+	}
+
+	if dt.maxStringLength < 0 {
+		return nil, fmt.Errorf("max string length must not be negative, got %d", dt.maxStringLength)
+	}
+	if dt.coercionCacheSize < 0 {
+		return nil, fmt.Errorf("coercion cache size must not be negative, got %d", dt.coercionCacheSize)
+	}
+	if dt.roundDecimalOutputs && dt.decimalOutputScale < 0 {
+		return nil, fmt.Errorf("decimal output scale must not be negative, got %d", dt.decimalOutputScale)
+	}
+	if len(conditionCols) == 0 && !dt.allowNoConditions {
 		return nil, fmt.Errorf("table must define at least one condition column")
 	}
 	if len(outputCols) == 0 {
@@ -47,41 +108,80 @@ func NewDecisionTable(name string, conditionCols, outputCols []Column, opts ...O
 	if err != nil {
 		return nil, err
 	}
+	if err := checkCurrencyColumns(conditionMap); err != nil {
+		return nil, err
+	}
 	outputMap, err := buildColumnMap(outputCols, ColumnTypeConclusion, ColumnTypeMetadata)
 	if err != nil {
 		return nil, err
 	}
+	for _, col := range conditionCols {
+		if col.Path == "" {
+			continue
+		}
+		if dt.inputPaths == nil {
+			dt.inputPaths = make(map[string]*inputPath)
+		}
+		// the path was checked when the column was validated
+		dt.inputPaths[col.Name], _ = parseInputPath(col.Path)
+	}
+	dt.conditionColumns = conditionMap
+	dt.outputColumns = outputMap
+	dt.conditionOrder = append([]Column(nil), conditionCols...)
+	dt.outputOrder = append([]Column(nil), outputCols...)
+	return dt, nil
+}
 
-	dt := &DecisionTable{
-		Name:             name,
-		conditionColumns: conditionMap,
-		outputColumns:    outputMap,
-		matchPolicy:      MatchPolicyAll,
-		noMatchPolicy:    NoMatchPolicyThrowError,
-		rowValidation:    RowValidationStrict,
+// NewDecisionTableWithRows constructs a decision table and adds rows in order. It fails on the first
+// row that does not sanitize or that repeats a non-empty rule ID, reporting the row's index.
+func NewDecisionTableWithRows(name string, conditionCols, outputCols []Column, rows []Row, opts ...Option) (*DecisionTable, error) {
+	dt, err := NewDecisionTable(name, conditionCols, outputCols, opts...)
+	if err != nil {
+		return nil, err
 	}
-	for _, opt := range opts {
-		if opt != nil {
-			opt(dt)
+	ruleIDs := make(map[string]int, len(rows))
+	for i, row := range rows {
+		if err := noteRuleID(ruleIDs, row, i); err != nil {
+			return nil, err
+		}
+		if err := dt.AddRow(row); err != nil {
+			return nil, fmt.Errorf("row %d: %w", i, err)
 		}
 	}
 	return dt, nil
 }
 
+// noteRuleID records the non-empty rule ID of the row at index i in ruleIDs, failing when an earlier
+// row already used it.
+func noteRuleID(ruleIDs map[string]int, row Row, i int) error {
+	if row.RuleID == "" {
+		return nil
+	}
+	if first, dup := ruleIDs[row.RuleID]; dup {
+		return fmt.Errorf("row %d: duplicate rule id %q (first used by row %d)", i, row.RuleID, first)
+	}
+	ruleIDs[row.RuleID] = i
+	return nil
+}
+
 // AddRow registers a decision table row. The incoming row is copied and sanitized.
 func (dt *DecisionTable) AddRow(row Row) error {
-	prepared, err := dt.prepareRow(row, dt.rowValidation == RowValidationStrict, dt.rowValidation == RowValidationStrict)
+	prepared, err := dt.prepareRule(row)
 	if err != nil {
 		return err
 	}
 	if prepared.Number <= 0 {
 		prepared.Number = len(dt.rows) + 1
 	}
+	if err := dt.checkUniqueness(prepared, -1); err != nil {
+		return err
+	}
 	dt.rows = append(dt.rows, prepared)
 	return nil
 }
 
 // SetDefaultRow registers a default row that will be returned automatically when no rules match.
+// Calling it again replaces the previous default row; use ClearDefaultRow to remove it.
 func (dt *DecisionTable) SetDefaultRow(row Row) error {
 	if dt.noMatchPolicy != NoMatchPolicyReturnDefault && dt.noMatchPolicy != NoMatchPolicyThrowError {
 		return fmt.Errorf("default rows are only valid when using RETURN_DEFAULT or THROW_ERROR no-match policy")
@@ -89,6 +189,9 @@ func (dt *DecisionTable) SetDefaultRow(row Row) error {
 	if len(row.EvalCells) > 0 {
 		return fmt.Errorf("default rows cannot contain evaluation cells")
 	}
+	if !row.ValidFrom.IsZero() || !row.ValidUntil.IsZero() {
+		return fmt.Errorf("default rows are always in effect and cannot have a validity window")
+	}
 	prepared, err := dt.prepareRow(row, false, true)
 	if err != nil {
 		return err
@@ -98,68 +201,391 @@ func (dt *DecisionTable) SetDefaultRow(row Row) error {
 	}
 	copied := prepared
 	dt.defaultRow = &copied
+	dt.pruneOutputTemplates()
 	return nil
 }
 
+// ClearDefaultRow removes the configured default row, if any. It is a no-op on a nil table.
+func (dt *DecisionTable) ClearDefaultRow() {
+	if dt == nil {
+		return
+	}
+	dt.defaultRow = nil
+	dt.pruneOutputTemplates()
+}
+
+// AddGuardedDefault appends a default row that only applies when its conditions match the input.
+// When no rule matches, the guarded defaults are tried in the order they were added and the first
+// whose conditions all match is returned; if none does, the unconditional default row or the no-match
+// policy applies as usual. Like SetDefaultRow it requires the RETURN_DEFAULT or THROW_ERROR policy.
+// Guarded defaults have no row number and cannot be written to the Excel and CSV layouts.
+func (dt *DecisionTable) AddGuardedDefault(conditions []EvalCell, returns []ReturnCell) error {
+	if dt.noMatchPolicy != NoMatchPolicyReturnDefault && dt.noMatchPolicy != NoMatchPolicyThrowError {
+		return fmt.Errorf("default rows are only valid when using RETURN_DEFAULT or THROW_ERROR no-match policy")
+	}
+	if len(conditions) == 0 {
+		return fmt.Errorf("guarded default needs at least one condition; use SetDefaultRow for an unconditional default")
+	}
+	prepared, err := dt.prepareRow(Row{EvalCells: conditions, ReturnCells: returns}, true, true)
+	if err != nil {
+		return fmt.Errorf("guarded default %d: %w", len(dt.guardedDefaults)+1, err)
+	}
+	dt.guardedDefaults = append(dt.guardedDefaults, prepared)
+	return nil
+}
+
+// HasDefaultRow reports whether a default row is configured.
+func (dt *DecisionTable) HasDefaultRow() bool {
+	return dt != nil && dt.defaultRow != nil
+}
+
 // Evaluate processes the supplied input map and returns the rows that match the configured policy.
-// When there are no matches and the table is configured with RETURN_DEFAULT, the supplied defaultReturn map is returned.
+// When there are no matches the no-match policy applies (see NoMatchPolicy): a default row wins, and
+// under RETURN_DEFAULT the supplied defaultReturn map is returned when the table has none.
 func (dt *DecisionTable) Evaluate(input map[string]any, defaultReturn map[string]any) ([]MatchedRow, error) {
+	return dt.EvaluateProvider(MapInput(input), defaultReturn)
+}
+
+// EvaluateJSON decodes data, a JSON object keyed by column name, and evaluates it like Evaluate.
+// Numbers are decoded as json.Number, so large INTEGER values and long DECIMAL fractions keep the
+// precision a float64 would lose.
+func (dt *DecisionTable) EvaluateJSON(data []byte, defaultReturn map[string]any) ([]MatchedRow, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var input map[string]any
+	if err := dec.Decode(&input); err != nil {
+		return nil, fmt.Errorf("decode json input: %w", err)
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("decode json input: unexpected data after the input object")
+	}
+	if input == nil {
+		return nil, fmt.Errorf("decode json input: expected an object, got null")
+	}
+	return dt.Evaluate(input, defaultReturn)
+}
+
+// EvaluateWithDefaults behaves like Evaluate with a layered fallback: when nothing matches under
+// RETURN_DEFAULT and the table has no default row, it returns a copy of the first non-nil map in
+// defaults, so callers can pass rule, tenant and global defaults in order of precedence.
+func (dt *DecisionTable) EvaluateWithDefaults(input map[string]any, defaults ...map[string]any) ([]MatchedRow, error) {
+	var fallback map[string]any
+	for _, candidate := range defaults {
+		if candidate != nil {
+			fallback = candidate
+			break
+		}
+	}
+	return dt.EvaluateProvider(MapInput(input), fallback)
+}
+
+// EvaluateProvider behaves like Evaluate but reads input values from p, letting callers evaluate
+// structs, protobuf messages or other sources through an InputProvider.
+func (dt *DecisionTable) EvaluateProvider(p InputProvider, defaultReturn map[string]any) ([]MatchedRow, error) {
 	var matches []MatchedRow
-	for _, row := range dt.rows {
+	err := dt.evaluateFunc(p, defaultReturn, time.Now(), func(m MatchedRow) bool {
+		matches = append(matches, m)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return matches, nil
+}
+
+// EvaluateFunc streams matches to yield as they are found instead of collecting them, stopping early
+// when yield returns false. Match and no-match policies apply as in Evaluate; under UNIQUE the first
+// match has already been yielded by the time a second match is reported as an error.
+func (dt *DecisionTable) EvaluateFunc(input map[string]any, yield func(MatchedRow) bool) error {
+	return dt.evaluateFunc(MapInput(input), nil, time.Now(), yield)
+}
+
+func (dt *DecisionTable) evaluateFunc(input InputProvider, defaultReturn map[string]any, asOf time.Time, yield func(MatchedRow) bool) error {
+	var renderErr error
+	if dt.hasOutputTemplates() {
+		data := dt.templateContext(input)
+		next := yield
+		yield = func(m MatchedRow) bool {
+			if renderErr = dt.renderTemplates(m.Values, m.Groups, data); renderErr != nil {
+				return false
+			}
+			return next(m)
+		}
+	}
+	if dt.echoInput {
+		echo := dt.echoedInput(input)
+		next := yield
+		yield = func(m MatchedRow) bool {
+			m.Input = cloneMap(echo)
+			return next(m)
+		}
+	}
+	if dt.observer == nil {
+		if err := dt.evaluateRows(input, defaultReturn, asOf, yield); err != nil {
+			return err
+		}
+		return renderErr
+	}
+	start := time.Now()
+	dt.observer.OnEvaluateStart()
+	count := 0
+	err := dt.evaluateRows(input, defaultReturn, asOf, func(m MatchedRow) bool {
+		count++
+		if len(m.MatchedRules) > 0 {
+			for _, ruleID := range m.MatchedRules {
+				dt.observer.OnRowMatch(ruleID)
+			}
+		} else {
+			dt.observer.OnRowMatch(m.RuleID)
+		}
+		return yield(m)
+	})
+	if err == nil {
+		err = renderErr
+	}
+	dt.observer.OnEvaluateEnd(time.Since(start), count, err)
+	return err
+}
+
+// evaluateRows applies the match policy to the rows in effect at asOf.
+func (dt *DecisionTable) evaluateRows(input InputProvider, defaultReturn map[string]any, asOf time.Time, yield func(MatchedRow) bool) error {
+	if err := dt.checkInputKeys(input); err != nil {
+		return err
+	}
+	matched := 0
+	var groupMatches map[string]string
+	if dt.matchPolicy == MatchPolicyUnique && dt.uniquePerGroup {
+		groupMatches = make(map[string]string)
+	}
+	var collected []Row
+	var best *Row
+	for i, row := range dt.rows {
+		if row.Disabled || !row.activeAt(asOf) {
+			continue
+		}
 		match, err := row.matches(input)
 		if err != nil {
-			return nil, err
+			return err
 		}
-		if match {
-			matches = append(matches, MatchedRow{
-				Values:    row.materializeReturnValues(),
-				RuleID:    row.RuleID,
-				Comments:  row.Comments,
-				RowNumber: row.Number,
-			})
-			if dt.matchPolicy == MatchPolicyFirst {
-				break
+		if !match {
+			continue
+		}
+		if dt.matchPolicy == MatchPolicyCollect {
+			collected = append(collected, row)
+			continue
+		}
+		if dt.matchPolicy == MatchPolicyPriority {
+			if best == nil || row.Priority > best.Priority {
+				best = &dt.rows[i]
 			}
-			if dt.matchPolicy == MatchPolicyUnique && len(matches) > 1 {
-				return nil, fmt.Errorf("match policy UNIQUE expected exactly one match, found at least %d", len(matches))
+			continue
+		}
+		matched++
+		if groupMatches != nil {
+			if first, dup := groupMatches[row.Group]; dup {
+				return fmt.Errorf("match policy UNIQUE expected at most one match in group %q, found %s and %s", row.Group, first, ruleLabel(row))
 			}
+			groupMatches[row.Group] = ruleLabel(row)
+		} else if dt.matchPolicy == MatchPolicyUnique && matched > 1 {
+			return fmt.Errorf("match policy UNIQUE expected exactly one match, found at least %d", matched)
+		}
+		if !yield(dt.rowMatch(row)) {
+			return nil
+		}
+		if dt.matchPolicy == MatchPolicyFirst {
+			return nil
+		}
+	}
+
+	if best != nil {
+		yield(dt.rowMatch(*best))
+		return nil
+	}
+	if len(collected) > 0 {
+		aggregated, err := collectRows(collected)
+		if err != nil {
+			return err
 		}
+		dt.finalizeOutputs(aggregated.Values)
+		aggregated.Groups = dt.groupOutputs(aggregated.Values)
+		yield(aggregated)
+		return nil
+	}
+	if matched > 0 {
+		return nil
 	}
 
-	if len(matches) == 0 {
-		switch dt.noMatchPolicy {
-		case NoMatchPolicyReturnDefault:
-			switch {
-			case dt.defaultRow != nil:
-				matches = append(matches, MatchedRow{
-					Values:    dt.defaultRow.materializeReturnValues(),
-					RuleID:    dt.defaultRow.RuleID,
-					Comments:  dt.defaultRow.Comments,
-					RowNumber: dt.defaultRow.Number,
-				})
-			case defaultReturn != nil:
-				matches = append(matches, MatchedRow{
-					Values: cloneMap(defaultReturn),
-				})
+	for _, guarded := range dt.guardedDefaults {
+		match, err := guarded.matches(input)
+		if err != nil {
+			return fmt.Errorf("guarded default: %w", err)
+		}
+		if match {
+			m, err := dt.defaultMatch(guarded)
+			if err != nil {
+				return fmt.Errorf("guarded default: %w", err)
 			}
-		case NoMatchPolicyThrowError:
-			if dt.defaultRow != nil {
-				matches = append(matches, MatchedRow{
-					Values:    dt.defaultRow.materializeReturnValues(),
-					RuleID:    dt.defaultRow.RuleID,
-					Comments:  dt.defaultRow.Comments,
-					RowNumber: dt.defaultRow.Number,
-				})
-			} else {
-				return nil, fmt.Errorf("no rules matched and no default rule configured")
+			yield(m)
+			return nil
+		}
+	}
+	switch dt.noMatchPolicy {
+	case NoMatchPolicyReturnDefault:
+		switch {
+		case dt.defaultRow != nil:
+			m, err := dt.defaultMatch(*dt.defaultRow)
+			if err != nil {
+				return err
 			}
+			yield(m)
+		case defaultReturn != nil:
+			values := cloneMap(defaultReturn)
+			yield(MatchedRow{
+				Values:    values,
+				IsDefault: true,
+				Groups:    dt.groupOutputs(values),
+			})
+		}
+	case NoMatchPolicyThrowError:
+		if dt.defaultRow == nil {
+			return fmt.Errorf("no rules matched and no default rule configured")
+		}
+		m, err := dt.defaultMatch(*dt.defaultRow)
+		if err != nil {
+			return err
 		}
-	} else if dt.matchPolicy == MatchPolicyUnique {
-		// exactly one match already enforced during iteration
-		return matches, nil
+		yield(m)
+	case NoMatchPolicyReturnNil:
+		// nothing to return, by design
 	}
+	return nil
+}
 
-	return matches, nil
+// EvaluateVerbose evaluates every row against the input and reports each row's outcome in table order.
+// Unlike Evaluate it ignores the match policy, never short-circuits and never returns a no-match error;
+// every condition of every active row is evaluated so the condition counts are complete.
+func (dt *DecisionTable) EvaluateVerbose(input map[string]any) ([]RowResult, error) {
+	if dt == nil {
+		return nil, fmt.Errorf("decision table is nil")
+	}
+	if err := dt.checkInputKeys(MapInput(input)); err != nil {
+		return nil, err
+	}
+	results := make([]RowResult, 0, len(dt.rows))
+	now := time.Now()
+	for _, row := range dt.rows {
+		result := RowResult{
+			RuleID:          row.RuleID,
+			RowNumber:       row.Number,
+			ConditionsTotal: len(row.EvalCells),
+		}
+		if row.Disabled || !row.activeAt(now) {
+			results = append(results, result)
+			continue
+		}
+		_, matched, err := row.score(MapInput(input))
+		if err != nil {
+			return nil, err
+		}
+		result.ConditionsMatched = matched
+		result.Matched = matched == len(row.EvalCells)
+		if result.Matched {
+			result.Values = dt.finalizeOutputs(row.materializeReturnValues(!dt.sharedValues))
+			if dt.hasOutputTemplates() {
+				if err := dt.renderTemplates(result.Values, nil, dt.templateContext(MapInput(input))); err != nil {
+					return nil, err
+				}
+			}
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func (dt *DecisionTable) checkInputKeys(input InputProvider) error {
+	if !dt.rejectUnknownKeys {
+		return nil
+	}
+	keyed, ok := input.(keyedInput)
+	if !ok {
+		return nil
+	}
+	var unknown []string
+	known := make(map[string]struct{}, len(dt.conditionOrder))
+	for _, col := range dt.conditionOrder {
+		if path := dt.inputPaths[col.Name]; path != nil {
+			known[path.root] = struct{}{}
+		} else {
+			known[col.Name] = struct{}{}
+		}
+	}
+	for _, key := range keyed.Keys() {
+		if _, ok := known[key]; !ok {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+	sort.Strings(unknown)
+	return fmt.Errorf("%w: %s", ErrUnknownInputKey, strings.Join(unknown, ", "))
+}
+
+func (dt *DecisionTable) rowMatch(row Row) MatchedRow {
+	values := dt.finalizeOutputs(row.materializeReturnValues(!dt.sharedValues))
+	return MatchedRow{
+		Values:     values,
+		RuleID:     row.RuleID,
+		Comments:   row.Comments,
+		RowNumber:  row.Number,
+		Groups:     dt.groupOutputs(values),
+		Conditions: dt.matchConditions(row),
+		Metadata:   dt.matchMetadata(row),
+		RuleGroup:  row.Group,
+	}
+}
+
+// matchConditions returns the evaluation cells a result carries for row: copies, or the table's own
+// cells under WithUnsafeSharedValues.
+func (dt *DecisionTable) matchConditions(row Row) []EvalCell {
+	if dt.sharedValues {
+		return row.EvalCells
+	}
+	return cloneEvalCells(row.EvalCells)
+}
+
+// matchMetadata returns a copy of row's Metadata, or the table's own map under WithUnsafeSharedValues.
+func (dt *DecisionTable) matchMetadata(row Row) map[string]string {
+	if dt.sharedValues {
+		return row.Metadata
+	}
+	return maps.Clone(row.Metadata)
+}
+
+// defaultMatch builds the result of a default row. Under COLLECT its modifiers are applied the way
+// collectRows applies them for a single matched row, starting from unset values.
+func (dt *DecisionTable) defaultMatch(row Row) (MatchedRow, error) {
+	var values map[string]any
+	if dt.matchPolicy == MatchPolicyCollect {
+		collected, err := collectRows([]Row{row})
+		if err != nil {
+			return MatchedRow{}, fmt.Errorf("default row: %w", err)
+		}
+		values = collected.Values
+	} else {
+		values = row.materializeReturnValues(!dt.sharedValues)
+	}
+	values = dt.finalizeOutputs(values)
+	return MatchedRow{
+		Values:     values,
+		RuleID:     row.RuleID,
+		Comments:   row.Comments,
+		RowNumber:  row.Number,
+		IsDefault:  true,
+		Groups:     dt.groupOutputs(values),
+		Conditions: dt.matchConditions(row),
+		Metadata:   dt.matchMetadata(row),
+	}, nil
 }
 
 // Rows returns a shallow copy of the registered rows so callers cannot mutate the internal slice.
@@ -172,6 +598,242 @@ func (dt *DecisionTable) Rows() []Row {
 	return out
 }
 
+// ValidatePatterns re-checks that every MATCHES_REGEX cell holds a compiled pattern and reports each
+// cell that does not. Rows are compiled when added, so a report means a row was altered afterwards,
+// for example through the slices shared by Rows or GetRow.
+func (dt *DecisionTable) ValidatePatterns() []error {
+	if dt == nil {
+		return nil
+	}
+	var errs []error
+	for _, row := range dt.rows {
+		for _, cell := range row.EvalCells {
+			if cell.Operator != OperatorMatchesRegex {
+				continue
+			}
+			if re, ok := cell.Value.(*regexp.Regexp); !ok || re == nil {
+				errs = append(errs, fmt.Errorf("rule %s row %d column %s: MATCHES_REGEX cell has no compiled pattern, got %T", row.RuleID, row.Number, cell.Column, cell.Value))
+			}
+		}
+	}
+	return errs
+}
+
+// RuleIDs returns the rule IDs in row order.
+func (dt *DecisionTable) RuleIDs() []string {
+	if dt == nil {
+		return nil
+	}
+	ids := make([]string, len(dt.rows))
+	for i, row := range dt.rows {
+		ids[i] = row.RuleID
+	}
+	return ids
+}
+
+// GetRow returns the row with the given rule ID.
+func (dt *DecisionTable) GetRow(ruleID string) (Row, bool) {
+	if i := dt.rowIndex(ruleID); i >= 0 {
+		return dt.rows[i], true
+	}
+	return Row{}, false
+}
+
+// UpdateRow replaces the row with the given rule ID in place, keeping its position and row number.
+// The replacement is sanitized like AddRow; an empty RuleID keeps the existing ID.
+func (dt *DecisionTable) UpdateRow(ruleID string, row Row) error {
+	i := dt.rowIndex(ruleID)
+	if i < 0 {
+		return fmt.Errorf("%w: %q", ErrRuleNotFound, ruleID)
+	}
+	prepared, err := dt.prepareRule(row)
+	if err != nil {
+		return err
+	}
+	if prepared.RuleID == "" {
+		prepared.RuleID = ruleID
+		prepared.autoID = dt.rows[i].autoID
+	}
+	if j := dt.rowIndex(prepared.RuleID); j >= 0 && j != i {
+		return fmt.Errorf("duplicate rule id %q (used by row %d)", prepared.RuleID, dt.rows[j].Number)
+	}
+	prepared.Number = dt.rows[i].Number
+	if err := dt.checkUniqueness(prepared, i); err != nil {
+		return err
+	}
+	dt.rows[i] = prepared
+	dt.pruneOutputTemplates()
+	return nil
+}
+
+// DisableRule keeps the rule with the given ID in the table but skips it during evaluation.
+func (dt *DecisionTable) DisableRule(ruleID string) error {
+	return dt.setRuleDisabled(ruleID, true)
+}
+
+// EnableRule re-enables a rule previously disabled with DisableRule or by its source file.
+func (dt *DecisionTable) EnableRule(ruleID string) error {
+	return dt.setRuleDisabled(ruleID, false)
+}
+
+func (dt *DecisionTable) setRuleDisabled(ruleID string, disabled bool) error {
+	i := dt.rowIndex(ruleID)
+	if i < 0 {
+		return fmt.Errorf("%w: %q", ErrRuleNotFound, ruleID)
+	}
+	if !disabled {
+		enabled := dt.rows[i]
+		enabled.Disabled = false
+		if err := dt.checkUniqueness(enabled, i); err != nil {
+			return err
+		}
+	}
+	dt.rows[i].Disabled = disabled
+	return nil
+}
+
+// RemoveRow deletes the row with the given rule ID. Remaining rows keep their row numbers.
+func (dt *DecisionTable) RemoveRow(ruleID string) error {
+	i := dt.rowIndex(ruleID)
+	if i < 0 {
+		return fmt.Errorf("%w: %q", ErrRuleNotFound, ruleID)
+	}
+	dt.rows = append(dt.rows[:i:i], dt.rows[i+1:]...)
+	dt.pruneOutputTemplates()
+	return nil
+}
+
+// InsertRowAt inserts a row before the row at index, or appends it when index equals RowCount. The row
+// is sanitized like AddRow; without a Number it takes index+1, and later rows keep their numbers until
+// Renumber is called.
+func (dt *DecisionTable) InsertRowAt(index int, row Row) error {
+	if index < 0 || index > len(dt.rows) {
+		return fmt.Errorf("row index %d out of range [0, %d]", index, len(dt.rows))
+	}
+	prepared, err := dt.prepareRule(row)
+	if err != nil {
+		return err
+	}
+	if prepared.Number <= 0 {
+		prepared.Number = index + 1
+	}
+	if err := dt.checkUniqueness(prepared, -1); err != nil {
+		return err
+	}
+	dt.rows = slices.Insert(dt.rows, index, prepared)
+	return nil
+}
+
+// Renumber reassigns row numbers 1..n in table order, and n+1 to the default row, so RowNumber in
+// results follows the current order after edits. With regenerateIDs, rule IDs that a loader generated
+// because the source gave none are generated again from the new numbers; IDs written in the source or
+// set by the caller are kept. It fails without changing the table if a regenerated ID would collide.
+func (dt *DecisionTable) Renumber(regenerateIDs bool) error {
+	ids := make([]string, len(dt.rows))
+	seen := make(map[string]struct{}, len(dt.rows)+1)
+	for i, row := range dt.rows {
+		ids[i] = row.RuleID
+		if regenerateIDs && row.autoID {
+			ids[i] = dt.generateRuleID(i + 1)
+		}
+		if _, dup := seen[ids[i]]; dup && regenerateIDs && ids[i] != "" {
+			return fmt.Errorf("renumbered row %d: duplicate rule id %q", i+1, ids[i])
+		}
+		seen[ids[i]] = struct{}{}
+	}
+	defaultID := ""
+	if dt.defaultRow != nil {
+		defaultID = dt.defaultRow.RuleID
+		if regenerateIDs && dt.defaultRow.autoID {
+			defaultID = dt.generateRuleID(len(dt.rows) + 1)
+		}
+		if _, dup := seen[defaultID]; dup && regenerateIDs && defaultID != "" {
+			return fmt.Errorf("renumbered default row: duplicate rule id %q", defaultID)
+		}
+	}
+
+	for i := range dt.rows {
+		dt.rows[i].Number = i + 1
+		dt.rows[i].RuleID = ids[i]
+	}
+	if dt.defaultRow != nil {
+		dt.defaultRow.Number = len(dt.rows) + 1
+		dt.defaultRow.RuleID = defaultID
+	}
+	return nil
+}
+
+func (dt *DecisionTable) rowIndex(ruleID string) int {
+	if dt == nil {
+		return -1
+	}
+	for i, row := range dt.rows {
+		if row.RuleID == ruleID {
+			return i
+		}
+	}
+	return -1
+}
+
+// ConditionColumns returns the condition columns in declaration order.
+func (dt *DecisionTable) ConditionColumns() []Column {
+	if dt == nil {
+		return nil
+	}
+	return append([]Column(nil), dt.conditionOrder...)
+}
+
+// OutputColumns returns the conclusion and metadata columns in declaration order.
+func (dt *DecisionTable) OutputColumns() []Column {
+	if dt == nil {
+		return nil
+	}
+	return append([]Column(nil), dt.outputOrder...)
+}
+
+// Version reports the rule set version declared by the source file or WithVersion, if any.
+func (dt *DecisionTable) Version() string {
+	if dt == nil {
+		return ""
+	}
+	return dt.version
+}
+
+// MatchPolicy reports the configured match policy; a nil table reports ALL, the constructor default.
+func (dt *DecisionTable) MatchPolicy() MatchPolicy {
+	if dt == nil {
+		return MatchPolicyAll
+	}
+	return dt.matchPolicy
+}
+
+// NoMatchPolicy reports the configured no-match policy; a nil table reports THROW_ERROR, the
+// constructor default.
+func (dt *DecisionTable) NoMatchPolicy() NoMatchPolicy {
+	if dt == nil {
+		return NoMatchPolicyThrowError
+	}
+	return dt.noMatchPolicy
+}
+
+// NoMatchBehavior describes what Evaluate does when no rule matches, without evaluating anything, so
+// configuration checks can flag tables that will fail on an unmatched input.
+func (dt *DecisionTable) NoMatchBehavior() NoMatchDescription {
+	desc := NoMatchDescription{Policy: dt.noMatchPolicy, GuardedDefaults: len(dt.guardedDefaults)}
+	if dt.defaultRow != nil {
+		desc.HasDefaultRow = true
+		desc.DefaultRuleID = dt.defaultRow.RuleID
+		return desc
+	}
+	switch dt.noMatchPolicy {
+	case NoMatchPolicyReturnDefault:
+		desc.UsesDefaultReturn = true
+	case NoMatchPolicyThrowError:
+		desc.Fails = true
+	}
+	return desc
+}
+
 // RowCount exposes the number of rows currently stored.
 func (dt *DecisionTable) RowCount() int {
 	if dt == nil {
@@ -180,6 +842,121 @@ func (dt *DecisionTable) RowCount() int {
 	return len(dt.rows)
 }
 
+func (dt *DecisionTable) generateRuleID(rowNumber int) string {
+	if dt != nil && dt.ruleIDGenerator != nil {
+		return dt.ruleIDGenerator(rowNumber)
+	}
+	return strconv.Itoa(rowNumber)
+}
+
+// requiresExplicitAny reports whether blank tabular condition cells must be written as ANY or *.
+func (dt *DecisionTable) requiresExplicitAny() bool {
+	return dt.requireExplicitAny && dt.rowValidation == RowValidationStrict
+}
+
+// finalizeOutputs applies the output options in place: it drops null values under WithSparseOutput
+// and rounds decimal values when WithDecimalOutputScale is configured.
+func (dt *DecisionTable) finalizeOutputs(values map[string]any) map[string]any {
+	if !dt.roundDecimalOutputs && !dt.sparseOutput {
+		return values
+	}
+	for name, v := range values {
+		if v == nil && dt.sparseOutput {
+			delete(values, name)
+			continue
+		}
+		if !dt.roundDecimalOutputs {
+			continue
+		}
+		col, ok := dt.outputColumns[name]
+		if !ok || col.DataType != DataTypeDecimal {
+			continue
+		}
+		if dec, ok := v.(*big.Float); ok {
+			values[name] = roundDecimal(dec, dt.decimalOutputScale)
+		}
+	}
+	return values
+}
+
+// CoerceInput returns the condition column values of input coerced to each column's data type, as
+// the engine compares them: a date string becomes a time.Time, "12" on an INTEGER column an int64 and
+// a decimal a *big.Float. Keys that name no condition column are left out, or rejected under
+// WithRejectUnknownInputKeys, and null values stay nil. Per-cell transforms and string normalization are
+// not applied, since they differ between rules. The first value that fails to coerce is reported with
+// its column name.
+func (dt *DecisionTable) CoerceInput(input map[string]any) (map[string]any, error) {
+	if err := dt.checkInputKeys(MapInput(input)); err != nil {
+		return nil, err
+	}
+	coerced := make(map[string]any, len(dt.conditionOrder))
+	for _, col := range dt.conditionOrder {
+		raw, ok := dt.columnInput(MapInput(input), col)
+		if !ok {
+			continue
+		}
+		if unit, _ := col.unitParser(); unit != nil {
+			raw = applyUnit(unit, raw)
+		}
+		value, err := sanitizeActualValue(col.DataType, raw)
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %w", col.Name, err)
+		}
+		coerced[col.Name] = value
+	}
+	return coerced, nil
+}
+
+// echoedInput captures the condition column values of input, coerced to each column's data type
+// the way evaluation sees them. Values that fail to coerce are kept as given.
+func (dt *DecisionTable) echoedInput(input InputProvider) map[string]any {
+	echo := make(map[string]any, len(dt.conditionOrder))
+	if input == nil {
+		return echo
+	}
+	for _, col := range dt.conditionOrder {
+		raw, ok := dt.columnInput(input, col)
+		if !ok {
+			continue
+		}
+		parsed := raw
+		if unit, _ := col.unitParser(); unit != nil {
+			parsed = applyUnit(unit, raw)
+		}
+		value, err := coercePrimitive(col.DataType, parsed)
+		if err != nil {
+			value = cloneArbitraryValue(raw)
+		}
+		echo[col.Name] = value
+	}
+	return echo
+}
+
+// groupOutputs copies the values of grouped output columns into per-group maps.
+func (dt *DecisionTable) groupOutputs(values map[string]any) map[string]map[string]any {
+	var groups map[string]map[string]any
+	for _, col := range dt.outputOrder {
+		if col.Group == "" {
+			continue
+		}
+		if groups == nil {
+			groups = make(map[string]map[string]any)
+		}
+		group, ok := groups[col.Group]
+		if !ok {
+			group = make(map[string]any)
+			groups[col.Group] = group
+		}
+		if v, present := values[col.Name]; present {
+			if !dt.sharedValues {
+				v = cloneArbitraryValue(v)
+			}
+			group[col.Name] = v
+		}
+	}
+	return groups
+}
+
 func cloneMap(src map[string]any) map[string]any {
 	if src == nil {
 		return nil
@@ -212,6 +989,22 @@ func buildColumnMap(cols []Column, allowedTypes ...ColumnType) (map[string]Colum
 	return result, nil
 }
 
+// prepareRule sanitizes a rule row under the table's row validation and checks that it constrains
+// every required condition column.
+func (dt *DecisionTable) prepareRule(row Row) (Row, error) {
+	strict := dt.rowValidation == RowValidationStrict
+	prepared, err := dt.prepareRow(row, strict && len(dt.conditionColumns) > 0, strict)
+	if err != nil {
+		return Row{}, err
+	}
+	for i, cell := range prepared.ConditionsByColumn(dt.conditionOrder) {
+		if cell == nil && dt.conditionOrder[i].Required {
+			return Row{}, fmt.Errorf("required column %s has no condition", dt.conditionOrder[i].Name)
+		}
+	}
+	return prepared, nil
+}
+
 func (dt *DecisionTable) prepareRow(row Row, requireEval bool, requireReturn bool) (Row, error) {
 	if dt == nil {
 		return Row{}, fmt.Errorf("decision table is nil")
@@ -229,25 +1022,144 @@ func (dt *DecisionTable) prepareRow(row Row, requireEval bool, requireReturn boo
 		RuleID:      row.RuleID,
 		Comments:    row.Comments,
 		Number:      row.Number,
+		Priority:    row.Priority,
+		Disabled:    row.Disabled,
+		Metadata:    maps.Clone(row.Metadata),
+		ValidFrom:   row.ValidFrom,
+		ValidUntil:  row.ValidUntil,
+		Group:       strings.TrimSpace(row.Group),
+		autoID:      row.autoID,
+	}
+	if err := checkValidity(row); err != nil {
+		return Row{}, err
 	}
 
+	seen := make(map[string]struct{}, len(row.EvalCells))
 	for i, cell := range row.EvalCells {
 		col, ok := dt.conditionColumns[cell.Column]
 		if !ok {
 			return Row{}, fmt.Errorf("%w %q", ErrUnknownColumn, cell.Column)
 		}
+		if _, dup := seen[col.Name]; dup && !dt.allowRepeatedColumns {
+			return Row{}, fmt.Errorf("column %s has more than one evaluation cell in the same row", col.Name)
+		}
+		seen[col.Name] = struct{}{}
 		if cell.Operator == "" {
 			return Row{}, fmt.Errorf("column %s missing operator", col.Name)
 		}
-		value, err := sanitizeExpectedValue(col.DataType, cell.Operator, cell.Value)
+		if cell.ValueRef != "" {
+			if err := dt.checkValueRef(col, cell); err != nil {
+				return Row{}, fmt.Errorf("column %s: %w", col.Name, err)
+			}
+		}
+		raw, interval, op := cell.Value, cell.Interval, cell.Operator
+		if op == OperatorDefault {
+			if cell.Value != nil || cell.ValueRef != "" {
+				return Row{}, fmt.Errorf("column %s: operator DEFAULT takes no value", col.Name)
+			}
+			if col.Default == nil {
+				return Row{}, fmt.Errorf("column %s: operator DEFAULT requires a column default", col.Name)
+			}
+			// the cell compares the input with the column default as EQ would
+			raw, op = col.Default, OperatorEqual
+		}
+		if cell.ValueRef != "" {
+			// the expected value is read from the referenced input, so there is nothing to sanitize
+		} else if isBetweenOperator(cell.Operator) {
+			var err error
+			if raw, interval, err = splitInterval(raw, interval); err != nil {
+				return Row{}, fmt.Errorf("column %s: %w", col.Name, err)
+			}
+		}
+		unit, err := col.unitParser()
+		if err != nil {
+			return Row{}, err
+		}
+		if unit != nil && !isCustomOperator(op) {
+			raw = applyUnit(unit, raw)
+		} else {
+			unit = nil
+		}
+		var value any
+		if cell.ValueRef == "" {
+			if !isCustomOperator(op) {
+				if err := col.checkScale(raw); err != nil {
+					return Row{}, fmt.Errorf("column %s: %w", col.Name, err)
+				}
+			}
+			var err error
+			if value, err = sanitizeExpectedValue(col.DataType, op, raw, cell.Flags, interval, dt.allowEmptyCollections); err != nil {
+				return Row{}, fmt.Errorf("column %s: %w", col.Name, err)
+			}
+		}
+		valueRange, err := col.valueRange()
 		if err != nil {
-			return Row{}, fmt.Errorf("column %s: %w", col.Name, err)
+			return Row{}, err
+		}
+		if !isCustomOperator(cell.Operator) {
+			if err := valueRange.check(value); err != nil {
+				return Row{}, fmt.Errorf("column %s: %w", col.Name, err)
+			}
+		}
+		if cell.Weight < 0 || math.IsNaN(cell.Weight) || math.IsInf(cell.Weight, 0) {
+			return Row{}, fmt.Errorf("column %s: weight must be a finite non-negative number", col.Name)
+		}
+		var normalize func(string) string
+		if dt.stringNormalizer != nil && isStringDataType(col.DataType) && !isCustomOperator(cell.Operator) {
+			normalize = dt.stringNormalizer
+		}
+		if cell.Transform != "" {
+			if !isStringDataType(col.DataType) || isCustomOperator(cell.Operator) {
+				return Row{}, fmt.Errorf("column %s: transforms only apply to built-in operators on string columns", col.Name)
+			}
+			if normalize, err = resolveTransform(cell.Transform, normalize); err != nil {
+				return Row{}, fmt.Errorf("column %s: %w", col.Name, err)
+			}
+		}
+		if normalize != nil && cell.Operator != OperatorMatchesRegex {
+			value = normalizeStrings(value, normalize)
 		}
 		prepared.EvalCells[i] = EvalCell{
-			Column:   col.Name,
-			Operator: cell.Operator,
-			Value:    value,
-			dataType: col.DataType,
+			Column:     col.Name,
+			Operator:   cell.Operator,
+			Value:      value,
+			ValueRef:   cell.ValueRef,
+			Weight:     cell.Weight,
+			Flags:      cell.Flags,
+			Interval:   interval,
+			Transform:  cell.Transform,
+			dataType:   col.DataType,
+			normalize:  normalize,
+			valueRange: valueRange,
+			unit:       unit,
+		}
+		if cell.Operator == OperatorDefault {
+			// the cell keeps no Value of its own so that it can be added again as it was read
+			prepared.EvalCells[i].Value = nil
+			prepared.EvalCells[i].defaultValue = value
+		}
+		if cell.Operator == OperatorMatchesRegex {
+			prepared.EvalCells[i].maxLength = dt.maxStringLength
+		}
+		if cell.Operator == OperatorAllEqual {
+			prepared.EvalCells[i].vacuousAllEqual = dt.vacuousAllEqual
+		}
+		prepared.EvalCells[i].path = dt.inputPaths[col.Name]
+		prepared.EvalCells[i].missing = col.MissingPolicy
+		if col.MissingPolicy == MissingPolicyDefault {
+			prepared.EvalCells[i].missingDefault = col.Default
+		}
+		if col.Currency != "" {
+			prepared.EvalCells[i].currency = strings.TrimSpace(col.Currency)
+			prepared.EvalCells[i].currencyColumn = col.CurrencyColumn
+			prepared.EvalCells[i].currencyPath = dt.inputPaths[col.CurrencyColumn]
+		}
+		if cell.ValueRef != "" {
+			prepared.EvalCells[i].refPath = dt.inputPaths[cell.ValueRef]
+		}
+		switch cell.Operator {
+		case OperatorNotEqual, OperatorNotIn, OperatorNotBetween:
+			prepared.EvalCells[i].nullUnknown = dt.threeValuedNulls
 		}
 	}
 
@@ -256,13 +1168,41 @@ func (dt *DecisionTable) prepareRow(row Row, requireEval bool, requireReturn boo
 		if !ok {
 			return Row{}, fmt.Errorf("%w %q", ErrUnknownColumn, cell.Column)
 		}
-		value, err := sanitizeReturnValue(col.DataType, cell.Value)
+		modifier, operand, err := splitReturnModifier(col.DataType, cell)
 		if err != nil {
 			return Row{}, fmt.Errorf("return column %s: %w", col.Name, err)
 		}
+		if modifier != ModifierNone && dt.matchPolicy != MatchPolicyCollect {
+			return Row{}, fmt.Errorf("return column %s: modifier %s requires COLLECT match policy", col.Name, modifier)
+		}
+		if err := col.checkScale(operand); err != nil {
+			return Row{}, fmt.Errorf("return column %s: %w", col.Name, err)
+		}
+		value, err := sanitizeReturnValue(col.DataType, operand)
+		if err != nil {
+			return Row{}, fmt.Errorf("return column %s: %w", col.Name, err)
+		}
+		if modifier != ModifierNone && value == nil {
+			return Row{}, fmt.Errorf("return column %s: modifier %s requires an operand", col.Name, modifier)
+		}
+		if col.Template {
+			if err := dt.prepareOutputTemplate(col, value); err != nil {
+				return Row{}, fmt.Errorf("return column %s: %w", col.Name, err)
+			}
+		}
+		if modifier == ModifierNone {
+			valueRange, err := col.valueRange()
+			if err != nil {
+				return Row{}, err
+			}
+			if err := valueRange.check(value); err != nil {
+				return Row{}, fmt.Errorf("return column %s: %w", col.Name, err)
+			}
+		}
 		prepared.ReturnCells[i] = ReturnCell{
 			Column:   col.Name,
 			Value:    value,
+			Modifier: modifier,
 			dataType: col.DataType,
 		}
 	}
@@ -0,0 +1,76 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// EvaluateAsOf behaves like Evaluate with no caller default but treats asOf as the current time when
+// deciding which rules are in effect, so a table can be checked as it applied on a past date or will
+// apply on a future one.
+func (dt *DecisionTable) EvaluateAsOf(input map[string]any, asOf time.Time) ([]MatchedRow, error) {
+	var matches []MatchedRow
+	err := dt.evaluateFunc(MapInput(input), nil, asOf, func(m MatchedRow) bool {
+		matches = append(matches, m)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return matches, nil
+}
+
+// activeAt reports whether the row is in effect at t: from ValidFrom inclusive until ValidUntil
+// exclusive, with a zero bound leaving that side open.
+func (r Row) activeAt(t time.Time) bool {
+	if !r.ValidFrom.IsZero() && t.Before(r.ValidFrom) {
+		return false
+	}
+	return r.ValidUntil.IsZero() || t.Before(r.ValidUntil)
+}
+
+// validityOverlaps reports whether two rows are ever in effect at the same time.
+func validityOverlaps(a, b Row) bool {
+	if !a.ValidUntil.IsZero() && !b.ValidFrom.IsZero() && !b.ValidFrom.Before(a.ValidUntil) {
+		return false
+	}
+	return a.ValidFrom.IsZero() || b.ValidUntil.IsZero() || a.ValidFrom.Before(b.ValidUntil)
+}
+
+// checkValidity rejects a validity window that ends before it starts.
+func checkValidity(row Row) error {
+	if !row.ValidFrom.IsZero() && !row.ValidUntil.IsZero() && !row.ValidFrom.Before(row.ValidUntil) {
+		return fmt.Errorf("validUntil %s must be after validFrom %s", row.ValidUntil.Format(time.RFC3339), row.ValidFrom.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// parseValidityBound reads a validFrom or validUntil value written as an RFC 3339 timestamp or a
+// date. A date for validUntil keeps the rule in effect through that whole day (UTC).
+func parseValidityBound(raw any, until bool) (time.Time, error) {
+	ts, err := parseISODateTime(raw)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if s, ok := raw.(string); ok && until {
+		if _, dateErr := time.Parse("2006-01-02", strings.TrimSpace(s)); dateErr == nil {
+			ts = ts.AddDate(0, 0, 1)
+		}
+	}
+	return ts, nil
+}
@@ -0,0 +1,123 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+const effectiveTableJSON = `
+{
+  "decisionTable": {
+    "name": "vat",
+    "policies": {"matchPolicy": "FIRST", "noMatchPolicy": "RETURN_DEFAULT"},
+    "columns": [
+      {"name": "country", "type": "CONDITION", "dataType": "STRING"},
+      {"name": "rate", "type": "CONCLUSION", "dataType": "INTEGER"}
+    ],
+    "rules": [
+      {"id": "old", "validUntil": "2024-12-31", "when": [{"operator": "equal", "value": "DE"}], "then": [19]},
+      {"id": "new", "validFrom": "2025-01-01", "validUntil": "2025-06-30T12:00:00Z", "when": [{"operator": "equal", "value": "DE"}], "then": [21]},
+      {"id": "future", "validFrom": "2099-01-01", "when": [{"operator": "equal", "value": "DE"}], "then": [25]}
+    ],
+    "defaultRule": {"then": [0]}
+  }
+}`
+
+func TestEvaluateAsOfSkipsRulesOutOfEffect(t *testing.T) {
+	dt, err := LoadJSON([]byte(effectiveTableJSON), "vat.json")
+	if err != nil {
+		t.Fatalf("load json: %v", err)
+	}
+	cases := []struct {
+		asOf string
+		want string
+	}{
+		{"2024-06-01T00:00:00Z", "old"},
+		{"2024-12-31T23:59:59Z", "old"},
+		{"2025-01-01T00:00:00Z", "new"},
+		{"2025-06-30T11:59:59Z", "new"},
+		{"2025-06-30T12:00:00Z", "default"},
+		{"2099-01-01T00:00:00Z", "future"},
+	}
+	for _, tc := range cases {
+		asOf, err := time.Parse(time.RFC3339, tc.asOf)
+		if err != nil {
+			t.Fatalf("parse %s: %v", tc.asOf, err)
+		}
+		rows, err := dt.EvaluateAsOf(map[string]any{"country": "DE"}, asOf)
+		if err != nil {
+			t.Fatalf("evaluate as of %s returned error: %v", tc.asOf, err)
+		}
+		if len(rows) != 1 {
+			t.Fatalf("as of %s: expected one row, got %#v", tc.asOf, rows)
+		}
+		got := rows[0].RuleID
+		if rows[0].IsDefault {
+			got = "default"
+		}
+		if got != tc.want {
+			t.Fatalf("as of %s: expected %s, got %#v", tc.asOf, tc.want, rows)
+		}
+	}
+
+	// Evaluate checks the current time, when the old rule has expired and the future one is not yet active.
+	rows, err := dt.Evaluate(map[string]any{"country": "DE"}, nil)
+	if err != nil {
+		t.Fatalf("evaluate returned error: %v", err)
+	}
+	if len(rows) != 1 || !rows[0].IsDefault {
+		t.Fatalf("expected no rule in effect now, got %#v", rows)
+	}
+}
+
+func TestValidityWindowValidation(t *testing.T) {
+	dt, err := NewDecisionTable("vat",
+		[]Column{{Name: "country", Type: ColumnTypeCondition, DataType: DataTypeString}},
+		[]Column{{Name: "rate", Type: ColumnTypeConclusion, DataType: DataTypeInteger}},
+	)
+	if err != nil {
+		t.Fatalf("new table: %v", err)
+	}
+	day := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	err = dt.AddRow(Row{
+		EvalCells:   []EvalCell{{Column: "country", Operator: OperatorEqual, Value: "DE"}},
+		ReturnCells: []ReturnCell{{Column: "rate", Value: 19}},
+		ValidFrom:   day,
+		ValidUntil:  day,
+	})
+	if err == nil || !strings.Contains(err.Error(), "must be after validFrom") {
+		t.Fatalf("expected empty window to be rejected, got %v", err)
+	}
+	err = dt.SetDefaultRow(Row{ReturnCells: []ReturnCell{{Column: "rate", Value: 0}}, ValidFrom: day})
+	if err == nil || !strings.Contains(err.Error(), "validity window") {
+		t.Fatalf("expected default row window to be rejected, got %v", err)
+	}
+	if _, err := LoadJSON([]byte(strings.Replace(effectiveTableJSON, `"2099-01-01"`, `"soon"`, 1)), "vat.json"); err == nil || !strings.Contains(err.Error(), "validFrom") {
+		t.Fatalf("expected an invalid validFrom to fail, got %v", err)
+	}
+}
+
+func TestOverlapIgnoresDisjointValidity(t *testing.T) {
+	dt, err := LoadJSON([]byte(strings.Replace(effectiveTableJSON, `"FIRST"`, `"UNIQUE"`, 1)), "vat.json")
+	if err != nil {
+		t.Fatalf("load json: %v", err)
+	}
+	if overlaps := dt.DetectOverlaps(); len(overlaps) != 0 {
+		t.Fatalf("expected rules with disjoint windows not to overlap, got %v", overlaps)
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import (
+	"fmt"
+	"maps"
+	"math/big"
+	"reflect"
+	"regexp"
+	"sort"
+	"time"
+)
+
+// Equal reports whether two tables have the same name, version, policies, columns, rules and default
+// row. Sanitized values are compared semantically: decimals by value, times by instant, regular
+// expressions by pattern. Cells within a row are compared by column, so their order does not matter.
+// Function-valued options are not compared.
+func (dt *DecisionTable) Equal(other *DecisionTable) bool {
+	return dt.EqualErr(other) == nil
+}
+
+// EqualErr is Equal that reports the first difference it finds, or nil when the tables are equal.
+func (dt *DecisionTable) EqualErr(other *DecisionTable) error {
+	if dt == nil || other == nil {
+		if dt == nil && other == nil {
+			return nil
+		}
+		return fmt.Errorf("one table is nil")
+	}
+	switch {
+	case dt.Name != other.Name:
+		return fmt.Errorf("name %q != %q", dt.Name, other.Name)
+	case dt.version != other.version:
+		return fmt.Errorf("version %q != %q", dt.version, other.version)
+	case dt.matchPolicy != other.matchPolicy:
+		return fmt.Errorf("match policy %s != %s", dt.matchPolicy, other.matchPolicy)
+	case dt.noMatchPolicy != other.noMatchPolicy:
+		return fmt.Errorf("no-match policy %s != %s", dt.noMatchPolicy, other.noMatchPolicy)
+	case dt.rowValidation != other.rowValidation:
+		return fmt.Errorf("row validation %d != %d", dt.rowValidation, other.rowValidation)
+	}
+	if err := columnsEqual("condition", dt.conditionOrder, other.conditionOrder); err != nil {
+		return err
+	}
+	if err := columnsEqual("output", dt.outputOrder, other.outputOrder); err != nil {
+		return err
+	}
+	if len(dt.rows) != len(other.rows) {
+		return fmt.Errorf("row count %d != %d", len(dt.rows), len(other.rows))
+	}
+	for i := range dt.rows {
+		if err := rowsEqual(dt.rows[i], other.rows[i]); err != nil {
+			return fmt.Errorf("row %d: %w", i, err)
+		}
+	}
+	if (dt.defaultRow == nil) != (other.defaultRow == nil) {
+		return fmt.Errorf("default row present %v != %v", dt.defaultRow != nil, other.defaultRow != nil)
+	}
+	if dt.defaultRow != nil {
+		if err := rowsEqual(*dt.defaultRow, *other.defaultRow); err != nil {
+			return fmt.Errorf("default row: %w", err)
+		}
+	}
+	if len(dt.guardedDefaults) != len(other.guardedDefaults) {
+		return fmt.Errorf("guarded default count %d != %d", len(dt.guardedDefaults), len(other.guardedDefaults))
+	}
+	for i := range dt.guardedDefaults {
+		if err := rowsEqual(dt.guardedDefaults[i], other.guardedDefaults[i]); err != nil {
+			return fmt.Errorf("guarded default %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func columnsEqual(kind string, a, b []Column) error {
+	if len(a) != len(b) {
+		return fmt.Errorf("%s column count %d != %d", kind, len(a), len(b))
+	}
+	for i := range a {
+		x, y := a[i], b[i]
+		if x.Name != y.Name || x.Label != y.Label || x.Type != y.Type || x.DataType != y.DataType || x.Group != y.Group || x.Template != y.Template || x.Unit != y.Unit || x.Required != y.Required || x.Scale != y.Scale || x.Path != y.Path || x.MissingPolicy != y.MissingPolicy || x.Currency != y.Currency || x.CurrencyColumn != y.CurrencyColumn ||
+			formatBound(x, x.Min) != formatBound(y, y.Min) || formatBound(x, x.Max) != formatBound(y, y.Max) ||
+			(x.Default == nil) != (y.Default == nil) || formatBound(x, x.Default) != formatBound(y, y.Default) {
+			return fmt.Errorf("%s column %d: %+v != %+v", kind, i, x, y)
+		}
+	}
+	return nil
+}
+
+func rowsEqual(a, b Row) error {
+	switch {
+	case a.RuleID != b.RuleID:
+		return fmt.Errorf("rule id %q != %q", a.RuleID, b.RuleID)
+	case a.Comments != b.Comments:
+		return fmt.Errorf("comments %q != %q", a.Comments, b.Comments)
+	case a.Number != b.Number:
+		return fmt.Errorf("number %d != %d", a.Number, b.Number)
+	case a.Priority != b.Priority:
+		return fmt.Errorf("priority %d != %d", a.Priority, b.Priority)
+	case a.Group != b.Group:
+		return fmt.Errorf("group %q != %q", a.Group, b.Group)
+	case a.Disabled != b.Disabled:
+		return fmt.Errorf("disabled %v != %v", a.Disabled, b.Disabled)
+	case !a.ValidFrom.Equal(b.ValidFrom) || !a.ValidUntil.Equal(b.ValidUntil):
+		return fmt.Errorf("validity %v..%v != %v..%v", a.ValidFrom, a.ValidUntil, b.ValidFrom, b.ValidUntil)
+	case !maps.Equal(a.Metadata, b.Metadata):
+		return fmt.Errorf("metadata %v != %v", a.Metadata, b.Metadata)
+	case len(a.EvalCells) != len(b.EvalCells):
+		return fmt.Errorf("condition count %d != %d", len(a.EvalCells), len(b.EvalCells))
+	case len(a.ReturnCells) != len(b.ReturnCells):
+		return fmt.Errorf("return count %d != %d", len(a.ReturnCells), len(b.ReturnCells))
+	}
+	left, right := cellsByColumn(a.EvalCells), cellsByColumn(b.EvalCells)
+	for i, x := range left {
+		y := right[i]
+		if x.Column != y.Column || x.Operator != y.Operator || x.Weight != y.Weight || x.Flags != y.Flags || x.Interval != y.Interval ||
+			x.Transform != y.Transform || x.ValueRef != y.ValueRef || !valuesEqual(x.Value, y.Value) {
+			return fmt.Errorf("condition %s: %s != %s", x.Column, describeCell(x), describeCell(y))
+		}
+	}
+	returnsLeft, returnsRight := returnsByColumn(a.ReturnCells), returnsByColumn(b.ReturnCells)
+	for i, x := range returnsLeft {
+		y := returnsRight[i]
+		if x.Column != y.Column || x.Modifier != y.Modifier || !valuesEqual(x.Value, y.Value) {
+			return fmt.Errorf("return %s: %v != %v", x.Column, formatValue(x.dataType, x.Value), formatValue(y.dataType, y.Value))
+		}
+	}
+	return nil
+}
+
+func cellsByColumn(cells []EvalCell) []EvalCell {
+	sorted := append([]EvalCell(nil), cells...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Column < sorted[j].Column })
+	return sorted
+}
+
+func returnsByColumn(cells []ReturnCell) []ReturnCell {
+	sorted := append([]ReturnCell(nil), cells...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Column < sorted[j].Column })
+	return sorted
+}
+
+func valuesEqual(a, b any) bool {
+	switch x := a.(type) {
+	case *big.Float:
+		y, ok := b.(*big.Float)
+		return ok && (x == nil) == (y == nil) && (x == nil || x.Cmp(y) == 0)
+	case time.Time:
+		y, ok := b.(time.Time)
+		return ok && x.Equal(y)
+	case *regexp.Regexp:
+		y, ok := b.(*regexp.Regexp)
+		return ok && x.String() == y.String()
+	case []any:
+		y, ok := b.([]any)
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !valuesEqual(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(a, b)
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestEqualAfterCSVRoundTrip(t *testing.T) {
+	dt := buildSampleTable(t, WithMatchPolicy(MatchPolicyFirst))
+	if err := dt.AddRow(Row{
+		RuleID:      "promo",
+		EvalCells:   []EvalCell{{Column: "country", Operator: OperatorMatchesRegex, Value: "^U"}, {Column: "age", Operator: OperatorBetween, Value: "[18..65)"}},
+		ReturnCells: []ReturnCell{{Column: "tier", Value: "promo"}, {Column: "discount", Value: "0.10"}},
+	}); err != nil {
+		t.Fatalf("add row: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := WriteCSV(dt, &buf); err != nil {
+		t.Fatalf("write csv: %v", err)
+	}
+	reloaded, err := LoadCSV(dt.Name, &buf, WithMatchPolicy(MatchPolicyFirst))
+	if err != nil {
+		t.Fatalf("load csv: %v", err)
+	}
+	if err := dt.EqualErr(reloaded); err != nil {
+		t.Fatalf("expected round trip to be equal: %v", err)
+	}
+	if !dt.Equal(dt) {
+		t.Fatalf("expected a table to equal itself")
+	}
+}
+
+func TestEqualReportsFirstDifference(t *testing.T) {
+	a := buildSampleTable(t)
+	b := buildSampleTable(t)
+	if !a.Equal(b) {
+		t.Fatalf("expected identical tables to be equal: %v", a.EqualErr(b))
+	}
+	if err := b.UpdateRow(b.RuleIDs()[0], Row{
+		EvalCells:   []EvalCell{{Column: "age", Operator: OperatorGreaterOrEqual, Value: 21}},
+		ReturnCells: []ReturnCell{{Column: "tier", Value: "standard"}},
+	}); err != nil {
+		t.Fatalf("update row: %v", err)
+	}
+	err := a.EqualErr(b)
+	if err == nil || !strings.Contains(err.Error(), "row 0") {
+		t.Fatalf("expected a row 0 difference, got %v", err)
+	}
+	if a.Equal(nil) {
+		t.Fatalf("expected a table not to equal nil")
+	}
+}
@@ -0,0 +1,265 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// excelDefaultVersion fills the Version row, which the loader requires, for tables without a version.
+const excelDefaultVersion = "1.0"
+
+// SaveExcelFile writes the table to path using the legacy layout understood by LoadExcelFile.
+func SaveExcelFile(dt *DecisionTable, path string) error {
+	f, err := buildExcelWorkbook(dt)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := f.SaveAs(path); err != nil {
+		return fmt.Errorf("save excel file %s: %w", path, err)
+	}
+	return nil
+}
+
+// WriteExcel renders the table as a workbook in the legacy layout understood by LoadExcel. Rule IDs
+// and comments survive only through ruleId and comments metadata columns, as the layout has no other
+// place for them. Scalar condition columns used with EQ or IN get a dropdown offering an EQ condition
+// for every value those cells mention; other conditions may still be typed in. Cell weights and
+// priorities are rejected as they are by WriteCSV.
+func WriteExcel(dt *DecisionTable, w io.Writer) error {
+	f, err := buildExcelWorkbook(dt)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if _, err := f.WriteTo(w); err != nil {
+		return fmt.Errorf("write excel: %w", err)
+	}
+	return nil
+}
+
+// NewExcelTemplate returns a blank workbook in the legacy layout understood by LoadExcel, for authors
+// starting a table from scratch. It holds the version, policy and column header rows and any
+// attribute rows the columns need, followed by an empty rule row marked First Row and an empty
+// Default Row. The columns and policies are validated as NewDecisionTable would validate them.
+func NewExcelTemplate(conditions, outputs []Column, mp MatchPolicy, nmp NoMatchPolicy) (*excelize.File, error) {
+	dt, err := NewDecisionTable("template", conditions, outputs, WithMatchPolicy(mp), WithNoMatchPolicy(nmp))
+	if err != nil {
+		return nil, err
+	}
+	return newExcelWorkbook(dt)
+}
+
+func buildExcelWorkbook(dt *DecisionTable) (*excelize.File, error) {
+	if dt == nil {
+		return nil, fmt.Errorf("decision table is nil")
+	}
+	if len(dt.rows) == 0 {
+		return nil, fmt.Errorf("excel layout needs at least one rule")
+	}
+	return newExcelWorkbook(dt)
+}
+
+func newExcelWorkbook(dt *DecisionTable) (*excelize.File, error) {
+	if len(dt.guardedDefaults) > 0 {
+		return nil, fmt.Errorf("guarded default rows cannot be written to the excel layout")
+	}
+	ordered := append(dt.ConditionColumns(), dt.OutputColumns()...)
+	if len(ordered) < 2 {
+		return nil, fmt.Errorf("excel layout needs at least two columns, got %d", len(ordered))
+	}
+
+	f := excelize.NewFile()
+	if err := f.SetSheetName(f.GetSheetName(0), excelSheetName); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if err := writeExcelSheet(f, dt, ordered); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return f, nil
+}
+
+func writeExcelSheet(f *excelize.File, dt *DecisionTable, ordered []Column) error {
+	var err error
+	set := func(col, row int, value string) {
+		if err == nil {
+			err = f.SetCellStr(excelSheetName, cellName(col, row), value)
+		}
+	}
+
+	layout := DefaultExcelLayout()
+	version := dt.Version()
+	if version == "" {
+		version = excelDefaultVersion
+	}
+	set(1, layout.VersionRow, "Version")
+	set(2, layout.VersionRow, version)
+	set(1, layout.MatchPolicyRow, "Match Policy")
+	set(2, layout.MatchPolicyRow, dt.matchPolicy.String())
+	set(1, layout.NoMatchRow, "No Match Policy")
+	set(2, layout.NoMatchRow, dt.noMatchPolicy.String())
+
+	lastCol := layout.FirstColumn + len(ordered) - 1
+	set(layout.FirstColumn, layout.ColumnMarkerRow, "First Column")
+	set(lastCol, layout.ColumnMarkerRow, "Last Column")
+	hasLabels, hasMin, hasMax, hasTemplates, hasDefaults, hasUnits, hasRequired, hasScales, hasPaths, hasMissing, hasCurrencies := false, false, false, false, false, false, false, false, false, false, false
+	for i, col := range ordered {
+		set(layout.FirstColumn+i, layout.ColumnNameRow, col.Name)
+		set(layout.FirstColumn+i, layout.ColumnTypeRow, string(col.Type))
+		set(layout.FirstColumn+i, layout.DataTypeRow, string(col.DataType))
+		hasLabels = hasLabels || col.Label != ""
+		hasMin = hasMin || col.Min != nil
+		hasMax = hasMax || col.Max != nil
+		hasTemplates = hasTemplates || col.Template
+		hasDefaults = hasDefaults || col.Default != nil
+		hasUnits = hasUnits || col.Unit != ""
+		hasRequired = hasRequired || col.Required
+		hasScales = hasScales || col.Scale != 0
+		hasPaths = hasPaths || col.Path != ""
+		hasMissing = hasMissing || col.MissingPolicy != ""
+		hasCurrencies = hasCurrencies || col.Currency != ""
+	}
+
+	rowIdx := layout.firstDataRow()
+	for _, attr := range []struct {
+		present bool
+		marker  string
+		value   func(Column) string
+	}{
+		{hasLabels, attributeLabel, func(c Column) string { return c.Label }},
+		{hasMin, attributeMin, func(c Column) string { return formatBound(c, c.Min) }},
+		{hasMax, attributeMax, func(c Column) string { return formatBound(c, c.Max) }},
+		{hasTemplates, attributeTemplate, formatTemplateFlag},
+		{hasDefaults, attributeDefault, func(c Column) string { return formatBound(c, c.Default) }},
+		{hasUnits, attributeUnit, func(c Column) string { return c.Unit }},
+		{hasRequired, attributeRequired, formatRequiredFlag},
+		{hasScales, attributeScale, formatScale},
+		{hasPaths, attributePath, func(c Column) string { return c.Path }},
+		{hasMissing, attributeMissing, func(c Column) string { return string(c.MissingPolicy) }},
+		{hasCurrencies, attributeCurrency, func(c Column) string { return c.Currency }},
+		{hasCurrencies, attributeCurrencyColumn, func(c Column) string { return c.CurrencyColumn }},
+	} {
+		if !attr.present {
+			continue
+		}
+		set(1, rowIdx, attr.marker)
+		for i, col := range ordered {
+			set(layout.FirstColumn+i, rowIdx, attr.value(col))
+		}
+		rowIdx++
+	}
+
+	firstRule := rowIdx
+	for i, row := range dt.rows {
+		record, rowErr := formatTabularRow("", row, dt.conditionOrder, dt.outputOrder, dt.requiresExplicitAny())
+		if rowErr != nil {
+			return fmt.Errorf("rule %s: %w", row.RuleID, rowErr)
+		}
+		if i == 0 {
+			set(1, rowIdx, "First Row")
+		}
+		writeExcelRecord(set, layout.FirstColumn, rowIdx, record)
+		rowIdx++
+	}
+	if len(dt.rows) == 0 {
+		// a template leaves one blank rule row to fill in
+		set(1, rowIdx, "First Row")
+		rowIdx++
+	}
+	set(1, rowIdx, "Default Row")
+	if dt.defaultRow != nil {
+		record, rowErr := formatTabularRow("", *dt.defaultRow, dt.conditionOrder, dt.outputOrder, false)
+		if rowErr != nil {
+			return fmt.Errorf("default row: %w", rowErr)
+		}
+		writeExcelRecord(set, layout.FirstColumn, rowIdx, record)
+	}
+	if err != nil {
+		return err
+	}
+	return addConditionDropdowns(f, dt, layout.FirstColumn, firstRule, rowIdx-1)
+}
+
+// writeExcelRecord writes the column cells of a formatTabularRow record, dropping its marker, rule ID
+// and description fields.
+func writeExcelRecord(set func(col, row int, value string), firstCol, rowIdx int, record []string) {
+	for i, text := range record[3:] {
+		if text != "" {
+			set(firstCol+i, rowIdx, text)
+		}
+	}
+}
+
+// addConditionDropdowns attaches a list validation to the rule rows of each scalar condition column
+// whose EQ and IN cells mention at least one value. The validation shows no error alert, so typed
+// conditions such as ranges are still accepted. Values containing commas cannot appear in an Excel
+// list and are left out, as are columns whose list exceeds Excel's length limit.
+func addConditionDropdowns(f *excelize.File, dt *DecisionTable, firstCol, firstRow, lastRow int) error {
+	for i, col := range dt.conditionOrder {
+		if isListDataType(col.DataType) {
+			continue
+		}
+		var options []string
+		seen := make(map[string]struct{})
+		for _, row := range dt.rows {
+			for _, cell := range row.EvalCells {
+				if cell.Column != col.Name || cell.Transform != "" {
+					continue
+				}
+				var values []any
+				switch cell.Operator {
+				case OperatorEqual:
+					values = []any{cell.Value}
+				case OperatorIn:
+					values, _ = cell.Value.([]any)
+				}
+				for _, v := range values {
+					text := formatValue(col.DataType, v)
+					if text == "" || strings.Contains(text, ",") {
+						continue
+					}
+					if _, dup := seen[text]; dup {
+						continue
+					}
+					seen[text] = struct{}{}
+					options = append(options, string(OperatorEqual)+" "+text)
+				}
+			}
+		}
+		if len(options) == 0 {
+			continue
+		}
+		dv := excelize.NewDataValidation(true)
+		dv.SetSqref(cellName(firstCol+i, firstRow) + ":" + cellName(firstCol+i, lastRow))
+		if err := dv.SetDropList(options); err != nil {
+			if errors.Is(err, excelize.ErrDataValidationFormulaLength) {
+				continue
+			}
+			return fmt.Errorf("column %s: %w", col.Name, err)
+		}
+		if err := f.AddDataValidation(excelSheetName, dv); err != nil {
+			return fmt.Errorf("column %s: %w", col.Name, err)
+		}
+	}
+	return nil
+}
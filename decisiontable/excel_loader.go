@@ -28,48 +28,141 @@ const (
 	excelMatchPolicyRow  = 2
 	excelNoMatchRow      = 3
 	excelColumnMarkerRow = 5
-	excelFirstDataRow    = excelColumnMarkerRow + 4
 	excelFirstColumn     = 2 // column B
 	excelMaxColumns      = 1000
 	excelMaxRows         = 10000
 )
 
+// ExcelLayout positions the header rows of a workbook sheet. Rows and columns are 1-based. The
+// optional attribute rows and the First Row marker follow the last header row.
+type ExcelLayout struct {
+	VersionRow      int
+	MatchPolicyRow  int
+	NoMatchRow      int
+	ColumnMarkerRow int // row holding the First Column and Last Column markers
+	ColumnNameRow   int
+	ColumnTypeRow   int
+	DataTypeRow     int
+	FirstColumn     int // column of the First Column marker; column A is reserved for row markers
+}
+
+// DefaultExcelLayout returns the layout used by LoadExcelFile and LoadExcel.
+func DefaultExcelLayout() ExcelLayout {
+	return ExcelLayout{
+		VersionRow:      excelVersionRow,
+		MatchPolicyRow:  excelMatchPolicyRow,
+		NoMatchRow:      excelNoMatchRow,
+		ColumnMarkerRow: excelColumnMarkerRow,
+		ColumnNameRow:   excelColumnMarkerRow + 1,
+		ColumnTypeRow:   excelColumnMarkerRow + 2,
+		DataTypeRow:     excelColumnMarkerRow + 3,
+		FirstColumn:     excelFirstColumn,
+	}
+}
+
+func (l ExcelLayout) validate() error {
+	rows := []struct {
+		name string
+		row  int
+	}{
+		{"version", l.VersionRow},
+		{"match policy", l.MatchPolicyRow},
+		{"no match policy", l.NoMatchRow},
+		{"column marker", l.ColumnMarkerRow},
+		{"column name", l.ColumnNameRow},
+		{"column type", l.ColumnTypeRow},
+		{"data type", l.DataTypeRow},
+	}
+	used := make(map[int]string, len(rows))
+	for _, r := range rows {
+		if r.row < 1 {
+			return fmt.Errorf("excel layout: %s row must be positive, got %d", r.name, r.row)
+		}
+		if other, exists := used[r.row]; exists {
+			return fmt.Errorf("excel layout: %s row overlaps %s row at row %d", r.name, other, r.row)
+		}
+		used[r.row] = r.name
+	}
+	if l.FirstColumn < 2 {
+		return fmt.Errorf("excel layout: first column must be B or later, got %d", l.FirstColumn)
+	}
+	return nil
+}
+
+// firstDataRow is the row after the last header row, where the attribute rows or the First Row
+// marker begin.
+func (l ExcelLayout) firstDataRow() int {
+	return max(l.VersionRow, l.MatchPolicyRow, l.NoMatchRow, l.ColumnMarkerRow, l.ColumnNameRow, l.ColumnTypeRow, l.DataTypeRow) + 1
+}
+
 type excelColumnLayout struct {
 	Conditions []Column
 	Outputs    []Column
 	Ordered    []Column
+	// FirstDataRow is shifted down by one for each optional attribute row (Label, Min, Max) that
+	// follows the data type row.
+	FirstDataRow int
 }
 
 // LoadExcelFile loads a decision table from an Excel file that follows the legacy layout.
-func LoadExcelFile(path string) (*DecisionTable, error) {
+// Options are applied before the policies declared in the workbook.
+func LoadExcelFile(path string, opts ...Option) (*DecisionTable, error) {
 	f, err := excelize.OpenFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("open excel file %s: %w", path, err)
 	}
 	defer f.Close()
-	return loadExcelWorkbook(path, f)
+	return loadExcelWorkbook(path, f, DefaultExcelLayout(), opts)
+}
+
+// LoadExcelFileWithLayout loads a decision table from an Excel file whose header rows sit at the
+// positions given by layout.
+func LoadExcelFileWithLayout(path string, layout ExcelLayout, opts ...Option) (*DecisionTable, error) {
+	if err := layout.validate(); err != nil {
+		return nil, err
+	}
+	f, err := excelize.OpenFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("open excel file %s: %w", path, err)
+	}
+	defer f.Close()
+	return loadExcelWorkbook(path, f, layout, opts)
 }
 
 // LoadExcel loads a decision table from an io.Reader (e.g., embedded resource).
-func LoadExcel(name string, r io.Reader) (*DecisionTable, error) {
+func LoadExcel(name string, r io.Reader, opts ...Option) (*DecisionTable, error) {
+	f, err := excelize.OpenReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("open excel stream %s: %w", name, err)
+	}
+	defer f.Close()
+	return loadExcelWorkbook(name, f, DefaultExcelLayout(), opts)
+}
+
+// LoadExcelWithLayout is LoadExcel for workbooks that follow a custom ExcelLayout.
+func LoadExcelWithLayout(name string, r io.Reader, layout ExcelLayout, opts ...Option) (*DecisionTable, error) {
+	if err := layout.validate(); err != nil {
+		return nil, err
+	}
 	f, err := excelize.OpenReader(r)
 	if err != nil {
 		return nil, fmt.Errorf("open excel stream %s: %w", name, err)
 	}
 	defer f.Close()
-	return loadExcelWorkbook(name, f)
+	return loadExcelWorkbook(name, f, layout, opts)
 }
 
-func loadExcelWorkbook(name string, f *excelize.File) (*DecisionTable, error) {
+func loadExcelWorkbook(name string, f *excelize.File, sheet ExcelLayout, opts []Option) (*DecisionTable, error) {
 	if _, err := f.GetSheetIndex(excelSheetName); err != nil {
 		return nil, fmt.Errorf("sheet %q not found: %w", excelSheetName, err)
 	}
 
-	if _, err := expectLabelAndValue(f, excelVersionRow, "Version"); err != nil {
+	version, err := expectLabelAndValue(f, sheet.VersionRow, "Version")
+	if err != nil {
 		return nil, err
 	}
 
-	matchPolicyRaw, err := expectLabelAndValue(f, excelMatchPolicyRow, "Match Policy")
+	matchPolicyRaw, err := expectLabelAndValue(f, sheet.MatchPolicyRow, "Match Policy")
 	if err != nil {
 		return nil, err
 	}
@@ -78,7 +171,7 @@ func loadExcelWorkbook(name string, f *excelize.File) (*DecisionTable, error) {
 		return nil, err
 	}
 
-	noMatchRaw, err := expectLabelAndValue(f, excelNoMatchRow, "No Match Policy")
+	noMatchRaw, err := expectLabelAndValue(f, sheet.NoMatchRow, "No Match Policy")
 	if err != nil {
 		return nil, err
 	}
@@ -86,38 +179,71 @@ func loadExcelWorkbook(name string, f *excelize.File) (*DecisionTable, error) {
 	if err != nil {
 		return nil, err
 	}
+	defaultRuleID, err := optionalDefaultRuleID(f, sheet.NoMatchRow)
+	if err != nil {
+		return nil, err
+	}
+	if defaultRuleID != "" {
+		if err := checkDefaultRuleIDPolicy("Default Rule ID", noMatchPolicy); err != nil {
+			return nil, err
+		}
+	}
 
-	layout, firstCol, lastCol, err := readExcelColumns(f)
+	layout, firstCol, lastCol, err := readExcelColumns(f, sheet)
 	if err != nil {
 		return nil, err
 	}
 
-	dt, err := NewDecisionTable(name, layout.Conditions, layout.Outputs, WithMatchPolicy(matchPolicy), WithNoMatchPolicy(noMatchPolicy))
+	opts = withDeclaredPolicies(opts, matchPolicy, noMatchPolicy)
+	if version = strings.TrimSpace(version); version != "" {
+		opts = append(opts, WithVersion(version))
+	}
+	dt, err := NewDecisionTable(name, layout.Conditions, layout.Outputs, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	rows, defaultRow, err := readExcelRows(f, layout, firstCol, lastCol)
+	rows, defaultRow, err := readExcelRows(f, layout, firstCol, lastCol, defaultRuleID, dt.generateRuleID, dt.requiresExplicitAny())
 	if err != nil {
 		return nil, err
 	}
-	for idx, row := range rows {
+	for _, row := range rows {
 		if err := dt.AddRow(row); err != nil {
-			return nil, fmt.Errorf("row %d: %w", idx+1, err)
+			return nil, excelRowError(layout, row, err)
 		}
 	}
-	if noMatchPolicy == NoMatchPolicyReturnDefault {
+	// a designated default rule is also kept under THROW_ERROR; RETURN_NIL was rejected above
+	if noMatchPolicy == NoMatchPolicyReturnDefault || defaultRuleID != "" {
 		if defaultRow == nil {
 			return nil, fmt.Errorf("default row is required for RETURN_DEFAULT policy")
 		}
 		if err := dt.SetDefaultRow(*defaultRow); err != nil {
-			return nil, err
+			return nil, excelRowError(layout, *defaultRow, err)
 		}
 	}
 
 	return dt, nil
 }
 
+// excelRowError wraps an error about a loaded row with the sheet row it was read from.
+func excelRowError(layout excelColumnLayout, row Row, err error) error {
+	return &LoadError{Sheet: excelSheetName, Row: layout.FirstDataRow + row.Number - 1, RuleID: row.RuleID, Err: err}
+}
+
+// excelCellRuleID reads the ruleId metadata cell of a sheet row, for rows that failed to convert.
+func excelCellRuleID(f *excelize.File, layout excelColumnLayout, firstCol, rowIdx int) string {
+	for i, column := range layout.Ordered {
+		if column.Type == ColumnTypeMetadata && strings.EqualFold(column.Name, "ruleId") {
+			value, err := f.GetCellValue(excelSheetName, cellName(firstCol+i, rowIdx))
+			if err != nil {
+				return ""
+			}
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
+
 func expectLabelAndValue(f *excelize.File, row int, label string) (string, error) {
 	labelCell, err := f.GetCellValue(excelSheetName, cellName(1, row))
 	if err != nil {
@@ -136,18 +262,18 @@ func expectLabelAndValue(f *excelize.File, row int, label string) (string, error
 	return value, nil
 }
 
-func readExcelColumns(f *excelize.File) (excelColumnLayout, int, int, error) {
-	marker, err := f.GetCellValue(excelSheetName, cellName(excelFirstColumn, excelColumnMarkerRow))
+func readExcelColumns(f *excelize.File, sheet ExcelLayout) (excelColumnLayout, int, int, error) {
+	marker, err := f.GetCellValue(excelSheetName, cellName(sheet.FirstColumn, sheet.ColumnMarkerRow))
 	if err != nil {
 		return excelColumnLayout{}, 0, 0, err
 	}
 	if !strings.EqualFold(strings.TrimSpace(marker), "First Column") {
-		return excelColumnLayout{}, 0, 0, fmt.Errorf("expected First Column marker at row %d column B", excelColumnMarkerRow)
+		return excelColumnLayout{}, 0, 0, fmt.Errorf("expected First Column marker at %s", cellName(sheet.FirstColumn, sheet.ColumnMarkerRow))
 	}
 
 	lastCol := 0
-	for col := excelFirstColumn + 1; col < excelFirstColumn+excelMaxColumns; col++ {
-		value, err := f.GetCellValue(excelSheetName, cellName(col, excelColumnMarkerRow))
+	for col := sheet.FirstColumn + 1; col < sheet.FirstColumn+excelMaxColumns; col++ {
+		value, err := f.GetCellValue(excelSheetName, cellName(col, sheet.ColumnMarkerRow))
 		if err != nil {
 			return excelColumnLayout{}, 0, 0, err
 		}
@@ -155,7 +281,7 @@ func readExcelColumns(f *excelize.File) (excelColumnLayout, int, int, error) {
 			continue
 		}
 		if !strings.EqualFold(strings.TrimSpace(value), "Last Column") {
-			return excelColumnLayout{}, 0, 0, fmt.Errorf("expected Last Column marker near row %d", excelColumnMarkerRow)
+			return excelColumnLayout{}, 0, 0, fmt.Errorf("expected Last Column marker near row %d", sheet.ColumnMarkerRow)
 		}
 		lastCol = col
 		break
@@ -165,26 +291,45 @@ func readExcelColumns(f *excelize.File) (excelColumnLayout, int, int, error) {
 	}
 
 	layout := excelColumnLayout{
-		Conditions: []Column{},
-		Outputs:    []Column{},
-		Ordered:    []Column{},
+		Conditions:   []Column{},
+		Outputs:      []Column{},
+		Ordered:      []Column{},
+		FirstDataRow: sheet.firstDataRow(),
+	}
+	attributeRows := make(map[int]string)
+	for {
+		marker, err := f.GetCellValue(excelSheetName, cellName(1, layout.FirstDataRow))
+		if err != nil {
+			return excelColumnLayout{}, 0, 0, err
+		}
+		attr, ok := columnAttribute(marker)
+		if !ok {
+			break
+		}
+		for _, seen := range attributeRows {
+			if seen == attr {
+				return excelColumnLayout{}, 0, 0, fmt.Errorf("duplicate %s row at row %d", attr, layout.FirstDataRow)
+			}
+		}
+		attributeRows[layout.FirstDataRow] = attr
+		layout.FirstDataRow++
 	}
 	seen := make(map[string]struct{})
-	for col := excelFirstColumn; col <= lastCol; col++ {
-		name, err := f.GetCellValue(excelSheetName, cellName(col, excelColumnMarkerRow+1))
+	for col := sheet.FirstColumn; col <= lastCol; col++ {
+		name, err := f.GetCellValue(excelSheetName, cellName(col, sheet.ColumnNameRow))
 		if err != nil {
 			return excelColumnLayout{}, 0, 0, err
 		}
 		name = strings.TrimSpace(name)
 		if name == "" {
-			return excelColumnLayout{}, 0, 0, fmt.Errorf("column name missing near row %d", excelColumnMarkerRow+1)
+			return excelColumnLayout{}, 0, 0, fmt.Errorf("column name missing near row %d", sheet.ColumnNameRow)
 		}
 		if _, exists := seen[name]; exists {
 			return excelColumnLayout{}, 0, 0, fmt.Errorf("duplicate column %s", name)
 		}
 		seen[name] = struct{}{}
 
-		typeRaw, err := f.GetCellValue(excelSheetName, cellName(col, excelColumnMarkerRow+2))
+		typeRaw, err := f.GetCellValue(excelSheetName, cellName(col, sheet.ColumnTypeRow))
 		if err != nil {
 			return excelColumnLayout{}, 0, 0, err
 		}
@@ -193,7 +338,7 @@ func readExcelColumns(f *excelize.File) (excelColumnLayout, int, int, error) {
 			return excelColumnLayout{}, 0, 0, fmt.Errorf("column %s: %w", name, err)
 		}
 
-		dataTypeRaw, err := f.GetCellValue(excelSheetName, cellName(col, excelColumnMarkerRow+3))
+		dataTypeRaw, err := f.GetCellValue(excelSheetName, cellName(col, sheet.DataTypeRow))
 		if err != nil {
 			return excelColumnLayout{}, 0, 0, err
 		}
@@ -202,6 +347,15 @@ func readExcelColumns(f *excelize.File) (excelColumnLayout, int, int, error) {
 			return excelColumnLayout{}, 0, 0, fmt.Errorf("column %s: %w", name, err)
 		}
 		column := Column{Name: name, Type: colType, DataType: dataType}
+		for row, attr := range attributeRows {
+			value, err := f.GetCellValue(excelSheetName, cellName(col, row))
+			if err != nil {
+				return excelColumnLayout{}, 0, 0, err
+			}
+			if err := setColumnAttribute(&column, attr, value); err != nil {
+				return excelColumnLayout{}, 0, 0, fmt.Errorf("column %s: %w", name, err)
+			}
+		}
 		layout.Ordered = append(layout.Ordered, column)
 		switch colType {
 		case ColumnTypeCondition:
@@ -211,20 +365,42 @@ func readExcelColumns(f *excelize.File) (excelColumnLayout, int, int, error) {
 		}
 	}
 
-	if len(layout.Conditions) == 0 || len(layout.Outputs) == 0 {
-		return excelColumnLayout{}, 0, 0, fmt.Errorf("excel table must define condition and output columns")
+	if len(layout.Outputs) == 0 {
+		return excelColumnLayout{}, 0, 0, fmt.Errorf("excel table must define output columns")
 	}
 
-	return layout, excelFirstColumn, lastCol, nil
+	return layout, sheet.FirstColumn, lastCol, nil
 }
 
-func readExcelRows(f *excelize.File, layout excelColumnLayout, firstCol, lastCol int) ([]Row, *Row, error) {
-	marker, err := f.GetCellValue(excelSheetName, cellName(1, excelFirstDataRow))
+// optionalDefaultRuleID reads the Default Rule ID written in columns C and D of the No Match Policy
+// row, which designates a rule as the default row. It returns "" when the label is absent.
+func optionalDefaultRuleID(f *excelize.File, row int) (string, error) {
+	label, err := f.GetCellValue(excelSheetName, cellName(3, row))
+	if err != nil {
+		return "", fmt.Errorf("read cell: %w", err)
+	}
+	if !strings.EqualFold(strings.TrimSpace(label), "Default Rule ID") {
+		return "", nil
+	}
+	value, err := f.GetCellValue(excelSheetName, cellName(4, row))
+	if err != nil {
+		return "", fmt.Errorf("read cell: %w", err)
+	}
+	if strings.TrimSpace(value) == "" {
+		return "", fmt.Errorf("Default Rule ID value missing in row %d", row)
+	}
+	return strings.TrimSpace(value), nil
+}
+
+// readExcelRows reads the rule rows up to the Default Row marker. When defaultRuleID is set the rule
+// with that ID becomes the default row and the marker row must be left blank.
+func readExcelRows(f *excelize.File, layout excelColumnLayout, firstCol, lastCol int, defaultRuleID string, newID func(int) string, requireAny bool) ([]Row, *Row, error) {
+	marker, err := f.GetCellValue(excelSheetName, cellName(1, layout.FirstDataRow))
 	if err != nil {
 		return nil, nil, err
 	}
 	if !strings.EqualFold(strings.TrimSpace(marker), "First Row") {
-		return nil, nil, fmt.Errorf("expected First Row marker in column A row %d", excelFirstDataRow)
+		return nil, nil, fmt.Errorf("expected First Row marker in column A row %d", layout.FirstDataRow)
 	}
 
 	var rows []Row
@@ -232,19 +408,39 @@ func readExcelRows(f *excelize.File, layout excelColumnLayout, firstCol, lastCol
 	ruleIDs := make(map[string]struct{})
 	rowNumber := 0
 
-	for rowIdx := excelFirstDataRow; rowIdx < excelFirstDataRow+excelMaxRows; rowIdx++ {
+	for rowIdx := layout.FirstDataRow; rowIdx < layout.FirstDataRow+excelMaxRows; rowIdx++ {
 		rowNumber++
-		row, err := convertExcelRow(f, layout, firstCol, lastCol, rowIdx, rowNumber, ruleIDs)
+		markerCell, err := f.GetCellValue(excelSheetName, cellName(1, rowIdx))
 		if err != nil {
 			return nil, nil, err
 		}
-		markerCell, err := f.GetCellValue(excelSheetName, cellName(1, rowIdx))
+		isDefault := strings.EqualFold(strings.TrimSpace(markerCell), "Default Row")
+		if isDefault && defaultRuleID != "" {
+			blank, err := excelRowBlank(f, firstCol, lastCol, rowIdx)
+			if err != nil {
+				return nil, nil, err
+			}
+			if !blank {
+				return nil, nil, fmt.Errorf("default row in row %d must be blank when Default Rule ID is set", rowIdx)
+			}
+			return designateExcelDefault(layout, rows, defaultRuleID)
+		}
+		if !isDefault && rowIdx == layout.FirstDataRow {
+			skip, err := excelTemplateRuleRow(f, firstCol, lastCol, rowIdx)
+			if err != nil {
+				return nil, nil, err
+			}
+			if skip {
+				continue
+			}
+		}
+		row, err := convertExcelRow(f, layout, firstCol, lastCol, rowIdx, rowNumber, ruleIDs, newID, requireAny && !isDefault)
 		if err != nil {
-			return nil, nil, err
+			return nil, nil, &LoadError{Sheet: excelSheetName, Row: rowIdx, RuleID: excelCellRuleID(f, layout, firstCol, rowIdx), Err: err}
 		}
-		if strings.EqualFold(strings.TrimSpace(markerCell), "Default Row") {
+		if isDefault {
 			if len(row.EvalCells) > 0 {
-				return nil, nil, fmt.Errorf("default row cannot contain evaluation cells")
+				return nil, nil, excelRowError(layout, row, fmt.Errorf("default row cannot contain evaluation cells"))
 			}
 			defaultRow = &row
 			break
@@ -259,7 +455,49 @@ func readExcelRows(f *excelize.File, layout excelColumnLayout, firstCol, lastCol
 	return rows, defaultRow, nil
 }
 
-func convertExcelRow(f *excelize.File, layout excelColumnLayout, firstCol, lastCol, rowIdx, rowNumber int, ruleIDs map[string]struct{}) (Row, error) {
+// designateExcelDefault moves the rule named id out of rows and returns it as the default row.
+func designateExcelDefault(layout excelColumnLayout, rows []Row, id string) ([]Row, *Row, error) {
+	for i, row := range rows {
+		if row.RuleID != id {
+			continue
+		}
+		if err := checkDesignatedDefault(row); err != nil {
+			return nil, nil, excelRowError(layout, row, err)
+		}
+		rest := append(rows[:i:i], rows[i+1:]...)
+		return rest, &row, nil
+	}
+	return nil, nil, fmt.Errorf("Default Rule ID %q does not name a rule", id)
+}
+
+// excelTemplateRuleRow reports whether rowIdx is the blank rule row NewExcelTemplate writes: a
+// single empty row between the First Row and Default Row markers. Blank rows anywhere else are read
+// as rules, so a gap left in a real table is reported instead of being dropped.
+func excelTemplateRuleRow(f *excelize.File, firstCol, lastCol, rowIdx int) (bool, error) {
+	next, err := f.GetCellValue(excelSheetName, cellName(1, rowIdx+1))
+	if err != nil {
+		return false, err
+	}
+	if !strings.EqualFold(strings.TrimSpace(next), "Default Row") {
+		return false, nil
+	}
+	return excelRowBlank(f, firstCol, lastCol, rowIdx)
+}
+
+func excelRowBlank(f *excelize.File, firstCol, lastCol, rowIdx int) (bool, error) {
+	for col := firstCol; col <= lastCol; col++ {
+		value, err := f.GetCellValue(excelSheetName, cellName(col, rowIdx))
+		if err != nil {
+			return false, err
+		}
+		if strings.TrimSpace(value) != "" {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func convertExcelRow(f *excelize.File, layout excelColumnLayout, firstCol, lastCol, rowIdx, rowNumber int, ruleIDs map[string]struct{}, newID func(int) string, requireAny bool) (Row, error) {
 	row := Row{Number: rowNumber}
 	for col := firstCol; col <= lastCol; col++ {
 		columnIndex := col - firstCol
@@ -275,73 +513,120 @@ func convertExcelRow(f *excelize.File, layout excelColumnLayout, firstCol, lastC
 
 		switch column.Type {
 		case ColumnTypeCondition:
-			if trimmed == "" {
+			present, err := checkConditionCell(trimmed, column, requireAny)
+			if err != nil {
+				return Row{}, err
+			}
+			if !present {
 				continue
 			}
 			op, operand, err := parseConditionString(trimmed, column.DataType)
 			if err != nil {
 				return Row{}, fmt.Errorf("column %s: %w", column.Name, err)
 			}
+			if err := checkOperatorDataType(op, column.DataType); err != nil {
+				return Row{}, fmt.Errorf("column %s: %w", column.Name, err)
+			}
 			row.EvalCells = append(row.EvalCells, EvalCell{
 				Column:   column.Name,
 				Operator: op,
 				Value:    operand,
 			})
 		case ColumnTypeConclusion, ColumnTypeMetadata:
+			// a blank output cell means null, matching a JSON null in "then"
+			var value any
+			if trimmed != "" {
+				value = rawValue
+			}
 			row.ReturnCells = append(row.ReturnCells, ReturnCell{
 				Column: column.Name,
-				Value:  rawValue,
+				Value:  value,
 			})
 			if column.Type == ColumnTypeMetadata && trimmed != "" {
 				if strings.EqualFold(column.Name, "ruleId") && row.RuleID == "" {
 					row.RuleID = trimmed
 				}
-				if strings.EqualFold(column.Name, "description") && row.Comments == "" {
-					row.Comments = trimmed
-				}
 			}
 		}
 	}
 
-	if row.RuleID == "" {
-		row.RuleID = fmt.Sprintf("%d", rowNumber)
+	fillCommentsFromMetadata(&row, layout.Outputs)
+	fillMetadataMap(&row, layout.Outputs)
+	if err := fillRowFromMetadata(&row, layout.Outputs); err != nil {
+		return Row{}, err
 	}
-	if _, exists := ruleIDs[row.RuleID]; exists {
-		return Row{}, fmt.Errorf("duplicate rule id %q", row.RuleID)
+	if err := ensureUniqueRuleID(&row, rowNumber, ruleIDs, newID); err != nil {
+		return Row{}, err
 	}
-	ruleIDs[row.RuleID] = struct{}{}
 
 	return row, nil
 }
 
+// splitOperatorPhrase separates the operator from its operand. Operators may be spelled as several
+// words ("greater than or equal 18", "NOT IN US,CA"), so the longest leading run of words that names
+// an operator wins.
+func splitOperatorPhrase(value string) (OperatorType, string, error) {
+	var ends []int
+	for i := 0; i < len(value); i++ {
+		if value[i] == ' ' && i > 0 && value[i-1] != ' ' {
+			ends = append(ends, i)
+		}
+	}
+	for i := len(ends) - 1; i >= 0; i-- {
+		if op, err := parseOperatorToken(value[:ends[i]]); err == nil {
+			return op, strings.TrimSpace(value[ends[i]:]), nil
+		}
+	}
+	if len(ends) == 0 {
+		return "", "", fmt.Errorf("invalid condition %q", value)
+	}
+	_, err := parseOperatorToken(value[:ends[0]])
+	return "", "", err
+}
+
 func cellName(col, row int) string {
 	name, _ := excelize.CoordinatesToCellName(col, row)
 	return name
 }
 
 func parseConditionString(value string, dt DataType) (OperatorType, any, error) {
+	// null checks and DEFAULT are the only operators that may be written without an operand
+	if op, err := parseOperatorToken(value); err == nil && (op == OperatorIsNull || op == OperatorIsNotNull || op == OperatorDefault) {
+		return op, nil, nil
+	}
+	// a bare interval such as "[18..65)" is shorthand for BETWEEN
+	if isIntervalNotation(value) {
+		return OperatorBetween, value, nil
+	}
 	delim := strings.Index(value, " ")
 	if delim <= 0 {
 		return "", nil, fmt.Errorf("invalid condition %q", value)
 	}
-	opToken := value[:delim]
-	operand := strings.TrimSpace(value[delim+1:])
-	if operand == "" {
-		return "", nil, fmt.Errorf("missing operand for %q", opToken)
-	}
-
-	op, err := parseOperatorToken(opToken)
+	op, operand, err := splitOperatorPhrase(value)
 	if err != nil {
 		return "", nil, err
 	}
+	if operand == "" {
+		return "", nil, fmt.Errorf("missing operand for %q", value)
+	}
 
+	if isBetweenOperator(op) {
+		if isIntervalNotation(operand) {
+			return op, operand, nil
+		}
+		return op, splitList(operand), nil
+	}
 	if requiresCollectionValue(op) {
-		values, err := splitAndDedupeList(operand)
+		// multiset operands keep their duplicates, which carry the counts
+		values, err := splitOperandList(operand, op != OperatorMultisetContained)
 		if err != nil {
 			return "", nil, err
 		}
 		return op, values, nil
 	}
+	if isListDataType(dt) && (op == OperatorEqual || op == OperatorNotEqual) {
+		return op, splitList(operand), nil
+	}
 
 	return op, operand, nil
 }
@@ -0,0 +1,117 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Explain renders the match as a sentence for support tooling, for example
+// "Rule rule-001 matched: creditScore >= 780, customerCategory in [PREMIUM, VIP]."
+func (m MatchedRow) Explain() string {
+	var b strings.Builder
+	switch {
+	case len(m.MatchedRules) > 0:
+		fmt.Fprintf(&b, "Rules %s matched", strings.Join(m.MatchedRules, ", "))
+	case m.IsDefault && m.RuleID == "":
+		b.WriteString("No rule matched; default result returned")
+	case m.IsDefault:
+		fmt.Fprintf(&b, "No rule matched; default rule %s applied", m.RuleID)
+	case m.RuleID != "":
+		fmt.Fprintf(&b, "Rule %s matched", m.RuleID)
+	default:
+		fmt.Fprintf(&b, "Row %d matched", m.RowNumber)
+	}
+	if len(m.Conditions) > 0 {
+		parts := make([]string, len(m.Conditions))
+		for i, cell := range m.Conditions {
+			parts[i] = explainCondition(cell)
+		}
+		b.WriteString(": ")
+		b.WriteString(strings.Join(parts, ", "))
+	}
+	b.WriteString(".")
+	return b.String()
+}
+
+var explainSymbols = map[OperatorType]string{
+	OperatorGreaterOrEqual: ">=",
+	OperatorGreater:        ">",
+	OperatorEqual:          "=",
+	OperatorLess:           "<",
+	OperatorLessOrEqual:    "<=",
+	OperatorNotEqual:       "!=",
+}
+
+func explainCondition(cell EvalCell) string {
+	if cell.ValueRef != "" {
+		return fmt.Sprintf("%s %s %s", cell.Column, explainSymbols[cell.Operator], cell.ValueRef)
+	}
+	switch cell.Operator {
+	case OperatorIsNull:
+		return cell.Column + " is null"
+	case OperatorIsNotNull:
+		return cell.Column + " is not null"
+	case OperatorBetween, OperatorNotBetween:
+		if bounds, ok := cell.Value.([]any); ok && len(bounds) == 2 {
+			elemType := elementDataType(cell.dataType)
+			lo, hi := formatValue(elemType, bounds[0]), formatValue(elemType, bounds[1])
+			negate := ""
+			if cell.Operator == OperatorNotBetween {
+				negate = "not "
+			}
+			if cell.Interval != "" {
+				return fmt.Sprintf("%s %sin %c%s, %s%c", cell.Column, negate, cell.Interval[0], lo, hi, cell.Interval[1])
+			}
+			return fmt.Sprintf("%s %sbetween %s and %s", cell.Column, negate, lo, hi)
+		}
+	case OperatorSetEquals:
+		return fmt.Sprintf("%s equals set %s", cell.Column, explainValue(cell.dataType, cell.Value))
+	case OperatorMultisetContained:
+		return fmt.Sprintf("%s within multiset %s", cell.Column, explainValue(cell.dataType, cell.Value))
+	case OperatorDefault:
+		return fmt.Sprintf("%s = default %s", cell.Column, explainValue(cell.dataType, cell.defaultValue))
+	case OperatorMatchesRegex:
+		if re, ok := cell.Value.(*regexp.Regexp); ok {
+			return fmt.Sprintf("%s matches /%s/", cell.Column, re.String())
+		}
+	}
+	if agg, cmp, ok := splitAggregateOperator(cell.Operator); ok {
+		return fmt.Sprintf("%s(%s) %s %s", strings.ToLower(agg), cell.Column, explainSymbols[cmp], explainValue(DataTypeInteger, cell.Value))
+	}
+	op, ok := explainSymbols[cell.Operator]
+	if !ok {
+		op = strings.ToLower(strings.ReplaceAll(string(cell.Operator), "_", " "))
+	}
+	return fmt.Sprintf("%s %s %s", cell.Column, op, explainValue(cell.dataType, cell.Value))
+}
+
+func explainValue(dt DataType, v any) string {
+	values, ok := v.([]any)
+	if !ok {
+		if v == nil {
+			return "null"
+		}
+		return formatValue(dt, v)
+	}
+	elemType := elementDataType(dt)
+	parts := make([]string, len(values))
+	for i, item := range values {
+		parts[i] = explainValue(elemType, item)
+	}
+	return "[" + strings.Join(parts, ", ") + "]"
+}
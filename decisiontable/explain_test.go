@@ -0,0 +1,83 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import "testing"
+
+func TestMatchedRowExplain(t *testing.T) {
+	dt, err := NewDecisionTable("loans",
+		[]Column{
+			{Name: "creditScore", Type: ColumnTypeCondition, DataType: DataTypeInteger},
+			{Name: "dtiRatio", Type: ColumnTypeCondition, DataType: DataTypeDecimal},
+			{Name: "customerCategory", Type: ColumnTypeCondition, DataType: DataTypeString},
+		},
+		[]Column{{Name: "approved", Type: ColumnTypeConclusion, DataType: DataTypeBoolean}},
+		WithMatchPolicy(MatchPolicyFirst), WithNoMatchPolicy(NoMatchPolicyReturnDefault))
+	if err != nil {
+		t.Fatalf("new table: %v", err)
+	}
+	if err := dt.AddRow(Row{
+		RuleID: "rule-001-premium",
+		EvalCells: []EvalCell{
+			{Column: "creditScore", Operator: OperatorGreaterOrEqual, Value: 780},
+			{Column: "dtiRatio", Operator: OperatorLess, Value: "0.3"},
+			{Column: "customerCategory", Operator: OperatorIn, Value: []any{"PREMIUM", "VIP"}},
+		},
+		ReturnCells: []ReturnCell{{Column: "approved", Value: true}},
+	}); err != nil {
+		t.Fatalf("add row: %v", err)
+	}
+	if err := dt.SetDefaultRow(Row{RuleID: "fallback", ReturnCells: []ReturnCell{{Column: "approved", Value: false}}}); err != nil {
+		t.Fatalf("set default: %v", err)
+	}
+
+	rows, err := dt.Evaluate(map[string]any{"creditScore": 800, "dtiRatio": 0.2, "customerCategory": "VIP"}, nil)
+	if err != nil {
+		t.Fatalf("evaluate: %v", err)
+	}
+	want := "Rule rule-001-premium matched: creditScore >= 780, dtiRatio < 0.3, customerCategory in [PREMIUM, VIP]."
+	if got := rows[0].Explain(); got != want {
+		t.Fatalf("unexpected explanation\n got: %s\nwant: %s", got, want)
+	}
+	rows[0].Conditions[0].Value = int64(0)
+	rows[0].Conditions[2].Value.([]any)[0] = "BASIC"
+	if got := dt.Rows()[0].EvalCells; got[0].Value != int64(780) || got[2].Value.([]any)[0] != "PREMIUM" {
+		t.Fatalf("expected editing a result's conditions to leave the rule unchanged, got %v", got)
+	}
+
+	rows, err = dt.Evaluate(map[string]any{"creditScore": 600, "dtiRatio": 0.2, "customerCategory": "VIP"}, nil)
+	if err != nil {
+		t.Fatalf("evaluate: %v", err)
+	}
+	if got := rows[0].Explain(); got != "No rule matched; default rule fallback applied." {
+		t.Fatalf("unexpected default explanation %q", got)
+	}
+}
+
+func TestExplainConditionForms(t *testing.T) {
+	cases := []struct {
+		cell EvalCell
+		want string
+	}{
+		{EvalCell{Column: "age", Operator: OperatorBetween, Value: []any{int64(18), int64(65)}, dataType: DataTypeInteger}, "age between 18 and 65"},
+		{EvalCell{Column: "email", Operator: OperatorIsNull, Value: true, dataType: DataTypeString}, "email is null"},
+		{EvalCell{Column: "tags", Operator: OperatorAnyContained, Value: []any{"a", "b"}, dataType: DataTypeListString}, "tags any contained in [a, b]"},
+	}
+	for _, tc := range cases {
+		if got := explainCondition(tc.cell); got != tc.want {
+			t.Fatalf("got %q, want %q", got, tc.want)
+		}
+	}
+}
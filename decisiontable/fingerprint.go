@@ -0,0 +1,220 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"math/big"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Fingerprint returns a hex SHA-256 digest of the table's semantic content: policies, columns,
+// rules with their sanitized values, the default row and every option that changes how rows load or
+// evaluate. Two tables that
+// evaluate alike digest alike, so the fingerprint can key caches and tell whether a reloaded file
+// changed. Decimals digest by exact value, times by instant and regular expressions by pattern.
+// Rule order counts only under FIRST, PRIORITY and COLLECT, where it can change a result; columns
+// and the cells within a row are digested by name. The table name and version are left out, as are
+// the observer and coercion cache size, which never change a result; function-valued options such as
+// WithStringNormalizer digest by whether they are set.
+func (dt *DecisionTable) Fingerprint() string {
+	if dt == nil {
+		return ""
+	}
+	h := sha256.New()
+	w := fingerprintWriter{h}
+	w.string(dt.matchPolicy.String())
+	w.string(dt.noMatchPolicy.String())
+	w.bool(dt.sparseOutput)
+	w.bool(dt.roundDecimalOutputs)
+	w.int(int64(dt.decimalOutputScale))
+	w.bool(dt.vacuousAllEqual)
+	w.bool(dt.threeValuedNulls)
+	w.bool(dt.uniquePerGroup)
+	w.int(int64(dt.rowValidation))
+	w.bool(dt.ruleIDGenerator != nil)
+	w.bool(dt.allowEmptyCollections)
+	w.bool(dt.rejectUnknownKeys)
+	w.bool(dt.allowNoConditions)
+	w.bool(dt.stringNormalizer != nil)
+	w.bool(dt.allowRepeatedColumns)
+	w.bool(dt.echoInput)
+	w.bool(dt.requireExplicitAny)
+	w.int(int64(dt.maxStringLength))
+	w.bool(dt.validateUniqueness)
+	w.bool(dt.sharedValues)
+
+	for _, cols := range [][]Column{dt.conditionOrder, dt.outputOrder} {
+		sorted := append([]Column(nil), cols...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+		w.int(int64(len(sorted)))
+		for _, col := range sorted {
+			w.string(col.Name)
+			w.string(col.Label)
+			w.string(string(col.Type))
+			w.string(string(col.DataType))
+			w.string(col.Group)
+			w.string(formatBound(col, col.Min))
+			w.string(formatBound(col, col.Max))
+			w.bool(col.Template)
+			w.bool(col.Default != nil)
+			w.string(formatBound(col, col.Default))
+			w.string(col.Unit)
+			w.bool(col.Required)
+			w.int(int64(col.Scale))
+			w.string(col.Path)
+			w.string(string(col.MissingPolicy))
+			w.string(col.Currency)
+			w.string(col.CurrencyColumn)
+		}
+	}
+
+	rows := make([][]byte, len(dt.rows))
+	for i, row := range dt.rows {
+		rows[i] = rowDigest(row)
+	}
+	switch dt.matchPolicy {
+	case MatchPolicyFirst, MatchPolicyPriority, MatchPolicyCollect:
+	default:
+		sort.Slice(rows, func(i, j int) bool { return string(rows[i]) < string(rows[j]) })
+	}
+	w.int(int64(len(rows)))
+	for _, digest := range rows {
+		h.Write(digest)
+	}
+	w.bool(dt.defaultRow != nil)
+	if dt.defaultRow != nil {
+		h.Write(rowDigest(*dt.defaultRow))
+	}
+	// guarded defaults are tried in order, so their digests are not sorted
+	w.int(int64(len(dt.guardedDefaults)))
+	for _, row := range dt.guardedDefaults {
+		h.Write(rowDigest(row))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// rowDigest hashes one row independently of its position in the table.
+func rowDigest(row Row) []byte {
+	h := sha256.New()
+	w := fingerprintWriter{h}
+	w.string(row.RuleID)
+	w.string(row.Comments)
+	w.int(int64(row.Priority))
+	w.bool(row.Disabled)
+	w.string(row.Group)
+	w.value(row.ValidFrom)
+	w.value(row.ValidUntil)
+	keys := make([]string, 0, len(row.Metadata))
+	for key := range row.Metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	w.int(int64(len(keys)))
+	for _, key := range keys {
+		w.string(key)
+		w.string(row.Metadata[key])
+	}
+	cells := cellsByColumn(row.EvalCells)
+	w.int(int64(len(cells)))
+	for _, cell := range cells {
+		w.string(cell.Column)
+		w.string(string(cell.Operator))
+		w.value(cell.Value)
+		w.string(cell.ValueRef)
+		w.string(strconv.FormatFloat(cell.Weight, 'g', -1, 64))
+		w.string(cell.Flags)
+		w.string(cell.Interval)
+		w.string(cell.Transform)
+	}
+	returns := returnsByColumn(row.ReturnCells)
+	w.int(int64(len(returns)))
+	for _, cell := range returns {
+		w.string(cell.Column)
+		w.string(string(cell.Modifier))
+		w.value(cell.Value)
+	}
+	return h.Sum(nil)
+}
+
+// fingerprintWriter writes length-prefixed, type-tagged fields so that no two distinct sequences of
+// fields produce the same byte stream.
+type fingerprintWriter struct {
+	h hash.Hash
+}
+
+func (w fingerprintWriter) int(n int64) {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], uint64(n))
+	w.h.Write(buf[:])
+}
+
+func (w fingerprintWriter) string(s string) {
+	w.int(int64(len(s)))
+	w.h.Write([]byte(s))
+}
+
+func (w fingerprintWriter) bool(b bool) {
+	if b {
+		w.h.Write([]byte{1})
+	} else {
+		w.h.Write([]byte{0})
+	}
+}
+
+func (w fingerprintWriter) value(v any) {
+	switch val := v.(type) {
+	case nil:
+		w.string("nil")
+	case string:
+		w.string("string")
+		w.string(val)
+	case int64:
+		w.string("int")
+		w.int(val)
+	case bool:
+		w.string("bool")
+		w.bool(val)
+	case *big.Float:
+		// the binary form is exact and does not depend on the value's precision
+		w.string("decimal")
+		w.string(val.Text('p', 0))
+	case time.Time:
+		w.string("time")
+		w.string(val.UTC().Format(time.RFC3339Nano))
+	case time.Duration:
+		w.string("duration")
+		w.int(int64(val))
+	case *regexp.Regexp:
+		w.string("regexp")
+		w.string(val.String())
+	case []any:
+		w.string("list")
+		w.int(int64(len(val)))
+		for _, item := range val {
+			w.value(item)
+		}
+	default:
+		w.string(fmt.Sprintf("%T", val))
+		w.string(fmt.Sprint(val))
+	}
+}
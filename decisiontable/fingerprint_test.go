@@ -0,0 +1,115 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func buildFingerprintTable(t *testing.T, name string, policy MatchPolicy, rows []Row, opts ...Option) *DecisionTable {
+	t.Helper()
+	dt, err := NewDecisionTable(name,
+		[]Column{
+			{Name: "amount", Type: ColumnTypeCondition, DataType: DataTypeDecimal},
+			{Name: "since", Type: ColumnTypeCondition, DataType: DataTypeDateTime},
+		},
+		[]Column{{Name: "tier", Type: ColumnTypeConclusion, DataType: DataTypeString}},
+		append([]Option{WithMatchPolicy(policy)}, opts...)...,
+	)
+	if err != nil {
+		t.Fatalf("failed to build table: %v", err)
+	}
+	for _, row := range rows {
+		if err := dt.AddRow(row); err != nil {
+			t.Fatalf("failed to add row %s: %v", row.RuleID, err)
+		}
+	}
+	return dt
+}
+
+func TestFingerprint(t *testing.T) {
+	gold := Row{RuleID: "gold", EvalCells: []EvalCell{{Column: "amount", Operator: OperatorGreater, Value: "100.50"}}, ReturnCells: []ReturnCell{{Column: "tier", Value: "gold"}}}
+	goldScale := gold
+	goldScale.EvalCells = []EvalCell{{Column: "amount", Operator: OperatorGreater, Value: 100.5}}
+	recent := Row{RuleID: "recent", EvalCells: []EvalCell{{Column: "since", Operator: OperatorGreaterOrEqual, Value: "2025-01-01T02:00:00+02:00"}}, ReturnCells: []ReturnCell{{Column: "tier", Value: "new"}}}
+	recentUTC := recent
+	recentUTC.EvalCells = []EvalCell{{Column: "since", Operator: OperatorGreaterOrEqual, Value: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}}
+
+	base := buildFingerprintTable(t, "a", MatchPolicyAll, []Row{gold, recent})
+	fp := base.Fingerprint()
+	if len(fp) != 64 {
+		t.Fatalf("expected a hex sha-256, got %q", fp)
+	}
+	if got := buildFingerprintTable(t, "b", MatchPolicyAll, []Row{goldScale, recentUTC}).Fingerprint(); got != fp {
+		t.Fatalf("expected equivalent values and another name to keep the fingerprint")
+	}
+	if got := buildFingerprintTable(t, "a", MatchPolicyAll, []Row{recent, gold}).Fingerprint(); got != fp {
+		t.Fatalf("expected rule order to be ignored under ALL")
+	}
+
+	first := buildFingerprintTable(t, "a", MatchPolicyFirst, []Row{gold, recent}).Fingerprint()
+	if first == fp {
+		t.Fatalf("expected the match policy to change the fingerprint")
+	}
+	if got := buildFingerprintTable(t, "a", MatchPolicyFirst, []Row{recent, gold}).Fingerprint(); got == first {
+		t.Fatalf("expected rule order to matter under FIRST")
+	}
+
+	changed := gold
+	changed.EvalCells = []EvalCell{{Column: "amount", Operator: OperatorGreater, Value: "100.51"}}
+	if got := buildFingerprintTable(t, "a", MatchPolicyAll, []Row{changed, recent}).Fingerprint(); got == fp {
+		t.Fatalf("expected a changed value to change the fingerprint")
+	}
+	if err := base.DisableRule("gold"); err != nil {
+		t.Fatalf("disable rule: %v", err)
+	}
+	if base.Fingerprint() == fp {
+		t.Fatalf("expected disabling a rule to change the fingerprint")
+	}
+}
+
+func TestFingerprintCoversOptions(t *testing.T) {
+	gold := Row{RuleID: "gold", EvalCells: []EvalCell{{Column: "amount", Operator: OperatorGreater, Value: "100.50"}}, ReturnCells: []ReturnCell{{Column: "tier", Value: "gold"}}}
+	base := buildFingerprintTable(t, "a", MatchPolicyAll, []Row{gold}).Fingerprint()
+	if got := buildFingerprintTable(t, "a", MatchPolicyAll, []Row{gold}, WithCoercionCache(16)).Fingerprint(); got != base {
+		t.Fatalf("expected the coercion cache size to keep the fingerprint")
+	}
+
+	options := []struct {
+		name string
+		opt  Option
+	}{
+		{"row validation", WithRowValidationPolicy(RowValidationLenient)},
+		{"empty collections", WithAllowEmptyCollections()},
+		{"unknown keys", WithRejectUnknownInputKeys()},
+		{"no conditions", WithAllowNoConditions()},
+		{"string normalizer", WithStringNormalizer(strings.ToLower)},
+		{"repeated columns", WithRepeatedConditionColumns()},
+		{"echo input", WithEchoInput()},
+		{"explicit any", WithExplicitAnyCells()},
+		{"max string length", WithMaxStringLength(8)},
+		{"validate uniqueness", WithValidateUniqueness()},
+		{"shared values", WithUnsafeSharedValues()},
+	}
+	for _, tc := range options {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := buildFingerprintTable(t, "a", MatchPolicyAll, []Row{gold}, tc.opt).Fingerprint(); got == base {
+				t.Fatalf("expected the option to change the fingerprint")
+			}
+		})
+	}
+}
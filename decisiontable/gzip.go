@@ -0,0 +1,54 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+)
+
+// LoadJSONGzip loads a decision table from a gzip-compressed JSON document.
+func LoadJSONGzip(r io.Reader, name string, opts ...Option) (*DecisionTable, error) {
+	data, err := readGzip(r, name)
+	if err != nil {
+		return nil, err
+	}
+	return LoadJSON(data, name, opts...)
+}
+
+// LoadExcelGzip loads a decision table from a gzip-compressed Excel workbook. The workbook is
+// decompressed in memory first, so a corrupt gzip stream is reported as such rather than as a bad workbook.
+func LoadExcelGzip(name string, r io.Reader, opts ...Option) (*DecisionTable, error) {
+	data, err := readGzip(r, name)
+	if err != nil {
+		return nil, err
+	}
+	return LoadExcel(name, bytes.NewReader(data), opts...)
+}
+
+func readGzip(r io.Reader, name string) ([]byte, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("open gzip stream %s: %w", name, err)
+	}
+	defer gz.Close()
+	data, err := io.ReadAll(gz)
+	if err != nil {
+		return nil, fmt.Errorf("read gzip stream %s: %w", name, err)
+	}
+	return data, nil
+}
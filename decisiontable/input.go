@@ -0,0 +1,46 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+// InputProvider supplies input values by column name so tables can be evaluated against structs,
+// protobuf messages or database rows without first copying them into a map. Get reports false when
+// the column is absent; absent columns are evaluated as nil.
+type InputProvider interface {
+	Get(column string) (any, bool)
+}
+
+// MapInput adapts a plain map to InputProvider.
+type MapInput map[string]any
+
+// Get implements InputProvider.
+func (m MapInput) Get(column string) (any, bool) {
+	v, ok := m[column]
+	return v, ok
+}
+
+// Keys returns the map keys so WithRejectUnknownInputKeys can inspect them.
+func (m MapInput) Keys() []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// keyedInput is implemented by providers that can enumerate their keys. Unknown-key rejection is
+// only applied to such providers.
+type keyedInput interface {
+	Keys() []string
+}
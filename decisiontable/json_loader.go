@@ -15,6 +15,7 @@
 package decisiontable
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -22,24 +23,49 @@ import (
 )
 
 // LoadJSONFile loads a decision table from a JSON file that follows the new DSL spec.
-func LoadJSONFile(path string) (*DecisionTable, error) {
+// Options are applied before the policies declared in the document.
+func LoadJSONFile(path string, opts ...Option) (*DecisionTable, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("read json file %s: %w", path, err)
 	}
-	return LoadJSON(data, path)
+	return LoadJSON(data, path, opts...)
 }
 
 // LoadJSON loads a decision table from raw JSON bytes.
-func LoadJSON(data []byte, name string) (*DecisionTable, error) {
+func LoadJSON(data []byte, name string, opts ...Option) (*DecisionTable, error) {
+	dt, errs := loadJSON(data, name, opts, false)
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+	return dt, nil
+}
+
+// ValidateJSON checks a JSON document the way LoadJSON does but reports every problem it finds instead
+// of stopping at the first. Rules are checked independently, so one bad rule does not hide the next;
+// column and policy errors still prevent the rules from being checked. A valid document yields nil.
+func ValidateJSON(data []byte) []error {
+	// The source name only stands in for a missing table name.
+	_, errs := loadJSON(data, "json", nil, true)
+	return errs
+}
+
+// loadJSON decodes and builds a JSON document. With accumulate it keeps checking after the first
+// error and returns every one it finds; the table is nil whenever errors are returned.
+func loadJSON(data []byte, name string, opts []Option, accumulate bool) (*DecisionTable, []error) {
 	var doc jsonDocument
 	if err := json.Unmarshal(data, &doc); err != nil {
-		return nil, fmt.Errorf("invalid json: %w", err)
+		return nil, []error{fmt.Errorf("invalid json: %w", err)}
 	}
 	if doc.DecisionTable == nil {
-		return nil, fmt.Errorf("json does not contain a decisionTable object")
+		return nil, []error{fmt.Errorf("json does not contain a decisionTable object")}
 	}
-	return buildDecisionTable(*doc.DecisionTable, name)
+	errs := buildErrors{accumulate: accumulate}
+	dt := buildDecisionTable(*doc.DecisionTable, name, opts, &errs)
+	if len(errs.errs) > 0 {
+		return nil, errs.errs
+	}
+	return dt, nil
 }
 
 type jsonDocument struct {
@@ -49,10 +75,15 @@ type jsonDocument struct {
 type jsonDecisionTableSpec struct {
 	Name        string               `json:"name"`
 	Description string               `json:"description"`
+	Version     string               `json:"version"`
 	Policies    jsonPoliciesSpec     `json:"policies"`
 	Columns     []jsonColumnSpec     `json:"columns"`
+	Enums       map[string][]any     `json:"enums"`
 	Rules       []jsonRuleSpec       `json:"rules"`
+	Templates   []jsonRuleTemplate   `json:"ruleTemplates"`
 	DefaultRule *jsonDefaultRuleSpec `json:"defaultRule"`
+	// DefaultRuleID names a rule, without conditions, that serves as the default row instead.
+	DefaultRuleID string `json:"defaultRuleId"`
 }
 
 type jsonPoliciesSpec struct {
@@ -61,55 +92,146 @@ type jsonPoliciesSpec struct {
 }
 
 type jsonColumnSpec struct {
-	Name     string `json:"name"`
-	Label    string `json:"label"`
-	Type     string `json:"type"`
-	DataType string `json:"dataType"`
+	Name           string      `json:"name"`
+	Label          string      `json:"label"`
+	Type           string      `json:"type"`
+	DataType       string      `json:"dataType"`
+	Group          string      `json:"group"`
+	Min            json.Number `json:"min"`
+	Max            json.Number `json:"max"`
+	Template       bool        `json:"template"`
+	Default        any         `json:"default"`
+	Unit           string      `json:"unit"`
+	Required       bool        `json:"required"`
+	Scale          int         `json:"scale"`
+	Path           string      `json:"path"`
+	Missing        string      `json:"missing"`
+	Currency       string      `json:"currency"`
+	CurrencyColumn string      `json:"currencyColumn"`
 }
 
 type jsonRuleSpec struct {
 	ID          string               `json:"id"`
 	Description string               `json:"description"`
+	Priority    *int                 `json:"priority"`
+	Enabled     *bool                `json:"enabled"`
+	ValidFrom   string               `json:"validFrom"`
+	ValidUntil  string               `json:"validUntil"`
+	Group       string               `json:"group"`
 	When        []*jsonConditionCell `json:"when"`
-	Then        []any                `json:"then"`
+	Then        jsonThen             `json:"then"`
 }
 
 type jsonConditionCell struct {
-	Operator string `json:"operator"`
-	Value    any    `json:"value"`
+	Operator  string  `json:"operator"`
+	Value     any     `json:"value"`
+	Weight    float64 `json:"weight"`
+	Flags     string  `json:"flags"`
+	Interval  string  `json:"interval"`
+	Transform string  `json:"transform"`
+	ValueRef  string  `json:"valueRef"`
 }
 
 type jsonDefaultRuleSpec struct {
-	Description string `json:"description"`
-	Then        []any  `json:"then"`
+	Description string   `json:"description"`
+	Then        jsonThen `json:"then"`
+}
+
+// jsonThen holds the outputs of a rule, written either positionally as an array or as an object keyed
+// by output column name. Columns missing from the object form are returned as null.
+type jsonThen struct {
+	values []any
+	named  map[string]any
+}
+
+func (t *jsonThen) UnmarshalJSON(data []byte) error {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		return json.Unmarshal(data, &t.named)
+	}
+	return json.Unmarshal(data, &t.values)
+}
+
+func (t jsonThen) returnCells(outputCols []Column) ([]ReturnCell, error) {
+	cells := make([]ReturnCell, 0, len(outputCols))
+	if t.named == nil {
+		if len(t.values) != len(outputCols) {
+			return nil, fmt.Errorf("expected %d then values, got %d", len(outputCols), len(t.values))
+		}
+		for idx, column := range outputCols {
+			cells = append(cells, ReturnCell{Column: column.Name, Value: t.values[idx]})
+		}
+		return cells, nil
+	}
+	known := make(map[string]struct{}, len(outputCols))
+	for _, column := range outputCols {
+		known[column.Name] = struct{}{}
+		cells = append(cells, ReturnCell{Column: column.Name, Value: t.named[column.Name]})
+	}
+	for name := range t.named {
+		if _, ok := known[name]; !ok {
+			return nil, fmt.Errorf("then references unknown output column %q", name)
+		}
+	}
+	return cells, nil
+}
+
+// buildErrors collects the problems found while building a table from a JSON document. Unless
+// accumulate is set the build stops at the first one.
+type buildErrors struct {
+	accumulate bool
+	errs       []error
 }
 
-func buildDecisionTable(spec jsonDecisionTableSpec, sourceName string) (*DecisionTable, error) {
+// add records err and reports whether the build should go on.
+func (b *buildErrors) add(err error) bool {
+	b.errs = append(b.errs, err)
+	return b.accumulate
+}
+
+func buildDecisionTable(spec jsonDecisionTableSpec, sourceName string, opts []Option, errs *buildErrors) *DecisionTable {
 	if len(spec.Columns) == 0 {
-		return nil, fmt.Errorf("decision table requires at least one column")
+		errs.add(fmt.Errorf("decision table requires at least one column"))
+		return nil
+	}
+	rules := spec.Rules
+	for idx, tmpl := range spec.Templates {
+		expanded, err := tmpl.expand()
+		if err != nil {
+			if !errs.add(fmt.Errorf("rule template %d: %w", idx+1, err)) {
+				return nil
+			}
+			continue
+		}
+		rules = append(rules, expanded...)
 	}
-	if len(spec.Rules) == 0 {
-		return nil, fmt.Errorf("decision table requires at least one rule")
+	if len(rules) == 0 {
+		if !errs.add(fmt.Errorf("decision table requires at least one rule")) {
+			return nil
+		}
 	}
 	if spec.Policies.MatchPolicy == "" {
-		return nil, fmt.Errorf("policies.matchPolicy is required")
+		if !errs.add(fmt.Errorf("policies.matchPolicy is required")) {
+			return nil
+		}
 	}
 	if spec.Policies.NoMatchPolicy == "" {
-		return nil, fmt.Errorf("policies.noMatchPolicy is required")
+		if !errs.add(fmt.Errorf("policies.noMatchPolicy is required")) {
+			return nil
+		}
 	}
 
-	mp, err := parseMatchPolicyString(spec.Policies.MatchPolicy)
-	if err != nil {
-		return nil, err
+	mp, mpErr := parseMatchPolicyString(spec.Policies.MatchPolicy)
+	if mpErr != nil && spec.Policies.MatchPolicy != "" && !errs.add(mpErr) {
+		return nil
 	}
-	nmp, err := parseNoMatchPolicyString(spec.Policies.NoMatchPolicy)
-	if err != nil {
-		return nil, err
+	nmp, nmpErr := parseNoMatchPolicyString(spec.Policies.NoMatchPolicy)
+	if nmpErr != nil && spec.Policies.NoMatchPolicy != "" && !errs.add(nmpErr) {
+		return nil
 	}
 
-	conditionCols, outputCols, err := convertColumns(spec.Columns)
-	if err != nil {
-		return nil, err
+	conditionCols, outputCols, ok := convertColumns(spec.Columns, errs)
+	if !ok || mpErr != nil || nmpErr != nil {
+		return nil
 	}
 
 	name := strings.TrimSpace(spec.Name)
@@ -117,82 +239,170 @@ func buildDecisionTable(spec jsonDecisionTableSpec, sourceName string) (*Decisio
 		name = sourceName
 	}
 
-	dt, err := NewDecisionTable(name, conditionCols, outputCols, WithMatchPolicy(mp), WithNoMatchPolicy(nmp))
+	opts = withDeclaredPolicies(opts, mp, nmp)
+	if version := strings.TrimSpace(spec.Version); version != "" {
+		opts = append(opts, WithVersion(version))
+	}
+	dt, err := NewDecisionTable(name, conditionCols, outputCols, opts...)
 	if err != nil {
-		return nil, err
+		errs.add(err)
+		return nil
 	}
 
+	defaultRuleID := strings.TrimSpace(spec.DefaultRuleID)
+	if defaultRuleID != "" && spec.DefaultRule != nil {
+		if !errs.add(fmt.Errorf("defaultRule and defaultRuleId cannot both be set")) {
+			return nil
+		}
+	}
+	if defaultRuleID != "" {
+		if err := checkDefaultRuleIDPolicy("defaultRuleId", nmp); err != nil {
+			if !errs.add(err) {
+				return nil
+			}
+			defaultRuleID = ""
+		}
+	}
+	var designated *Row
 	ruleIDs := make(map[string]struct{})
-	for idx, rule := range spec.Rules {
-		row, err := convertRule(rule, conditionCols, outputCols, idx+1, ruleIDs)
-		if err != nil {
-			return nil, fmt.Errorf("rule %d: %w", idx+1, err)
+	for idx, rule := range rules {
+		row, err := convertRule(rule, conditionCols, outputCols, spec.Enums, idx+1, ruleIDs, dt.generateRuleID)
+		if err == nil && defaultRuleID != "" && row.RuleID == defaultRuleID {
+			if err = checkDesignatedDefault(row); err == nil {
+				designated = &row
+				continue
+			}
+		} else if err == nil {
+			err = dt.AddRow(row)
+		} else {
+			row.RuleID = strings.TrimSpace(rule.ID)
 		}
-		if err := dt.AddRow(row); err != nil {
-			return nil, fmt.Errorf("rule %d: %w", idx+1, err)
+		if err != nil && !errs.add(&LoadError{Rule: idx + 1, RuleID: row.RuleID, Err: err}) {
+			return nil
 		}
 	}
 
-	if spec.DefaultRule != nil {
-		defaultRow, err := convertDefaultRule(*spec.DefaultRule, outputCols, len(spec.Rules)+1, ruleIDs)
-		if err != nil {
-			return nil, err
+	if defaultRuleID != "" {
+		var err error
+		if designated != nil {
+			err = dt.SetDefaultRow(*designated)
+		} else if _, known := ruleIDs[defaultRuleID]; !known {
+			err = fmt.Errorf("defaultRuleId %q does not name a rule", defaultRuleID)
+		}
+		if err != nil && !errs.add(err) {
+			return nil
 		}
-		if err := dt.SetDefaultRow(defaultRow); err != nil {
-			return nil, err
+	} else if spec.DefaultRule != nil {
+		defaultRow, err := convertDefaultRule(*spec.DefaultRule, outputCols, len(rules)+1, ruleIDs, dt.generateRuleID)
+		if err == nil {
+			err = dt.SetDefaultRow(defaultRow)
+		}
+		if err != nil && !errs.add(err) {
+			return nil
 		}
 	} else if nmp == NoMatchPolicyReturnDefault {
-		return nil, fmt.Errorf("defaultRule section required for RETURN_DEFAULT policy")
+		if !errs.add(fmt.Errorf("defaultRule section required for RETURN_DEFAULT policy")) {
+			return nil
+		}
 	}
 
-	return dt, nil
+	if len(errs.errs) > 0 {
+		return nil
+	}
+	return dt
 }
 
-func convertColumns(cols []jsonColumnSpec) ([]Column, []Column, error) {
+// convertColumns splits the column specs into condition and output columns. It reports false when any
+// column was rejected.
+func convertColumns(cols []jsonColumnSpec, errs *buildErrors) ([]Column, []Column, bool) {
 	conditions := make([]Column, 0, len(cols))
 	outputs := make([]Column, 0, len(cols))
 	seen := make(map[string]struct{}, len(cols))
+	ok := true
 	for idx, col := range cols {
 		name := strings.TrimSpace(col.Name)
 		if name == "" {
-			return nil, nil, fmt.Errorf("column %d name cannot be empty", idx+1)
+			ok = false
+			if !errs.add(fmt.Errorf("column %d name cannot be empty", idx+1)) {
+				return nil, nil, false
+			}
+			continue
 		}
 		if _, exists := seen[name]; exists {
-			return nil, nil, fmt.Errorf("duplicate column name %q", name)
+			ok = false
+			if !errs.add(fmt.Errorf("duplicate column name %q", name)) {
+				return nil, nil, false
+			}
+			continue
 		}
+		seen[name] = struct{}{}
 		colType, err := parseColumnTypeString(col.Type)
 		if err != nil {
-			return nil, nil, fmt.Errorf("column %s: %w", name, err)
+			ok = false
+			if !errs.add(fmt.Errorf("column %s: %w", name, err)) {
+				return nil, nil, false
+			}
 		}
 		dataType, err := parseDataTypeString(col.DataType)
 		if err != nil {
-			return nil, nil, fmt.Errorf("column %s: %w", name, err)
+			ok = false
+			if !errs.add(fmt.Errorf("column %s: %w", name, err)) {
+				return nil, nil, false
+			}
+		}
+		missing, err := parseMissingPolicy(col.Missing)
+		if err != nil {
+			ok = false
+			if !errs.add(fmt.Errorf("column %s: %w", name, err)) {
+				return nil, nil, false
+			}
+		}
+		column := Column{
+			Name:           name,
+			Label:          strings.TrimSpace(col.Label),
+			Type:           colType,
+			DataType:       dataType,
+			Group:          strings.TrimSpace(col.Group),
+			Min:            optionalNumber(col.Min),
+			Max:            optionalNumber(col.Max),
+			Default:        col.Default,
+			Unit:           strings.TrimSpace(col.Unit),
+			Required:       col.Required,
+			Scale:          col.Scale,
+			Path:           strings.TrimSpace(col.Path),
+			Template:       col.Template,
+			MissingPolicy:  missing,
+			Currency:       strings.TrimSpace(col.Currency),
+			CurrencyColumn: strings.TrimSpace(col.CurrencyColumn),
 		}
-		column := Column{Name: name, Type: colType, DataType: dataType}
 		switch colType {
 		case ColumnTypeCondition:
 			conditions = append(conditions, column)
 		case ColumnTypeConclusion, ColumnTypeMetadata:
 			outputs = append(outputs, column)
 		}
-		seen[name] = struct{}{}
 	}
-	if len(conditions) == 0 {
-		return nil, nil, fmt.Errorf("at least one CONDITION column is required")
+	if ok && len(outputs) == 0 {
+		errs.add(fmt.Errorf("at least one CONCLUSION or METADATA column is required"))
+		return nil, nil, false
 	}
-	if len(outputs) == 0 {
-		return nil, nil, fmt.Errorf("at least one CONCLUSION or METADATA column is required")
+	return conditions, outputs, ok
+}
+
+// optionalNumber maps an omitted min/max to nil so the column stays unbounded on that side.
+func optionalNumber(n json.Number) any {
+	if n == "" {
+		return nil
 	}
-	return conditions, outputs, nil
+	return n
 }
 
-func convertRule(rule jsonRuleSpec, conditionCols, outputCols []Column, rowNumber int, ruleIDs map[string]struct{}) (Row, error) {
+// convertRule maps a rule spec onto a Row. A valueRef of the form @name stands for the list declared
+// under that name in enums; any other valueRef names a condition column.
+func convertRule(rule jsonRuleSpec, conditionCols, outputCols []Column, enums map[string][]any, rowNumber int, ruleIDs map[string]struct{}, newID func(int) string) (Row, error) {
 	if len(rule.When) != len(conditionCols) {
 		return Row{}, fmt.Errorf("expected %d when cells, got %d", len(conditionCols), len(rule.When))
 	}
-	if len(rule.Then) != len(outputCols) {
-		return Row{}, fmt.Errorf("expected %d then values, got %d", len(outputCols), len(rule.Then))
-	}
 	row := Row{
 		Number:   rowNumber,
 		RuleID:   strings.TrimSpace(rule.ID),
@@ -214,51 +424,99 @@ func convertRule(rule jsonRuleSpec, conditionCols, outputCols []Column, rowNumbe
 		if err != nil {
 			return Row{}, fmt.Errorf("column %s: %w", column.Name, err)
 		}
-		if cell.Value == nil && op != OperatorIsNull && op != OperatorIsNotNull {
+		if err := checkOperatorDataType(op, column.DataType); err != nil {
+			return Row{}, fmt.Errorf("column %s: %w", column.Name, err)
+		}
+		value, valueRef := cell.Value, strings.TrimSpace(cell.ValueRef)
+		if name, isEnum := strings.CutPrefix(valueRef, "@"); isEnum {
+			if value != nil {
+				return Row{}, fmt.Errorf("column %s: value and enum reference %s are mutually exclusive", column.Name, valueRef)
+			}
+			list, ok := enums[name]
+			if !ok {
+				return Row{}, fmt.Errorf("column %s: unknown enum %s", column.Name, valueRef)
+			}
+			value, valueRef = append([]any{}, list...), ""
+		}
+		if value == nil && valueRef == "" && op != OperatorIsNull && op != OperatorIsNotNull && op != OperatorDefault {
 			return Row{}, fmt.Errorf("column %s: operator %s requires a value", column.Name, operator)
 		}
 		row.EvalCells = append(row.EvalCells, EvalCell{
-			Column:   column.Name,
-			Operator: op,
-			Value:    cell.Value,
+			Column:    column.Name,
+			Operator:  op,
+			Value:     value,
+			Weight:    cell.Weight,
+			Flags:     cell.Flags,
+			Interval:  cell.Interval,
+			Transform: cell.Transform,
+			ValueRef:  valueRef,
 		})
 	}
-	for idx, column := range outputCols {
-		row.ReturnCells = append(row.ReturnCells, ReturnCell{
-			Column: column.Name,
-			Value:  rule.Then[idx],
-		})
+	cells, err := rule.Then.returnCells(outputCols)
+	if err != nil {
+		return Row{}, err
+	}
+	row.ReturnCells = cells
+	fillCommentsFromMetadata(&row, outputCols)
+	fillMetadataMap(&row, outputCols)
+	if err := fillRowFromMetadata(&row, outputCols); err != nil {
+		return Row{}, err
+	}
+	if rule.Priority != nil {
+		row.Priority = *rule.Priority
+	}
+	if rule.Group != "" {
+		row.Group = rule.Group
+	}
+	if rule.Enabled != nil {
+		row.Disabled = !*rule.Enabled
+	}
+	if rule.ValidFrom != "" {
+		ts, err := parseValidityBound(rule.ValidFrom, false)
+		if err != nil {
+			return Row{}, fmt.Errorf("validFrom: %w", err)
+		}
+		row.ValidFrom = ts
+	}
+	if rule.ValidUntil != "" {
+		ts, err := parseValidityBound(rule.ValidUntil, true)
+		if err != nil {
+			return Row{}, fmt.Errorf("validUntil: %w", err)
+		}
+		row.ValidUntil = ts
 	}
-	if err := ensureUniqueRuleID(&row, rowNumber, ruleIDs); err != nil {
+	if err := ensureUniqueRuleID(&row, rowNumber, ruleIDs, newID); err != nil {
 		return Row{}, err
 	}
 	return row, nil
 }
 
-func convertDefaultRule(rule jsonDefaultRuleSpec, outputCols []Column, rowNumber int, ruleIDs map[string]struct{}) (Row, error) {
-	if len(rule.Then) != len(outputCols) {
-		return Row{}, fmt.Errorf("defaultRule: expected %d values, got %d", len(outputCols), len(rule.Then))
+func convertDefaultRule(rule jsonDefaultRuleSpec, outputCols []Column, rowNumber int, ruleIDs map[string]struct{}, newID func(int) string) (Row, error) {
+	cells, err := rule.Then.returnCells(outputCols)
+	if err != nil {
+		return Row{}, fmt.Errorf("defaultRule: %w", err)
 	}
 	row := Row{
-		Number:   rowNumber,
-		Comments: rule.Description,
-	}
-	for idx, column := range outputCols {
-		row.ReturnCells = append(row.ReturnCells, ReturnCell{
-			Column: column.Name,
-			Value:  rule.Then[idx],
-		})
+		Number:      rowNumber,
+		Comments:    rule.Description,
+		ReturnCells: cells,
 	}
-	if err := ensureUniqueRuleID(&row, rowNumber, ruleIDs); err != nil {
+	fillCommentsFromMetadata(&row, outputCols)
+	fillMetadataMap(&row, outputCols)
+	if err := ensureUniqueRuleID(&row, rowNumber, ruleIDs, newID); err != nil {
 		return Row{}, err
 	}
 	return row, nil
 }
 
-func ensureUniqueRuleID(row *Row, rowNumber int, ruleIDs map[string]struct{}) error {
+func ensureUniqueRuleID(row *Row, rowNumber int, ruleIDs map[string]struct{}, newID func(int) string) error {
 	id := strings.TrimSpace(row.RuleID)
 	if id == "" {
-		id = fmt.Sprintf("%d", rowNumber)
+		id = strings.TrimSpace(newID(rowNumber))
+		row.autoID = true
+	}
+	if id == "" {
+		return fmt.Errorf("rule id generator returned an empty id for row %d", rowNumber)
 	}
 	if _, exists := ruleIDs[id]; exists {
 		return fmt.Errorf("duplicate rule id %q", id)
@@ -0,0 +1,126 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// jsonRuleTemplate expands one rule into a rule per entry of Values. Every string in the template
+// rule may reference an entry's keys as {{key}}; a string that is exactly one placeholder takes the
+// entry's value with its JSON type, so a number or list can be substituted into "value" or "then".
+// Generated rules are appended after the explicit rules, in template and entry order. Their IDs are
+// the substituted template ID; a template ID without placeholders gets a -1, -2, ... suffix, and an
+// empty one is left to the rule ID generator. Duplicate IDs are rejected like any other.
+type jsonRuleTemplate struct {
+	Rule   json.RawMessage  `json:"rule"`
+	Values []map[string]any `json:"values"`
+}
+
+var templatePlaceholder = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_]+)\s*\}\}`)
+
+func (t jsonRuleTemplate) expand() ([]jsonRuleSpec, error) {
+	if len(t.Rule) == 0 {
+		return nil, fmt.Errorf("rule is required")
+	}
+	if len(t.Values) == 0 {
+		return nil, fmt.Errorf("values must list at least one entry")
+	}
+	var rule any
+	if err := json.Unmarshal(t.Rule, &rule); err != nil {
+		return nil, fmt.Errorf("invalid rule: %w", err)
+	}
+	fields, ok := rule.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("rule must be an object")
+	}
+	id, _ := fields["id"].(string)
+	suffixIDs := strings.TrimSpace(id) != "" && !templatePlaceholder.MatchString(id)
+
+	rules := make([]jsonRuleSpec, 0, len(t.Values))
+	for idx, vars := range t.Values {
+		substituted, err := substitutePlaceholders(rule, vars)
+		if err != nil {
+			return nil, fmt.Errorf("entry %d: %w", idx+1, err)
+		}
+		data, err := json.Marshal(substituted)
+		if err != nil {
+			return nil, fmt.Errorf("entry %d: %w", idx+1, err)
+		}
+		var spec jsonRuleSpec
+		if err := json.Unmarshal(data, &spec); err != nil {
+			return nil, fmt.Errorf("entry %d: %w", idx+1, err)
+		}
+		if suffixIDs {
+			spec.ID = strings.TrimSpace(id) + "-" + strconv.Itoa(idx+1)
+		}
+		rules = append(rules, spec)
+	}
+	return rules, nil
+}
+
+// substitutePlaceholders returns a copy of the decoded JSON value v with placeholders replaced from vars.
+func substitutePlaceholders(v any, vars map[string]any) (any, error) {
+	switch val := v.(type) {
+	case string:
+		if m := templatePlaceholder.FindStringSubmatch(val); m != nil && m[0] == val {
+			replacement, ok := vars[m[1]]
+			if !ok {
+				return nil, fmt.Errorf("placeholder {{%s}} has no value", m[1])
+			}
+			return replacement, nil
+		}
+		var missing string
+		out := templatePlaceholder.ReplaceAllStringFunc(val, func(match string) string {
+			key := templatePlaceholder.FindStringSubmatch(match)[1]
+			replacement, ok := vars[key]
+			if !ok {
+				missing = key
+				return match
+			}
+			return fmt.Sprint(replacement)
+		})
+		if missing != "" {
+			return nil, fmt.Errorf("placeholder {{%s}} has no value", missing)
+		}
+		return out, nil
+	case []any:
+		out := make([]any, len(val))
+		for i, elem := range val {
+			substituted, err := substitutePlaceholders(elem, vars)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = substituted
+		}
+		return out, nil
+	case map[string]any:
+		out := make(map[string]any, len(val))
+		for k, elem := range val {
+			substituted, err := substitutePlaceholders(elem, vars)
+			if err != nil {
+				return nil, err
+			}
+			out[k] = substituted
+		}
+		return out, nil
+	default:
+		return v, nil
+	}
+}
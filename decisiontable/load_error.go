@@ -0,0 +1,52 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LoadError locates a rule that failed to load. Loaders wrap row-level errors in it, so callers can use
+// errors.As to point at the offending cell range; fields that do not apply to the source are zero.
+type LoadError struct {
+	Sheet  string // Excel sheet holding the rule
+	Row    int    // Excel row of the rule, 1-based
+	Rule   int    // position of the rule in a JSON document, 1-based
+	RuleID string // rule ID when it is known
+	Err    error
+}
+
+func (e *LoadError) Error() string {
+	var b strings.Builder
+	if e.Sheet != "" {
+		fmt.Fprintf(&b, "sheet %q ", e.Sheet)
+	}
+	if e.Row > 0 {
+		fmt.Fprintf(&b, "row %d", e.Row)
+	} else {
+		fmt.Fprintf(&b, "rule %d", e.Rule)
+	}
+	if e.RuleID != "" {
+		fmt.Fprintf(&b, " (id %q)", e.RuleID)
+	}
+	b.WriteString(": ")
+	b.WriteString(e.Err.Error())
+	return b.String()
+}
+
+func (e *LoadError) Unwrap() error {
+	return e.Err
+}
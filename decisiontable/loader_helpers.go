@@ -16,13 +16,118 @@ package decisiontable
 
 import (
 	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
+	"unicode"
 )
 
+// normalizeKeyword upper-cases s and joins its words with single underscores, so "return default",
+// "Return-Default" and "RETURN_DEFAULT" all normalize to the same token.
 func normalizeKeyword(s string) string {
-	upper := strings.ToUpper(strings.TrimSpace(s))
-	upper = strings.ReplaceAll(upper, " ", "_")
-	return upper
+	words := strings.FieldsFunc(strings.ToUpper(s), func(r rune) bool {
+		return r == '_' || r == '-' || unicode.IsSpace(r)
+	})
+	return strings.Join(words, "_")
+}
+
+// Markers of the optional column attribute rows that may follow the data type row in the Excel and
+// CSV layouts, in any order.
+const (
+	attributeLabel          = "Label"
+	attributeMin            = "Min"
+	attributeMax            = "Max"
+	attributeTemplate       = "Template"
+	attributeDefault        = "Default"
+	attributeUnit           = "Unit"
+	attributeRequired       = "Required"
+	attributeScale          = "Scale"
+	attributePath           = "Path"
+	attributeMissing        = "Missing"
+	attributeCurrency       = "Currency"
+	attributeCurrencyColumn = "Currency Column"
+)
+
+// columnAttribute resolves a first-column marker to the attribute row it names.
+func columnAttribute(marker string) (string, bool) {
+	marker = strings.TrimSpace(marker)
+	for _, attr := range []string{attributeLabel, attributeMin, attributeMax, attributeTemplate, attributeDefault, attributeUnit, attributeRequired, attributeScale, attributePath, attributeMissing, attributeCurrency, attributeCurrencyColumn} {
+		if strings.EqualFold(marker, attr) {
+			return attr, true
+		}
+	}
+	return "", false
+}
+
+// setColumnAttribute applies a cell from an attribute row. Empty Min/Max cells leave that side unbounded;
+// the bounds themselves are coerced and checked when the table is built, as is a Default. A blank
+// Template or Required cell means false, a blank Default cell means no default and a blank Scale cell
+// leaves the column unchecked.
+func setColumnAttribute(col *Column, attr, raw string) error {
+	value := strings.TrimSpace(raw)
+	switch attr {
+	case attributeLabel:
+		col.Label = value
+	case attributeMin:
+		if value != "" {
+			col.Min = value
+		}
+	case attributeMax:
+		if value != "" {
+			col.Max = value
+		}
+	case attributeTemplate, attributeRequired:
+		if value == "" {
+			return nil
+		}
+		flag, err := toBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid %s cell %q", attr, value)
+		}
+		if attr == attributeTemplate {
+			col.Template = flag
+		} else {
+			col.Required = flag
+		}
+	case attributeDefault:
+		if value != "" {
+			col.Default = value
+		}
+	case attributeUnit:
+		col.Unit = value
+	case attributeScale:
+		if value == "" {
+			return nil
+		}
+		scale, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid %s cell %q", attr, value)
+		}
+		col.Scale = scale
+	case attributePath:
+		col.Path = value
+	case attributeMissing:
+		policy, err := parseMissingPolicy(value)
+		if err != nil {
+			return err
+		}
+		col.MissingPolicy = policy
+	case attributeCurrency:
+		col.Currency = value
+	case attributeCurrencyColumn:
+		col.CurrencyColumn = value
+	}
+	return nil
+}
+
+// withDeclaredPolicies appends the policies declared by a source document after the caller's options
+// so the document always wins, without mutating the caller's slice.
+func withDeclaredPolicies(opts []Option, mp MatchPolicy, nmp NoMatchPolicy) []Option {
+	out := make([]Option, 0, len(opts)+2)
+	out = append(out, opts...)
+	return append(out, WithMatchPolicy(mp), WithNoMatchPolicy(nmp))
 }
 
 func parseMatchPolicyString(s string) (MatchPolicy, error) {
@@ -33,6 +138,10 @@ func parseMatchPolicyString(s string) (MatchPolicy, error) {
 		return MatchPolicyAll, nil
 	case "UNIQUE":
 		return MatchPolicyUnique, nil
+	case "COLLECT":
+		return MatchPolicyCollect, nil
+	case "PRIORITY":
+		return MatchPolicyPriority, nil
 	default:
 		return MatchPolicyAll, fmt.Errorf("unknown match policy %q", s)
 	}
@@ -44,6 +153,8 @@ func parseNoMatchPolicyString(s string) (NoMatchPolicy, error) {
 		return NoMatchPolicyReturnDefault, nil
 	case "THROW_ERROR":
 		return NoMatchPolicyThrowError, nil
+	case "RETURN_NIL":
+		return NoMatchPolicyReturnNil, nil
 	default:
 		return NoMatchPolicyThrowError, fmt.Errorf("unknown no-match policy %q", s)
 	}
@@ -75,6 +186,21 @@ func parseColumnTypeString(s string) (ColumnType, error) {
 	}
 }
 
+func parseMissingPolicy(s string) (MissingPolicy, error) {
+	switch normalizeKeyword(s) {
+	case "":
+		return "", nil
+	case "NULL":
+		return MissingPolicyNull, nil
+	case "ERROR":
+		return MissingPolicyError, nil
+	case "DEFAULT":
+		return MissingPolicyDefault, nil
+	default:
+		return "", fmt.Errorf("unknown missing policy %q", s)
+	}
+}
+
 func parseDataTypeString(s string) (DataType, error) {
 	switch normalizeKeyword(s) {
 	case "STRING":
@@ -89,10 +215,14 @@ func parseDataTypeString(s string) (DataType, error) {
 		return DataTypeDate, nil
 	case "DATETIME":
 		return DataTypeDateTime, nil
+	case "DURATION":
+		return DataTypeDuration, nil
 	case "LIST_STRING":
 		return DataTypeListString, nil
 	case "LIST_INTEGER":
 		return DataTypeListInteger, nil
+	case "LIST_DECIMAL":
+		return DataTypeListDecimal, nil
 	default:
 		return "", fmt.Errorf("unknown data type %q", s)
 	}
@@ -122,7 +252,25 @@ func parseJSONOperatorToken(token string) (OperatorType, error) {
 		return OperatorIsNull, nil
 	case "ISNOTNULL", "IS_NOT_NULL":
 		return OperatorIsNotNull, nil
+	case "TEXTEQUAL", "TEXT_EQUAL":
+		return OperatorTextEqual, nil
+	case "BETWEEN":
+		return OperatorBetween, nil
+	case "NOTBETWEEN", "NOT_BETWEEN":
+		return OperatorNotBetween, nil
+	case "DEFAULT":
+		return OperatorDefault, nil
+	case "SETEQ", "SET_EQ", "SETEQUALS", "SET_EQUALS":
+		return OperatorSetEquals, nil
+	case "MULTISETCONTAINEDIN", "MULTISET_CONTAINED_IN":
+		return OperatorMultisetContained, nil
 	default:
+		if op, ok := parseAggregateToken(token); ok {
+			return op, nil
+		}
+		if op, ok := lookupOperatorToken(token); ok {
+			return op, nil
+		}
 		return "", fmt.Errorf("unknown operator %q", token)
 	}
 }
@@ -145,17 +293,17 @@ func parseOperatorToken(token string) (OperatorType, error) {
 	}
 
 	switch normalizeKeyword(tok) {
-	case "GT_EQ":
+	case "GT_EQ", "GREATER_THAN_OR_EQUAL", "GREATER_THAN_OR_EQUAL_TO":
 		return OperatorGreaterOrEqual, nil
-	case "GT":
+	case "GT", "GREATER_THAN":
 		return OperatorGreater, nil
-	case "EQ":
+	case "EQ", "EQUAL", "EQUALS", "EQUAL_TO":
 		return OperatorEqual, nil
-	case "LT":
+	case "LT", "LESS_THAN":
 		return OperatorLess, nil
-	case "LT_EQ":
+	case "LT_EQ", "LESS_THAN_OR_EQUAL", "LESS_THAN_OR_EQUAL_TO":
 		return OperatorLessOrEqual, nil
-	case "NOT_EQ":
+	case "NOT_EQ", "NOT_EQUAL", "NOT_EQUAL_TO":
 		return OperatorNotEqual, nil
 	case "IN":
 		return OperatorIn, nil
@@ -173,14 +321,203 @@ func parseOperatorToken(token string) (OperatorType, error) {
 		return OperatorContainsAll, nil
 	case "NOT_CONTAINS_ALL":
 		return OperatorNotContainsAll, nil
+	case "SET_EQ", "SET_EQUALS":
+		return OperatorSetEquals, nil
+	case "MULTISET_CONTAINED_IN":
+		return OperatorMultisetContained, nil
 	case "ALL_EQUAL":
 		return OperatorAllEqual, nil
+	case "MATCHES_REGEX":
+		return OperatorMatchesRegex, nil
+	case "IS_NULL":
+		return OperatorIsNull, nil
+	case "IS_NOT_NULL":
+		return OperatorIsNotNull, nil
+	case "TEXT_EQ":
+		return OperatorTextEqual, nil
+	case "BETWEEN":
+		return OperatorBetween, nil
+	case "NOT_BETWEEN":
+		return OperatorNotBetween, nil
+	case "DEFAULT":
+		return OperatorDefault, nil
 	default:
+		if op, ok := parseAggregateToken(tok); ok {
+			return op, nil
+		}
+		if op, ok := lookupOperatorToken(tok); ok {
+			return op, nil
+		}
 		return "", fmt.Errorf("unknown operator %q", token)
 	}
 }
 
-func splitAndDedupeList(value string) ([]string, error) {
+// fillCommentsFromMetadata copies the value of a "description" or "comments" metadata column into
+// row.Comments when the source left the row's own description empty. Every loader applies it to
+// rules and the default row alike, so MatchedRow.Comments does not depend on the file format.
+func fillCommentsFromMetadata(row *Row, outputCols []Column) {
+	if row.Comments != "" {
+		return
+	}
+	for _, column := range outputCols {
+		if column.Type != ColumnTypeMetadata {
+			continue
+		}
+		if !strings.EqualFold(column.Name, "description") && !strings.EqualFold(column.Name, "comments") {
+			continue
+		}
+		for _, cell := range row.ReturnCells {
+			if cell.Column != column.Name || cell.Value == nil {
+				continue
+			}
+			if text := strings.TrimSpace(fmt.Sprint(cell.Value)); text != "" {
+				row.Comments = text
+				return
+			}
+		}
+	}
+}
+
+// fillMetadataMap copies the non-blank cells of METADATA columns without a reserved meaning into
+// row.Metadata, keyed by column name.
+func fillMetadataMap(row *Row, outputCols []Column) {
+	for _, column := range outputCols {
+		if column.Type != ColumnTypeMetadata || isReservedMetadataColumn(column.Name) {
+			continue
+		}
+		for _, cell := range row.ReturnCells {
+			if cell.Column != column.Name || cell.Value == nil {
+				continue
+			}
+			text := strings.TrimSpace(fmt.Sprint(cell.Value))
+			if text == "" {
+				continue
+			}
+			if row.Metadata == nil {
+				row.Metadata = make(map[string]string)
+			}
+			row.Metadata[column.Name] = text
+		}
+	}
+}
+
+// isReservedMetadataColumn reports whether a METADATA column is read into a Row field of its own.
+func isReservedMetadataColumn(name string) bool {
+	for _, reserved := range []string{"ruleId", "description", "comments", "priority", "enabled", "validFrom", "validUntil", "group"} {
+		if strings.EqualFold(name, reserved) {
+			return true
+		}
+	}
+	return false
+}
+
+// fillRowFromMetadata parses the "priority" (integer), "enabled" (boolean), "validFrom"/"validUntil"
+// (date or timestamp) and "group" metadata columns into row.Priority, row.Disabled, row.ValidFrom,
+// row.ValidUntil and row.Group. Blank cells keep the defaults of priority zero, enabled, always in
+// effect and no group.
+func fillRowFromMetadata(row *Row, outputCols []Column) error {
+	for _, column := range outputCols {
+		if column.Type != ColumnTypeMetadata {
+			continue
+		}
+		for _, cell := range row.ReturnCells {
+			if cell.Column != column.Name || cell.Value == nil {
+				continue
+			}
+			if err := applyRowMetadata(row, column.Name, cell.Value); err != nil {
+				return fmt.Errorf("column %s: %w", column.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+func applyRowMetadata(row *Row, column string, value any) error {
+	switch {
+	case strings.EqualFold(column, "priority"):
+		n, err := toInt64(value)
+		if err != nil {
+			return err
+		}
+		row.Priority = int(n)
+	case strings.EqualFold(column, "enabled"):
+		enabled, err := toBool(value)
+		if err != nil {
+			return err
+		}
+		row.Disabled = !enabled
+	case strings.EqualFold(column, "validFrom"), strings.EqualFold(column, "validUntil"):
+		until := strings.EqualFold(column, "validUntil")
+		ts, err := parseValidityBound(value, until)
+		if err != nil {
+			return err
+		}
+		if until {
+			row.ValidUntil = ts
+		} else {
+			row.ValidFrom = ts
+		}
+	case strings.EqualFold(column, "group"):
+		row.Group = strings.TrimSpace(fmt.Sprint(value))
+	}
+	return nil
+}
+
+// anyMarker is written by the exporters for unconstrained condition cells when explicit ANY is required.
+const anyMarker = "*"
+
+// isAnyMarker reports whether a tabular condition cell explicitly matches any value.
+func isAnyMarker(s string) bool {
+	return s == anyMarker || strings.EqualFold(s, "ANY")
+}
+
+// checkConditionCell reports whether a trimmed tabular condition cell carries a condition. Blank cells
+// and the ANY markers match any value; blank cells are an error when requireAny is set.
+func checkConditionCell(trimmed string, column Column, requireAny bool) (bool, error) {
+	if isAnyMarker(trimmed) {
+		return false, nil
+	}
+	if trimmed == "" {
+		if requireAny {
+			return false, fmt.Errorf("column %s: blank condition cell, write ANY or %s to match any value", column.Name, anyMarker)
+		}
+		return false, nil
+	}
+	return true, nil
+}
+
+// splitList splits a comma separated value, keeping order and duplicates.
+func splitList(value string) []string {
+	if strings.TrimSpace(value) == "" {
+		return []string{}
+	}
+	parts := strings.Split(value, ",")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+	return parts
+}
+
+// checkDefaultRuleIDPolicy rejects a default rule designated by ID under a no-match policy that takes
+// no default row. field names the setting as the source spells it.
+func checkDefaultRuleIDPolicy(field string, nmp NoMatchPolicy) error {
+	if nmp == NoMatchPolicyReturnNil {
+		return fmt.Errorf("%s requires the RETURN_DEFAULT or THROW_ERROR no-match policy, got %s", field, nmp)
+	}
+	return nil
+}
+
+// checkDesignatedDefault reports whether a rule named by its ID as the default row can serve as one.
+func checkDesignatedDefault(row Row) error {
+	if len(row.EvalCells) > 0 {
+		return fmt.Errorf("rule %q is designated as the default row but has conditions", row.RuleID)
+	}
+	return nil
+}
+
+// splitOperandList splits a comma-separated operand, dropping blank parts and, when dedupe is set,
+// repeated ones.
+func splitOperandList(value string, dedupe bool) ([]string, error) {
 	parts := strings.Split(value, ",")
 	seen := make(map[string]struct{}, len(parts))
 	result := make([]string, 0, len(parts))
@@ -189,7 +526,7 @@ func splitAndDedupeList(value string) ([]string, error) {
 		if trimmed == "" {
 			continue
 		}
-		if _, ok := seen[trimmed]; ok {
+		if _, ok := seen[trimmed]; ok && dedupe {
 			continue
 		}
 		seen[trimmed] = struct{}{}
@@ -200,3 +537,70 @@ func splitAndDedupeList(value string) ([]string, error) {
 	}
 	return result, nil
 }
+
+// formatConditionString renders a sanitized evaluation cell in the textual "OP operand" form
+// understood by parseConditionString. It is shared by the tabular exporters.
+func formatConditionString(cell EvalCell) (string, error) {
+	if requiresCollectionValue(cell.Operator) {
+		values, ok := cell.Value.([]any)
+		if !ok {
+			return "", fmt.Errorf("operator %s expects slice value, got %T", cell.Operator, cell.Value)
+		}
+		parts := make([]string, len(values))
+		for i, v := range values {
+			parts[i] = formatValue(elementDataType(cell.dataType), v)
+		}
+		return string(cell.Operator) + " " + strings.Join(parts, ","), nil
+	}
+	switch cell.Operator {
+	case OperatorMatchesRegex:
+		re, ok := cell.Value.(*regexp.Regexp)
+		if !ok {
+			return "", fmt.Errorf("operator MATCHES_REGEX expects compiled regexp, got %T", cell.Value)
+		}
+		return string(cell.Operator) + " " + re.String(), nil
+	case OperatorIsNull, OperatorIsNotNull, OperatorDefault:
+		return string(cell.Operator), nil
+	case OperatorBetween, OperatorNotBetween:
+		if bounds, ok := cell.Value.([]any); ok && len(bounds) == 2 && cell.Interval != "" {
+			elemType := elementDataType(cell.dataType)
+			return fmt.Sprintf("%s %c%s..%s%c", cell.Operator, cell.Interval[0], formatValue(elemType, bounds[0]), formatValue(elemType, bounds[1]), cell.Interval[1]), nil
+		}
+	}
+	operand := formatValue(cell.dataType, cell.Value)
+	if operand == "" {
+		return "", fmt.Errorf("operator %s has no textual operand", cell.Operator)
+	}
+	return string(cell.Operator) + " " + operand, nil
+}
+
+// formatValue renders a sanitized value in the textual form accepted by coercePrimitive.
+// Lists are comma separated and nil renders as an empty string.
+func formatValue(dt DataType, v any) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case int64:
+		return strconv.FormatInt(val, 10)
+	case bool:
+		return strconv.FormatBool(val)
+	case *big.Float:
+		return val.Text('g', -1)
+	case time.Time:
+		if dt == DataTypeDate {
+			return val.Format("2006-01-02")
+		}
+		return val.Format(time.RFC3339Nano)
+	case []any:
+		elemType := elementDataType(dt)
+		parts := make([]string, len(val))
+		for i, item := range val {
+			parts[i] = formatValue(elemType, item)
+		}
+		return strings.Join(parts, ",")
+	default:
+		return fmt.Sprint(val)
+	}
+}
@@ -15,9 +15,17 @@
 package decisiontable
 
 import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
 	"math/big"
+	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/xuri/excelize/v2"
 )
@@ -153,6 +161,290 @@ func TestLoadJSONNoMatchThrowsError(t *testing.T) {
 	}
 }
 
+func TestLoadJSONRuleIDGenerator(t *testing.T) {
+	const doc = `
+{
+  "decisionTable": {
+    "name": "generated",
+    "policies": {"matchPolicy": "ALL", "noMatchPolicy": "THROW_ERROR"},
+    "columns": [
+      {"name": "score", "type": "CONDITION", "dataType": "INTEGER"},
+      {"name": "segment", "type": "CONCLUSION", "dataType": "STRING"}
+    ],
+    "rules": [
+      {"when": [{"operator": "greaterThan", "value": 50}], "then": ["high"]},
+      {"id": "explicit", "when": [{"operator": "greaterThan", "value": 10}], "then": ["mid"]},
+      {"when": [{"operator": "greaterThan", "value": 0}], "then": ["low"]}
+    ],
+    "defaultRule": {"then": ["none"]}
+  }
+}`
+
+	gen := WithRuleIDGenerator(func(n int) string { return fmt.Sprintf("rule-%04d", n) })
+	dt, err := LoadJSON([]byte(doc), "generated.json", gen)
+	if err != nil {
+		t.Fatalf("load json: %v", err)
+	}
+	var ids []string
+	for _, row := range dt.Rows() {
+		ids = append(ids, row.RuleID)
+	}
+	want := []string{"rule-0001", "explicit", "rule-0003"}
+	if !reflect.DeepEqual(ids, want) {
+		t.Fatalf("expected ids %v, got %v", want, ids)
+	}
+	rows, err := dt.Evaluate(map[string]any{"score": -1}, nil)
+	if err != nil {
+		t.Fatalf("evaluate returned error: %v", err)
+	}
+	if len(rows) != 1 || rows[0].RuleID != "rule-0004" {
+		t.Fatalf("expected generated default id, got %#v", rows)
+	}
+
+	collide := WithRuleIDGenerator(func(int) string { return "explicit" })
+	if _, err := LoadJSON([]byte(doc), "generated.json", collide); err == nil || !strings.Contains(err.Error(), "duplicate rule id") {
+		t.Fatalf("expected duplicate rule id error, got %v", err)
+	}
+}
+
+func TestLoadJSONRejectsEmptyCollection(t *testing.T) {
+	const doc = `
+{
+  "decisionTable": {
+    "name": "emptyIn",
+    "policies": {"matchPolicy": "FIRST", "noMatchPolicy": "THROW_ERROR"},
+    "columns": [
+      {"name": "country", "type": "CONDITION", "dataType": "STRING"},
+      {"name": "region", "type": "CONCLUSION", "dataType": "STRING"}
+    ],
+    "rules": [
+      {"id": "empty", "when": [{"operator": "in", "value": []}], "then": ["none"]}
+    ]
+  }
+}`
+
+	_, err := LoadJSON([]byte(doc), "empty.json")
+	if err == nil || !strings.Contains(err.Error(), "column country") {
+		t.Fatalf("expected empty collection error naming column, got %v", err)
+	}
+
+	dt, err := LoadJSON([]byte(doc), "empty.json", WithAllowEmptyCollections())
+	if err != nil {
+		t.Fatalf("expected empty collection to load when allowed: %v", err)
+	}
+	if _, err := dt.Evaluate(map[string]any{"country": "US"}, nil); err == nil {
+		t.Fatalf("expected empty IN never to match")
+	}
+}
+
+func TestLoadExcelRejectsEmptyCollection(t *testing.T) {
+	path := buildExcelFixture(t, func(f *excelize.File) {
+		if err := f.SetCellValue(excelSheetName, "C10", "IN  , ,"); err != nil {
+			t.Fatalf("set cell: %v", err)
+		}
+	})
+	if _, err := LoadExcelFile(path); err == nil || !strings.Contains(err.Error(), "column country") {
+		t.Fatalf("expected empty collection error naming column, got %v", err)
+	}
+}
+
+func TestParseConditionStringListAndTokenForms(t *testing.T) {
+	cases := []struct {
+		text string
+		dt   DataType
+		op   OperatorType
+		want any
+	}{
+		{"EQ tv,internet,tv", DataTypeListString, OperatorEqual, []string{"tv", "internet", "tv"}},
+		{"NOT_EQ 1, 2", DataTypeListInteger, OperatorNotEqual, []string{"1", "2"}},
+		{"EQ a,b", DataTypeString, OperatorEqual, "a,b"},
+		{"MATCHES_REGEX ^A.*", DataTypeString, OperatorMatchesRegex, "^A.*"},
+		{"IS_NULL true", DataTypeString, OperatorIsNull, "true"},
+		{"IS_NOT_NULL true", DataTypeInteger, OperatorIsNotNull, "true"},
+	}
+	for _, tc := range cases {
+		op, value, err := parseConditionString(tc.text, tc.dt)
+		if err != nil {
+			t.Fatalf("parse %q: %v", tc.text, err)
+		}
+		if op != tc.op || !reflect.DeepEqual(value, tc.want) {
+			t.Fatalf("parse %q: expected %s %#v, got %s %#v", tc.text, tc.op, tc.want, op, value)
+		}
+	}
+}
+
+func TestLoadJSONWithoutConditions(t *testing.T) {
+	const doc = `
+{
+  "decisionTable": {
+    "name": "settings",
+    "policies": {"matchPolicy": "FIRST", "noMatchPolicy": "RETURN_DEFAULT"},
+    "columns": [
+      {"name": "timeout", "type": "CONCLUSION", "dataType": "INTEGER"},
+      {"name": "owner", "type": "METADATA", "dataType": "STRING"}
+    ],
+    "rules": [
+      {"id": "only", "then": [30, "platform"]}
+    ],
+    "defaultRule": {"then": [60, "fallback"]}
+  }
+}`
+
+	if _, err := LoadJSON([]byte(doc), "settings.json"); err == nil {
+		t.Fatalf("expected error without condition columns by default")
+	}
+	dt, err := LoadJSON([]byte(doc), "settings.json", WithAllowNoConditions())
+	if err != nil {
+		t.Fatalf("load json: %v", err)
+	}
+	rows, err := dt.Evaluate(nil, nil)
+	if err != nil {
+		t.Fatalf("evaluate returned error: %v", err)
+	}
+	if len(rows) != 1 || rows[0].RuleID != "only" || rows[0].Values["timeout"] != int64(30) {
+		t.Fatalf("unexpected rows %#v", rows)
+	}
+}
+
+func TestLoadExcelWithoutConditions(t *testing.T) {
+	path := buildExcelFixture(t, func(f *excelize.File) {
+		for cell, value := range map[string]string{"B2": "FIRST", "B7": "Metadata", "B8": "String", "C7": "Metadata"} {
+			if err := f.SetCellValue(excelSheetName, cell, value); err != nil {
+				t.Fatalf("set cell %s: %v", cell, err)
+			}
+		}
+	})
+	if _, err := LoadExcelFile(path); err == nil || !strings.Contains(err.Error(), "at least one condition column") {
+		t.Fatalf("expected error without condition columns by default, got %v", err)
+	}
+	dt, err := LoadExcelFile(path, WithAllowNoConditions())
+	if err != nil {
+		t.Fatalf("load excel: %v", err)
+	}
+	if len(dt.ConditionColumns()) != 0 {
+		t.Fatalf("expected no condition columns, got %v", dt.ConditionColumns())
+	}
+	rows, err := dt.Evaluate(nil, nil)
+	if err != nil {
+		t.Fatalf("evaluate returned error: %v", err)
+	}
+	if len(rows) != 1 || rows[0].RuleID != "row1" || rows[0].Values["tier"] != "standard" {
+		t.Fatalf("unexpected rows %#v", rows)
+	}
+}
+
+func TestLoadJSONNullChecks(t *testing.T) {
+	const doc = `
+{
+  "decisionTable": {
+    "name": "nulls",
+    "policies": {"matchPolicy": "FIRST", "noMatchPolicy": "THROW_ERROR"},
+    "columns": [
+      {"name": "age", "type": "CONDITION", "dataType": "INTEGER"},
+      {"name": "country", "type": "CONDITION", "dataType": "STRING"},
+      {"name": "tier", "type": "CONCLUSION", "dataType": "STRING"}
+    ],
+    "rules": [
+      {"id": "missing-country", "when": [{}, {"operator": "isNull"}], "then": ["unknown"]},
+      {"id": "has-country", "when": [{}, {"operator": "isNotNull"}], "then": ["known"]}
+    ]
+  }
+}`
+
+	dt, err := LoadJSON([]byte(doc), "nulls.json")
+	if err != nil {
+		t.Fatalf("load json: %v", err)
+	}
+	assertNullCheckRules(t, dt)
+}
+
+func TestLoadExcelNullChecks(t *testing.T) {
+	path := buildExcelFixture(t, func(f *excelize.File) {
+		cells := map[string]string{
+			"B9": "", "C9": "IS_NULL", "D9": "unknown", "E9": "missing-country",
+			"B10": "", "C10": "IS_NOT_NULL", "D10": "known", "E10": "has-country",
+		}
+		for cell, value := range cells {
+			if err := f.SetCellValue(excelSheetName, cell, value); err != nil {
+				t.Fatalf("set cell %s: %v", cell, err)
+			}
+		}
+		if err := f.SetCellValue(excelSheetName, "B2", "FIRST"); err != nil {
+			t.Fatalf("set cell: %v", err)
+		}
+	})
+	dt, err := LoadExcelFile(path)
+	if err != nil {
+		t.Fatalf("load excel: %v", err)
+	}
+	assertNullCheckRules(t, dt)
+}
+
+func assertNullCheckRules(t *testing.T, dt *DecisionTable) {
+	t.Helper()
+	rows, err := dt.Evaluate(map[string]any{"age": 30}, nil)
+	if err != nil {
+		t.Fatalf("evaluate returned error: %v", err)
+	}
+	if len(rows) != 1 || rows[0].RuleID != "missing-country" {
+		t.Fatalf("expected missing-country rule, got %#v", rows)
+	}
+	rows, err = dt.Evaluate(map[string]any{"age": 30, "country": "US"}, nil)
+	if err != nil {
+		t.Fatalf("evaluate returned error: %v", err)
+	}
+	if len(rows) != 1 || rows[0].RuleID != "has-country" {
+		t.Fatalf("expected has-country rule, got %#v", rows)
+	}
+}
+
+func TestLoadExcelRuleIDGenerator(t *testing.T) {
+	path := buildExcelFixture(t, func(f *excelize.File) {
+		for _, cell := range []string{"E9", "E11"} {
+			if err := f.SetCellValue(excelSheetName, cell, ""); err != nil {
+				t.Fatalf("set cell %s: %v", cell, err)
+			}
+		}
+	})
+	gen := WithRuleIDGenerator(func(n int) string { return fmt.Sprintf("rule-%04d", n) })
+	dt, err := LoadExcelFile(path, gen)
+	if err != nil {
+		t.Fatalf("load excel: %v", err)
+	}
+	ids := func(dt *DecisionTable) []string {
+		var ids []string
+		for _, row := range dt.Rows() {
+			ids = append(ids, row.RuleID)
+		}
+		return ids
+	}
+	want := []string{"rule-0001", "row2"}
+	if got := ids(dt); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected ids %v, got %v", want, got)
+	}
+
+	// the ruleId cells stay blank in the written workbook, so loading it back with the same generator
+	// yields the same IDs
+	var buf bytes.Buffer
+	if err := WriteExcel(dt, &buf); err != nil {
+		t.Fatalf("write excel: %v", err)
+	}
+	reloaded, err := LoadExcel("generated.xlsx", bytes.NewReader(buf.Bytes()), gen)
+	if err != nil {
+		t.Fatalf("load generated workbook: %v", err)
+	}
+	if got := ids(reloaded); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected reloaded ids %v, got %v", want, got)
+	}
+	rows, err := reloaded.Evaluate(map[string]any{"age": 5, "country": "FR"}, nil)
+	if err != nil {
+		t.Fatalf("evaluate returned error: %v", err)
+	}
+	if len(rows) != 1 || !rows[0].IsDefault || rows[0].RuleID != "rule-0003" {
+		t.Fatalf("expected the generated default id to survive, got %#v", rows)
+	}
+}
+
 func TestLoadExcel(t *testing.T) {
 	path := buildExcelFixture(t)
 	dt, err := LoadExcelFile(path)
@@ -183,65 +475,474 @@ func TestLoadExcel(t *testing.T) {
 	}
 }
 
-func buildExcelFixture(t *testing.T) string {
-	t.Helper()
-	f := excelize.NewFile()
-	sheet := f.GetSheetName(0)
-	f.SetSheetName(sheet, excelSheetName)
+func TestLoadExcelBlankOutputIsNull(t *testing.T) {
+	path := buildExcelFixture(t, func(f *excelize.File) {
+		cells := map[string]string{"D6": "rate", "D8": "Decimal", "D9": "1.5", "D10": "", "D11": "0"}
+		for cell, value := range cells {
+			if err := f.SetCellValue(excelSheetName, cell, value); err != nil {
+				t.Fatalf("set cell %s: %v", cell, err)
+			}
+		}
+	})
+	dt, err := LoadExcelFile(path)
+	if err != nil {
+		t.Fatalf("load excel: %v", err)
+	}
+	rows, err := dt.Evaluate(map[string]any{"age": 30, "country": "CA"}, nil)
+	if err != nil {
+		t.Fatalf("evaluate returned error: %v", err)
+	}
+	if len(rows) != 1 || rows[0].RuleID != "row2" {
+		t.Fatalf("expected row2 match, got %#v", rows)
+	}
+	if value, ok := rows[0].Values["rate"]; !ok || value != nil {
+		t.Fatalf("expected blank decimal output to be nil, got %#v", rows[0].Values["rate"])
+	}
+	rows, err = dt.Evaluate(map[string]any{"age": 30, "country": "US"}, nil)
+	if err != nil {
+		t.Fatalf("evaluate returned error: %v", err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("expected row1 match, got %#v", rows)
+	}
+	if rate, ok := rows[0].Values["rate"].(*big.Float); !ok || rate.Cmp(big.NewFloat(1.5)) != 0 {
+		t.Fatalf("expected decimal output 1.5, got %#v", rows[0].Values["rate"])
+	}
+}
 
-	set := func(cell, value string) {
-		if err := f.SetCellValue(excelSheetName, cell, value); err != nil {
-			t.Fatalf("set cell %s: %v", cell, err)
+func TestLoadExcelLabelRow(t *testing.T) {
+	path := buildExcelFixture(t, func(f *excelize.File) {
+		if err := f.InsertRows(excelSheetName, 9, 1); err != nil {
+			t.Fatalf("insert row: %v", err)
 		}
+		cells := map[string]string{"A9": "Label", "B9": "Age", "C9": "Country", "D9": "Pricing Tier"}
+		for cell, value := range cells {
+			if err := f.SetCellValue(excelSheetName, cell, value); err != nil {
+				t.Fatalf("set cell %s: %v", cell, err)
+			}
+		}
+	})
+	dt, err := LoadExcelFile(path)
+	if err != nil {
+		t.Fatalf("load excel: %v", err)
+	}
+	if got := dt.ConditionColumns()[1].Label; got != "Country" {
+		t.Fatalf("expected Country label, got %q", got)
+	}
+	outputs := dt.OutputColumns()
+	if outputs[0].Label != "Pricing Tier" || outputs[1].Label != "" {
+		t.Fatalf("unexpected output labels %#v", outputs)
 	}
+	if dt.RowCount() != 2 {
+		t.Fatalf("expected label row not to be loaded as a rule, got %d rows", dt.RowCount())
+	}
+}
 
-	set("A1", "Version")
-	set("B1", "1.0")
-	set("A2", "Match Policy")
-	set("B2", "ALL")
-	set("A3", "No Match Policy")
-	set("B3", "RETURN_DEFAULT")
+func TestLoadExcelMinMaxRows(t *testing.T) {
+	path := buildExcelFixture(t, func(f *excelize.File) {
+		if err := f.InsertRows(excelSheetName, 9, 2); err != nil {
+			t.Fatalf("insert rows: %v", err)
+		}
+		cells := map[string]string{"A9": "Max", "B9": "120", "A10": "Min", "B10": "0"}
+		for cell, value := range cells {
+			if err := f.SetCellValue(excelSheetName, cell, value); err != nil {
+				t.Fatalf("set cell %s: %v", cell, err)
+			}
+		}
+	})
+	dt, err := LoadExcelFile(path)
+	if err != nil {
+		t.Fatalf("load excel: %v", err)
+	}
+	age := dt.ConditionColumns()[0]
+	if age.Min != "0" || age.Max != "120" {
+		t.Fatalf("unexpected bounds %#v %#v", age.Min, age.Max)
+	}
+	if dt.ConditionColumns()[1].Min != nil || dt.RowCount() != 2 {
+		t.Fatalf("attribute rows leaked into columns or rules: %#v, %d rows", dt.ConditionColumns()[1], dt.RowCount())
+	}
+	if _, err := dt.Evaluate(map[string]any{"age": 130, "country": "US"}, nil); err == nil || !strings.Contains(err.Error(), "outside column range [0, 120]") {
+		t.Fatalf("expected out of range input error, got %v", err)
+	}
+}
 
-	set("B5", "First Column")
-	set("F5", "Last Column")
+func TestLoadExcelDefaultMarker(t *testing.T) {
+	defaultCountry := func(f *excelize.File) {
+		if err := f.InsertRows(excelSheetName, 9, 1); err != nil {
+			t.Fatalf("insert rows: %v", err)
+		}
+		cells := map[string]string{"A9": "Default", "C9": "US", "C11": "DEFAULT"}
+		for cell, value := range cells {
+			if err := f.SetCellValue(excelSheetName, cell, value); err != nil {
+				t.Fatalf("set cell %s: %v", cell, err)
+			}
+		}
+	}
+	dt, err := LoadExcelFile(buildExcelFixture(t, defaultCountry))
+	if err != nil {
+		t.Fatalf("load excel: %v", err)
+	}
+	if got := dt.ConditionColumns()[1].Default; got != "US" {
+		t.Fatalf("unexpected country default %#v", got)
+	}
+	results, err := dt.Evaluate(map[string]any{"age": 30, "country": "US"}, nil)
+	if err != nil || len(results) != 2 || results[1].RuleID != "row2" {
+		t.Fatalf("expected row1 and row2 to match the default country, got %+v (%v)", results, err)
+	}
+	results, err = dt.Evaluate(map[string]any{"age": 30, "country": "CA"}, nil)
+	if err != nil || len(results) != 1 || !results[0].IsDefault {
+		t.Fatalf("expected only the default row for another country, got %+v (%v)", results, err)
+	}
+	// a null input is not the default; IS_NULL is the way to match it
+	results, err = dt.Evaluate(map[string]any{"age": 30}, nil)
+	if err != nil || len(results) != 1 || !results[0].IsDefault {
+		t.Fatalf("expected a missing country not to match DEFAULT, got %+v (%v)", results, err)
+	}
 
-	// column definitions
-	headers := []struct {
-		col string
-	}{
-		{"B"},
-		{"C"},
-		{"D"},
-		{"E"},
-		{"F"},
+	var buf bytes.Buffer
+	if err := WriteCSV(dt, &buf); err != nil {
+		t.Fatalf("write csv: %v", err)
 	}
-	names := []string{"age", "country", "tier", "ruleId", "comments"}
-	types := []string{"Condition", "Condition", "Conclusion", "Metadata", "Metadata"}
-	dataTypes := []string{"Integer", "String", "String", "String", "String"}
-	for idx, header := range headers {
-		set(header.col+"6", names[idx])
-		set(header.col+"7", types[idx])
-		set(header.col+"8", dataTypes[idx])
+	reloaded, err := LoadCSV("reloaded", &buf)
+	if err != nil {
+		t.Fatalf("reload csv: %v", err)
+	}
+	if got := reloaded.ConditionColumns()[1].Default; got != "US" {
+		t.Fatalf("csv round trip lost the default, got %#v", got)
+	}
+	if row := reloaded.Rows()[1]; row.EvalCells[1].Operator != OperatorDefault {
+		t.Fatalf("csv round trip lost the DEFAULT cell: %+v", row.EvalCells)
 	}
 
-	// rows
-	set("A9", "First Row")
-	set("B9", ">= 18")
-	set("C9", "= US")
-	set("D9", "standard")
-	set("E9", "row1")
-	set("F9", "adult rule")
+	// a stored DEFAULT cell can be added again as it is returned
+	row, ok := dt.GetRow("row2")
+	if !ok {
+		t.Fatalf("row2 not found")
+	}
+	if err := dt.UpdateRow("row2", row); err != nil {
+		t.Fatalf("update row with a DEFAULT cell: %v", err)
+	}
+	data, err := dt.MarshalBinary()
+	if err != nil {
+		t.Fatalf("marshal binary: %v", err)
+	}
+	decoded, err := LoadBinary(data)
+	if err != nil {
+		t.Fatalf("load binary with a DEFAULT cell: %v", err)
+	}
+	results, err = decoded.Evaluate(map[string]any{"age": 30, "country": "US"}, nil)
+	if err != nil || len(results) != 2 || results[1].RuleID != "row2" {
+		t.Fatalf("expected the binary round trip to keep matching the default country, got %+v (%v)", results, err)
+	}
 
-	set("B10", ">= 21")
-	set("C10", "IN CA,MX")
-	set("D10", "neighbor")
-	set("E10", "row2")
-	set("F10", "neighbor rule")
+	_, err = LoadExcelFile(buildExcelFixture(t, defaultCountry, func(f *excelize.File) {
+		if err := f.SetCellValue(excelSheetName, "B10", "DEFAULT"); err != nil {
+			t.Fatalf("set cell: %v", err)
+		}
+	}))
+	if err == nil || !strings.Contains(err.Error(), "operator DEFAULT requires a column default") {
+		t.Fatalf("expected missing default error, got %v", err)
+	}
+}
 
-	set("A11", "Default Row")
-	set("D11", "minor")
-	set("E11", "default")
-	set("F11", "default row")
+func TestRequiredColumnsRejectUnscopedRules(t *testing.T) {
+	const doc = `
+{
+  "decisionTable": {
+    "name": "tenants",
+    "policies": {"matchPolicy": "FIRST", "noMatchPolicy": "RETURN_DEFAULT"},
+    "columns": [
+      {"name": "tenantId", "type": "CONDITION", "dataType": "STRING", "required": true},
+      {"name": "amount", "type": "CONDITION", "dataType": "INTEGER"},
+      {"name": "action", "type": "CONCLUSION", "dataType": "STRING"}
+    ],
+    "rules": [
+      {"id": "acme-large", "when": [{"operator": "equal", "value": "acme"}, {"operator": "greaterThan", "value": 100}], "then": ["review"]},
+      {"id": "all-large", "when": [null, {"operator": "greaterThan", "value": 1000}], "then": ["block"]}
+    ],
+    "defaultRule": {"then": ["approve"]}
+  }
+}`
+	_, err := LoadJSON([]byte(doc), "tenants.json")
+	var loadErr *LoadError
+	if !errors.As(err, &loadErr) || loadErr.RuleID != "all-large" || !strings.Contains(err.Error(), "required column tenantId has no condition") {
+		t.Fatalf("expected the unscoped rule to be rejected, got %v", err)
+	}
+	scoped := strings.Replace(doc, `[null, {"operator": "greaterThan", "value": 1000}]`, `[{"operator": "notEqual", "value": "acme"}, {"operator": "greaterThan", "value": 1000}]`, 1)
+	dt, err := LoadJSON([]byte(scoped), "tenants.json")
+	if err != nil {
+		t.Fatalf("expected scoped rules to load, got %v", err)
+	}
+	if !dt.ConditionColumns()[0].Required {
+		t.Fatalf("expected tenantId to be required")
+	}
+
+	_, err = LoadExcelFile(buildExcelFixture(t, func(f *excelize.File) {
+		if err := f.InsertRows(excelSheetName, 9, 1); err != nil {
+			t.Fatalf("insert rows: %v", err)
+		}
+		cells := map[string]string{"A9": "Required", "C9": "true", "C11": ""}
+		for cell, value := range cells {
+			if err := f.SetCellValue(excelSheetName, cell, value); err != nil {
+				t.Fatalf("set cell %s: %v", cell, err)
+			}
+		}
+	}))
+	if !errors.As(err, &loadErr) || loadErr.Row != 11 || !strings.Contains(err.Error(), "required column country has no condition") {
+		t.Fatalf("expected the rule without a country to be rejected, got %v", err)
+	}
+}
+
+func TestLoadJSONColumnMinMax(t *testing.T) {
+	const doc = `
+{
+  "decisionTable": {
+    "name": "bounds",
+    "policies": {"matchPolicy": "FIRST", "noMatchPolicy": "THROW_ERROR"},
+    "columns": [
+      {"name": "score", "type": "CONDITION", "dataType": "INTEGER", "min": 300, "max": 850},
+      {"name": "rate", "type": "CONCLUSION", "dataType": "DECIMAL", "min": 0, "max": "0.25"}
+    ],
+    "rules": [
+      {"id": "high", "when": [{"operator": "greaterThan", "value": 700}], "then": [0.05]}
+    ]
+  }
+}`
+	dt, err := LoadJSON([]byte(doc), "bounds.json")
+	if err != nil {
+		t.Fatalf("load json: %v", err)
+	}
+	if _, err := dt.Evaluate(map[string]any{"score": 900}, nil); err == nil {
+		t.Fatalf("expected input above max to fail")
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCSV(dt, &buf); err != nil {
+		t.Fatalf("write csv: %v", err)
+	}
+	reloaded, err := LoadCSV("bounds.csv", &buf)
+	if err != nil {
+		t.Fatalf("load csv: %v", err)
+	}
+	if col := reloaded.OutputColumns()[0]; col.Min != "0" || col.Max != "0.25" {
+		t.Fatalf("bounds lost in csv round trip %#v", col)
+	}
+
+	bad := strings.Replace(doc, `"value": 700`, `"value": 900`, 1)
+	if _, err := LoadJSON([]byte(bad), "bounds.json"); err == nil || !strings.Contains(err.Error(), "outside column range") {
+		t.Fatalf("expected out of range rule value to fail, got %v", err)
+	}
+}
+
+func TestLoadExcelPriorityColumn(t *testing.T) {
+	path := buildExcelFixture(t, func(f *excelize.File) {
+		cells := map[string]string{
+			"B2": "PRIORITY", "F5": "", "G5": "Last Column",
+			"G6": "priority", "G7": "Metadata", "G8": "Integer",
+			"B10": ">= 18", "C10": "IN US,CA", "G9": "1", "G10": "5",
+		}
+		for cell, value := range cells {
+			if err := f.SetCellValue(excelSheetName, cell, value); err != nil {
+				t.Fatalf("set cell %s: %v", cell, err)
+			}
+		}
+	})
+	dt, err := LoadExcelFile(path)
+	if err != nil {
+		t.Fatalf("load excel: %v", err)
+	}
+	if dt.MatchPolicy() != MatchPolicyPriority {
+		t.Fatalf("expected PRIORITY policy, got %s", dt.MatchPolicy())
+	}
+	rows, err := dt.Evaluate(map[string]any{"age": 30, "country": "US"}, nil)
+	if err != nil {
+		t.Fatalf("evaluate: %v", err)
+	}
+	if len(rows) != 1 || rows[0].RuleID != "row2" {
+		t.Fatalf("expected the higher priority row2 to win, got %#v", rows)
+	}
+}
+
+func TestLoadJSONRulePriority(t *testing.T) {
+	const doc = `
+{
+  "decisionTable": {
+    "name": "priorities",
+    "policies": {"matchPolicy": "priority", "noMatchPolicy": "THROW_ERROR"},
+    "columns": [
+      {"name": "score", "type": "CONDITION", "dataType": "INTEGER"},
+      {"name": "segment", "type": "CONCLUSION", "dataType": "STRING"}
+    ],
+    "rules": [
+      {"id": "any", "when": [{"operator": "greaterThan", "value": 0}], "then": ["basic"]},
+      {"id": "high", "priority": 10, "when": [{"operator": "greaterThan", "value": 50}], "then": ["high"]},
+      {"id": "higher", "priority": 10, "when": [{"operator": "greaterThan", "value": 60}], "then": ["higher"]}
+    ]
+  }
+}`
+	dt, err := LoadJSON([]byte(doc), "priorities.json")
+	if err != nil {
+		t.Fatalf("load json: %v", err)
+	}
+	rows, err := dt.Evaluate(map[string]any{"score": 70}, nil)
+	if err != nil {
+		t.Fatalf("evaluate: %v", err)
+	}
+	if len(rows) != 1 || rows[0].RuleID != "high" {
+		t.Fatalf("expected tie to go to the earlier rule, got %#v", rows)
+	}
+	rows, err = dt.Evaluate(map[string]any{"score": 10}, nil)
+	if err != nil || len(rows) != 1 || rows[0].RuleID != "any" {
+		t.Fatalf("expected the only match to win, got %#v %v", rows, err)
+	}
+}
+
+func TestLoadDisabledRules(t *testing.T) {
+	path := buildExcelFixture(t, func(f *excelize.File) {
+		cells := map[string]string{
+			"B2": "FIRST", "F5": "", "G5": "Last Column",
+			"G6": "enabled", "G7": "Metadata", "G8": "Boolean",
+			"B10": ">= 18", "C10": "= US", "G9": "false",
+		}
+		for cell, value := range cells {
+			if err := f.SetCellValue(excelSheetName, cell, value); err != nil {
+				t.Fatalf("set cell %s: %v", cell, err)
+			}
+		}
+	})
+	dt, err := LoadExcelFile(path)
+	if err != nil {
+		t.Fatalf("load excel: %v", err)
+	}
+	rows, err := dt.Evaluate(map[string]any{"age": 30, "country": "US"}, nil)
+	if err != nil || len(rows) != 1 || rows[0].RuleID != "row2" {
+		t.Fatalf("expected disabled row1 to be skipped, got %#v %v", rows, err)
+	}
+
+	const doc = `
+{
+  "decisionTable": {
+    "name": "toggles",
+    "policies": {"matchPolicy": "FIRST", "noMatchPolicy": "THROW_ERROR"},
+    "columns": [
+      {"name": "score", "type": "CONDITION", "dataType": "INTEGER"},
+      {"name": "segment", "type": "CONCLUSION", "dataType": "STRING"}
+    ],
+    "rules": [
+      {"id": "high", "enabled": false, "when": [{"operator": "greaterThan", "value": 50}], "then": ["high"]},
+      {"id": "any", "when": [{"operator": "greaterThan", "value": 0}], "then": ["basic"]}
+    ]
+  }
+}`
+	dt, err = LoadJSON([]byte(doc), "toggles.json")
+	if err != nil {
+		t.Fatalf("load json: %v", err)
+	}
+	rows, err = dt.Evaluate(map[string]any{"score": 70}, nil)
+	if err != nil || rows[0].RuleID != "any" {
+		t.Fatalf("expected disabled JSON rule to be skipped, got %#v %v", rows, err)
+	}
+}
+
+func TestLoadJSONColumnLabels(t *testing.T) {
+	const doc = `
+{
+  "decisionTable": {
+    "name": "labels",
+    "policies": {"matchPolicy": "FIRST", "noMatchPolicy": "THROW_ERROR"},
+    "columns": [
+      {"name": "score", "label": "Credit Score", "type": "CONDITION", "dataType": "INTEGER"},
+      {"name": "segment", "label": "Segment", "type": "CONCLUSION", "dataType": "STRING"}
+    ],
+    "rules": [
+      {"id": "high", "when": [{"operator": "greaterThan", "value": 50}], "then": ["high"]}
+    ]
+  }
+}`
+	dt, err := LoadJSON([]byte(doc), "labels.json")
+	if err != nil {
+		t.Fatalf("load json: %v", err)
+	}
+	if dt.ConditionColumns()[0].Label != "Credit Score" || dt.OutputColumns()[0].Label != "Segment" {
+		t.Fatalf("unexpected labels %#v %#v", dt.ConditionColumns(), dt.OutputColumns())
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCSV(dt, &buf); err != nil {
+		t.Fatalf("write csv: %v", err)
+	}
+	reloaded, err := LoadCSV("labels.csv", &buf)
+	if err != nil {
+		t.Fatalf("load csv: %v", err)
+	}
+	if reloaded.ConditionColumns()[0].Label != "Credit Score" || reloaded.OutputColumns()[0].Label != "Segment" {
+		t.Fatalf("labels lost in csv round trip %#v %#v", reloaded.ConditionColumns(), reloaded.OutputColumns())
+	}
+}
+
+func buildExcelFixture(t *testing.T, mutators ...func(*excelize.File)) string {
+	t.Helper()
+	f := excelize.NewFile()
+	sheet := f.GetSheetName(0)
+	f.SetSheetName(sheet, excelSheetName)
+
+	set := func(cell, value string) {
+		if err := f.SetCellValue(excelSheetName, cell, value); err != nil {
+			t.Fatalf("set cell %s: %v", cell, err)
+		}
+	}
+
+	set("A1", "Version")
+	set("B1", "1.0")
+	set("A2", "Match Policy")
+	set("B2", "ALL")
+	set("A3", "No Match Policy")
+	set("B3", "RETURN_DEFAULT")
+
+	set("B5", "First Column")
+	set("F5", "Last Column")
+
+	// column definitions
+	headers := []struct {
+		col string
+	}{
+		{"B"},
+		{"C"},
+		{"D"},
+		{"E"},
+		{"F"},
+	}
+	names := []string{"age", "country", "tier", "ruleId", "comments"}
+	types := []string{"Condition", "Condition", "Conclusion", "Metadata", "Metadata"}
+	dataTypes := []string{"Integer", "String", "String", "String", "String"}
+	for idx, header := range headers {
+		set(header.col+"6", names[idx])
+		set(header.col+"7", types[idx])
+		set(header.col+"8", dataTypes[idx])
+	}
+
+	// rows
+	set("A9", "First Row")
+	set("B9", ">= 18")
+	set("C9", "= US")
+	set("D9", "standard")
+	set("E9", "row1")
+	set("F9", "adult rule")
+
+	set("B10", ">= 21")
+	set("C10", "IN CA,MX")
+	set("D10", "neighbor")
+	set("E10", "row2")
+	set("F10", "neighbor rule")
+
+	set("A11", "Default Row")
+	set("D11", "minor")
+	set("E11", "default")
+	set("F11", "default row")
+
+	for _, mutate := range mutators {
+		mutate(f)
+	}
 
 	dir := t.TempDir()
 	path := filepath.Join(dir, "fixture.xlsx")
@@ -250,3 +951,1167 @@ func buildExcelFixture(t *testing.T) string {
 	}
 	return path
 }
+
+func TestLoadJSONRegexFlags(t *testing.T) {
+	const docTemplate = `
+{
+  "decisionTable": {
+    "name": "codes",
+    "policies": {"matchPolicy": "FIRST", "noMatchPolicy": "THROW_ERROR"},
+    "columns": [
+      {"name": "code", "type": "CONDITION", "dataType": "STRING"},
+      {"name": "kind", "type": "CONCLUSION", "dataType": "STRING"}
+    ],
+    "rules": [
+      {"id": "promo", "when": [{"operator": "matchesRegex", "value": "^promo-", "flags": "%s"}], "then": ["promo"]}
+    ]
+  }
+}`
+
+	dt, err := LoadJSON([]byte(fmt.Sprintf(docTemplate, "i")), "codes.json")
+	if err != nil {
+		t.Fatalf("load json: %v", err)
+	}
+	rows, err := dt.Evaluate(map[string]any{"code": "PROMO-2025"}, nil)
+	if err != nil {
+		t.Fatalf("evaluate returned error: %v", err)
+	}
+	if len(rows) != 1 || rows[0].RuleID != "promo" {
+		t.Fatalf("expected case-insensitive match, got %#v", rows)
+	}
+
+	if _, err := LoadJSON([]byte(fmt.Sprintf(docTemplate, "ix")), "codes.json"); err == nil || !strings.Contains(err.Error(), "unknown regex flag") {
+		t.Fatalf("expected unknown flag error, got %v", err)
+	}
+}
+
+func TestLoadersExposeVersion(t *testing.T) {
+	const doc = `
+{
+  "decisionTable": {
+    "name": "versioned",
+    "version": " 2025.10-r3 ",
+    "policies": {"matchPolicy": "FIRST", "noMatchPolicy": "THROW_ERROR"},
+    "columns": [
+      {"name": "score", "type": "CONDITION", "dataType": "INTEGER"},
+      {"name": "segment", "type": "CONCLUSION", "dataType": "STRING"}
+    ],
+    "rules": [
+      {"id": "high", "when": [{"operator": "greaterThan", "value": 50}], "then": ["high"]}
+    ]
+  }
+}`
+
+	dt, err := LoadJSON([]byte(doc), "versioned.json", WithVersion("ignored"))
+	if err != nil {
+		t.Fatalf("load json: %v", err)
+	}
+	if dt.Version() != "2025.10-r3" {
+		t.Fatalf("expected declared json version to win, got %q", dt.Version())
+	}
+
+	unversioned := strings.Replace(doc, `"version": " 2025.10-r3 ",`, "", 1)
+	dt, err = LoadJSON([]byte(unversioned), "versioned.json", WithVersion("fallback"))
+	if err != nil {
+		t.Fatalf("load json: %v", err)
+	}
+	if dt.Version() != "fallback" {
+		t.Fatalf("expected caller version without declared one, got %q", dt.Version())
+	}
+
+	dt, err = LoadExcelFile(buildExcelFixture(t))
+	if err != nil {
+		t.Fatalf("load excel: %v", err)
+	}
+	if dt.Version() != "1.0" {
+		t.Fatalf("expected excel version 1.0, got %q", dt.Version())
+	}
+}
+
+func TestLoadJSONSparseOutput(t *testing.T) {
+	const doc = `
+{
+  "decisionTable": {
+    "name": "approval",
+    "policies": {"matchPolicy": "FIRST", "noMatchPolicy": "RETURN_DEFAULT"},
+    "columns": [
+      {"name": "score", "type": "CONDITION", "dataType": "INTEGER"},
+      {"name": "approved", "type": "CONCLUSION", "dataType": "BOOLEAN"},
+      {"name": "rejectionReason", "type": "CONCLUSION", "dataType": "STRING"}
+    ],
+    "rules": [
+      {"id": "reject", "when": [{"operator": "lessThan", "value": 500}], "then": {"approved": false, "rejectionReason": "low score"}},
+      {"id": "approve", "when": [{"operator": "greaterThanOrEqual", "value": 500}], "then": {"approved": true}}
+    ],
+    "defaultRule": {"then": [false, null]}
+  }
+}`
+
+	evaluate := func(dt *DecisionTable, score int) map[string]any {
+		t.Helper()
+		rows, err := dt.Evaluate(map[string]any{"score": score}, nil)
+		if err != nil {
+			t.Fatalf("evaluate returned error: %v", err)
+		}
+		if len(rows) != 1 {
+			t.Fatalf("expected one match, got %#v", rows)
+		}
+		return rows[0].Values
+	}
+
+	dense, err := LoadJSON([]byte(doc), "approval.json")
+	if err != nil {
+		t.Fatalf("load json: %v", err)
+	}
+	if values := evaluate(dense, 700); len(values) != 2 || values["rejectionReason"] != nil {
+		t.Fatalf("expected null rejection reason without sparse output, got %#v", values)
+	}
+
+	sparse, err := LoadJSON([]byte(doc), "approval.json", WithSparseOutput())
+	if err != nil {
+		t.Fatalf("load json: %v", err)
+	}
+	values := evaluate(sparse, 700)
+	if _, present := values["rejectionReason"]; present || values["approved"] != true {
+		t.Fatalf("expected rejection reason to be omitted, got %#v", values)
+	}
+	if values := evaluate(sparse, 100); values["rejectionReason"] != "low score" {
+		t.Fatalf("expected rejection reason on rejection rule, got %#v", values)
+	}
+
+	bad := strings.Replace(doc, `{"approved": true}`, `{"approved": true, "reason": "x"}`, 1)
+	if _, err := LoadJSON([]byte(bad), "approval.json"); err == nil || !strings.Contains(err.Error(), "unknown output column") {
+		t.Fatalf("expected unknown output column error, got %v", err)
+	}
+}
+
+func TestLoadDurationBetween(t *testing.T) {
+	const doc = `
+{
+  "decisionTable": {
+    "name": "latency",
+    "policies": {"matchPolicy": "FIRST", "noMatchPolicy": "THROW_ERROR"},
+    "columns": [
+      {"name": "latency", "type": "CONDITION", "dataType": "DURATION"},
+      {"name": "status", "type": "CONCLUSION", "dataType": "STRING"}
+    ],
+    "rules": [
+      {"id": "ok", "when": [{"operator": "between", "value": ["100ms", "1s"]}], "then": ["ok"]}
+    ]
+  }
+}`
+
+	dt, err := LoadJSON([]byte(doc), "latency.json")
+	if err != nil {
+		t.Fatalf("load json: %v", err)
+	}
+	rows, err := dt.Evaluate(map[string]any{"latency": 250 * time.Millisecond}, nil)
+	if err != nil || len(rows) != 1 {
+		t.Fatalf("expected json between match, got %#v (%v)", rows, err)
+	}
+
+	op, operand, err := parseConditionString("BETWEEN 100ms, 1s", DataTypeDuration)
+	if err != nil || op != OperatorBetween || !reflect.DeepEqual(operand, []string{"100ms", "1s"}) {
+		t.Fatalf("unexpected parsed condition %s %#v (%v)", op, operand, err)
+	}
+	text, err := formatConditionString(dt.Rows()[0].EvalCells[0])
+	if err != nil || text != "BETWEEN 100ms,1s" {
+		t.Fatalf("unexpected formatted condition %q (%v)", text, err)
+	}
+}
+
+func TestLoadJSONNotBetweenAndIntervals(t *testing.T) {
+	const doc = `
+{
+  "decisionTable": {
+    "name": "latency",
+    "policies": {"matchPolicy": "FIRST", "noMatchPolicy": "THROW_ERROR"},
+    "columns": [
+      {"name": "latency", "type": "CONDITION", "dataType": "DURATION"},
+      {"name": "status", "type": "CONCLUSION", "dataType": "STRING"}
+    ],
+    "rules": [
+      {"id": "fast", "when": [{"operator": "between", "value": "[0s..100ms)"}], "then": ["fast"]},
+      {"id": "ok", "when": [{"operator": "between", "value": ["100ms", "1s"], "interval": "[)"}], "then": ["ok"]},
+      {"id": "slow", "when": [{"operator": "notBetween", "value": ["0s", "1s"], "interval": "[)"}], "then": ["slow"]}
+    ]
+  }
+}`
+	dt, err := LoadJSON([]byte(doc), "latency.json")
+	if err != nil {
+		t.Fatalf("load json: %v", err)
+	}
+	for latency, want := range map[time.Duration]string{0: "fast", 100 * time.Millisecond: "ok", time.Second: "slow"} {
+		rows, err := dt.Evaluate(map[string]any{"latency": latency}, nil)
+		if err != nil || len(rows) != 1 || rows[0].RuleID != want {
+			t.Fatalf("latency %s: expected %s, got %#v (%v)", latency, want, rows, err)
+		}
+	}
+}
+
+func TestLoadersPopulateDefaultRowComments(t *testing.T) {
+	const doc = `
+{
+  "decisionTable": {
+    "name": "eligibility",
+    "policies": {"matchPolicy": "ALL", "noMatchPolicy": "RETURN_DEFAULT"},
+    "columns": [
+      {"name": "age", "type": "CONDITION", "dataType": "INTEGER"},
+      {"name": "tier", "type": "CONCLUSION", "dataType": "STRING"},
+      {"name": "comments", "type": "METADATA", "dataType": "STRING"}
+    ],
+    "rules": [
+      {"id": "adult", "when": [{"operator": "greaterThanOrEqual", "value": 18}], "then": ["standard", "adult rule"]}
+    ],
+    "defaultRule": {"description": "%s", "then": ["minor", "default row"]}
+  }
+}`
+
+	defaultComments := func(dt *DecisionTable) string {
+		t.Helper()
+		rows, err := dt.Evaluate(map[string]any{"age": 10, "country": "FR"}, nil)
+		if err != nil {
+			t.Fatalf("evaluate returned error: %v", err)
+		}
+		if len(rows) != 1 || !rows[0].IsDefault {
+			t.Fatalf("expected default match, got %#v", rows)
+		}
+		return rows[0].Comments
+	}
+
+	fromExcel, err := LoadExcelFile(buildExcelFixture(t))
+	if err != nil {
+		t.Fatalf("load excel: %v", err)
+	}
+	fromJSONDescription, err := LoadJSON([]byte(fmt.Sprintf(doc, "default row")), "eligibility.json")
+	if err != nil {
+		t.Fatalf("load json: %v", err)
+	}
+	fromJSONMetadata, err := LoadJSON([]byte(fmt.Sprintf(doc, "")), "eligibility.json")
+	if err != nil {
+		t.Fatalf("load json: %v", err)
+	}
+
+	for name, dt := range map[string]*DecisionTable{
+		"excel":            fromExcel,
+		"json description": fromJSONDescription,
+		"json metadata":    fromJSONMetadata,
+	} {
+		if got := defaultComments(dt); got != "default row" {
+			t.Fatalf("%s: expected default comments %q, got %q", name, "default row", got)
+		}
+	}
+	if row, ok := fromExcel.GetRow("row1"); !ok || row.Comments != "adult rule" {
+		t.Fatalf("expected excel rule comments from metadata, got %#v", row)
+	}
+}
+
+func TestParseConditionStringOperatorPhrases(t *testing.T) {
+	cases := []struct {
+		input   string
+		op      OperatorType
+		operand any
+	}{
+		{"greater than or equal 18", OperatorGreaterOrEqual, "18"},
+		{"Greater-Than-Or-Equal 18", OperatorGreaterOrEqual, "18"},
+		{"GREATER_THAN_OR_EQUAL 18", OperatorGreaterOrEqual, "18"},
+		{"greater than 18", OperatorGreater, "18"},
+		{"less-than-or-equal-to 5", OperatorLessOrEqual, "5"},
+		{"not equal US", OperatorNotEqual, "US"},
+		{"NOT IN US,CA", OperatorNotIn, []string{"US", "CA"}},
+		{"not-in US", OperatorNotIn, []string{"US"}},
+		{"any contained in vip,gold", OperatorAnyContained, []string{"vip", "gold"}},
+		{"Any-Contained-In vip", OperatorAnyContained, []string{"vip"}},
+		{"is not null", OperatorIsNotNull, nil},
+		{"is-null", OperatorIsNull, nil},
+		{"EQ IN", OperatorEqual, "IN"},
+	}
+	for _, tc := range cases {
+		op, operand, err := parseConditionString(tc.input, DataTypeString)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", tc.input, err)
+		}
+		if op != tc.op || !reflect.DeepEqual(operand, tc.operand) {
+			t.Fatalf("%q: expected %s %#v, got %s %#v", tc.input, tc.op, tc.operand, op, operand)
+		}
+	}
+
+	if _, _, err := parseConditionString("roughly 18", DataTypeInteger); err == nil || !strings.Contains(err.Error(), "unknown operator") {
+		t.Fatalf("expected unknown operator error, got %v", err)
+	}
+	if policy, err := parseNoMatchPolicyString("return-default"); err != nil || policy != NoMatchPolicyReturnDefault {
+		t.Fatalf("expected hyphenated policy to parse, got %s (%v)", policy, err)
+	}
+}
+
+func TestLoadExcelExplicitAnyCells(t *testing.T) {
+	blank := buildExcelFixture(t, func(f *excelize.File) {
+		if err := f.SetCellValue(excelSheetName, "C10", ""); err != nil {
+			t.Fatalf("set cell: %v", err)
+		}
+	})
+	if _, err := LoadExcelFile(blank); err != nil {
+		t.Fatalf("expected blank cell to load by default: %v", err)
+	}
+	if _, err := LoadExcelFile(blank, WithExplicitAnyCells()); err == nil || !strings.Contains(err.Error(), `row 10 (id "row2"): column country: blank condition cell`) {
+		t.Fatalf("expected blank condition cell error, got %v", err)
+	}
+
+	wildcard := buildExcelFixture(t, func(f *excelize.File) {
+		if err := f.SetCellValue(excelSheetName, "C10", "*"); err != nil {
+			t.Fatalf("set cell: %v", err)
+		}
+	})
+	dt, err := LoadExcelFile(wildcard, WithExplicitAnyCells())
+	if err != nil {
+		t.Fatalf("load excel with wildcard: %v", err)
+	}
+	if row, _ := dt.GetRow("row2"); len(row.EvalCells) != 1 {
+		t.Fatalf("expected wildcard to match any country, got %#v", row.EvalCells)
+	}
+}
+
+func TestLoadExcelFileWithLayout(t *testing.T) {
+	path := buildExcelFixture(t, func(f *excelize.File) {
+		if err := f.InsertRows(excelSheetName, 1, 2); err != nil {
+			t.Fatalf("insert rows: %v", err)
+		}
+	})
+	if _, err := LoadExcelFile(path); err == nil {
+		t.Fatalf("expected default layout to reject shifted sheet")
+	}
+
+	layout := DefaultExcelLayout()
+	layout.VersionRow += 2
+	layout.MatchPolicyRow += 2
+	layout.NoMatchRow += 2
+	layout.ColumnMarkerRow += 2
+	layout.ColumnNameRow += 2
+	layout.ColumnTypeRow += 2
+	layout.DataTypeRow += 2
+	dt, err := LoadExcelFileWithLayout(path, layout)
+	if err != nil {
+		t.Fatalf("load excel with layout: %v", err)
+	}
+	rows, err := dt.Evaluate(map[string]any{"age": 25, "country": "US"}, nil)
+	if err != nil || len(rows) != 1 || rows[0].RuleID != "row1" {
+		t.Fatalf("unexpected match: %#v (err %v)", rows, err)
+	}
+
+	bad := DefaultExcelLayout()
+	bad.ColumnTypeRow = bad.ColumnNameRow
+	if _, err := LoadExcelFileWithLayout(path, bad); err == nil || !strings.Contains(err.Error(), "overlaps") {
+		t.Fatalf("expected overlapping rows to be rejected, got %v", err)
+	}
+}
+
+func TestWriteExcelRoundTrip(t *testing.T) {
+	dt, err := LoadExcelFile(buildExcelFixture(t))
+	if err != nil {
+		t.Fatalf("load excel: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := WriteExcel(dt, &buf); err != nil {
+		t.Fatalf("write excel: %v", err)
+	}
+	data := buf.Bytes()
+	loaded, err := LoadExcel("fixture.xlsx", bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("reload exported excel: %v", err)
+	}
+	loaded.Name = dt.Name
+	if err := dt.EqualErr(loaded); err != nil {
+		t.Fatalf("round trip changed the table: %v", err)
+	}
+
+	f, err := excelize.OpenReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("open exported excel: %v", err)
+	}
+	defer f.Close()
+	validations, err := f.GetDataValidations(excelSheetName)
+	if err != nil {
+		t.Fatalf("read data validations: %v", err)
+	}
+	if len(validations) != 1 {
+		t.Fatalf("expected a dropdown on the country column only, got %d validations", len(validations))
+	}
+	dv := validations[0]
+	if dv.Sqref != "C9:C10" || dv.Formula1 != `"EQ US,EQ CA,EQ MX"` || dv.ShowErrorMessage {
+		t.Fatalf("unexpected country dropdown %s %s (error alert %v)", dv.Sqref, dv.Formula1, dv.ShowErrorMessage)
+	}
+}
+
+func TestNewExcelTemplateLoadsEmpty(t *testing.T) {
+	conditions := []Column{
+		{Name: "age", Type: ColumnTypeCondition, DataType: DataTypeInteger, Min: 0},
+		{Name: "country", Type: ColumnTypeCondition, DataType: DataTypeString},
+	}
+	outputs := []Column{{Name: "eligible", Type: ColumnTypeConclusion, DataType: DataTypeBoolean}}
+	f, err := NewExcelTemplate(conditions, outputs, MatchPolicyFirst, NoMatchPolicyReturnDefault)
+	if err != nil {
+		t.Fatalf("build template: %v", err)
+	}
+	var buf bytes.Buffer
+	if _, err := f.WriteTo(&buf); err != nil {
+		t.Fatalf("write template: %v", err)
+	}
+	f.Close()
+
+	dt, err := LoadExcel("template.xlsx", bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("load template: %v", err)
+	}
+	if len(dt.Rows()) != 0 {
+		t.Fatalf("expected no rules, got %d", len(dt.Rows()))
+	}
+	if dt.MatchPolicy() != MatchPolicyFirst || dt.NoMatchPolicy() != NoMatchPolicyReturnDefault {
+		t.Fatalf("unexpected policies %s/%s", dt.MatchPolicy(), dt.NoMatchPolicy())
+	}
+	got := append(dt.ConditionColumns(), dt.OutputColumns()...)
+	want := append(append([]Column(nil), conditions...), outputs...)
+	if len(got) != len(want) {
+		t.Fatalf("expected %d columns, got %+v", len(want), got)
+	}
+	for i := range want {
+		if got[i].Name != want[i].Name || got[i].Type != want[i].Type || got[i].DataType != want[i].DataType {
+			t.Fatalf("column %d: expected %+v, got %+v", i, want[i], got[i])
+		}
+	}
+	if formatBound(got[0], got[0].Min) != "0" {
+		t.Fatalf("expected the Min attribute row to survive, got %v", got[0].Min)
+	}
+
+	if _, err := NewExcelTemplate(conditions, nil, MatchPolicyFirst, NoMatchPolicyThrowError); err == nil {
+		t.Fatalf("expected a template without outputs to fail")
+	}
+}
+
+func TestLoadExcelReportsBlankRowsOutsideTemplates(t *testing.T) {
+	path := buildExcelFixture(t, func(f *excelize.File) {
+		for _, cell := range []string{"B10", "C10", "D10", "E10", "F10"} {
+			if err := f.SetCellValue(excelSheetName, cell, ""); err != nil {
+				t.Fatalf("set cell: %v", err)
+			}
+		}
+	})
+	_, err := LoadExcelFile(path)
+	var loadErr *LoadError
+	if !errors.As(err, &loadErr) || loadErr.Row != 10 {
+		t.Fatalf("expected the blank rule row to be reported rather than skipped, got %v", err)
+	}
+}
+
+func TestLoadJSONRuleTemplates(t *testing.T) {
+	const doc = `
+{
+  "decisionTable": {
+    "name": "shipping",
+    "policies": {"matchPolicy": "FIRST", "noMatchPolicy": "RETURN_DEFAULT"},
+    "columns": [
+      {"name": "region", "type": "CONDITION", "dataType": "STRING"},
+      {"name": "weight", "type": "CONDITION", "dataType": "INTEGER"},
+      {"name": "fee", "type": "CONCLUSION", "dataType": "INTEGER"}
+    ],
+    "rules": [
+      {"id": "heavy", "when": [null, {"operator": "greaterThan", "value": 100}], "then": [50]}
+    ],
+    "ruleTemplates": [
+      {
+        "rule": {
+          "id": "region-{{region}}",
+          "description": "flat fee for {{region}}",
+          "when": [{"operator": "in", "value": "{{codes}}"}, null],
+          "then": {"fee": "{{fee}}"}
+        },
+        "values": [
+          {"region": "na", "codes": ["US", "CA"], "fee": 5},
+          {"region": "eu", "codes": ["FR", "DE"], "fee": 8}
+        ]
+      },
+      {
+        "rule": {"id": "light", "when": [null, {"operator": "lessThan", "value": "{{max}}"}], "then": ["{{fee}}"]},
+        "values": [{"max": 1, "fee": 0}, {"max": 2, "fee": 1}]
+      }
+    ],
+    "defaultRule": {"then": [20]}
+  }
+}`
+
+	dt, err := LoadJSON([]byte(doc), "shipping.json")
+	if err != nil {
+		t.Fatalf("load json: %v", err)
+	}
+	var ids []string
+	for _, row := range dt.Rows() {
+		ids = append(ids, row.RuleID)
+	}
+	want := []string{"heavy", "region-na", "region-eu", "light-1", "light-2"}
+	if !reflect.DeepEqual(ids, want) {
+		t.Fatalf("expected ids %v, got %v", want, ids)
+	}
+	if got := dt.Rows()[2].Comments; got != "flat fee for eu" {
+		t.Fatalf("expected substituted description, got %q", got)
+	}
+
+	rows, err := dt.Evaluate(map[string]any{"region": "DE", "weight": 10}, nil)
+	if err != nil || len(rows) != 1 || rows[0].RuleID != "region-eu" || rows[0].Values["fee"] != int64(8) {
+		t.Fatalf("expected eu template rule, got %#v (err %v)", rows, err)
+	}
+
+	missing := strings.Replace(doc, `"fee": 8}`, `"fee": 8, "unused": 1}, {"region": "apac", "fee": 9}`, 1)
+	if _, err := LoadJSON([]byte(missing), "shipping.json"); err == nil || !strings.Contains(err.Error(), "rule template 1: entry 3: placeholder {{codes}} has no value") {
+		t.Fatalf("expected missing placeholder error, got %v", err)
+	}
+	duplicate := strings.Replace(doc, `"region": "eu"`, `"region": "na"`, 1)
+	if _, err := LoadJSON([]byte(duplicate), "shipping.json"); err == nil || !strings.Contains(err.Error(), `duplicate rule id "region-na"`) {
+		t.Fatalf("expected duplicate generated id error, got %v", err)
+	}
+}
+
+func TestLoadersRejectOperatorDataTypeMismatch(t *testing.T) {
+	const jsonDoc = `
+{
+  "decisionTable": {
+    "name": "mismatch",
+    "policies": {"matchPolicy": "FIRST", "noMatchPolicy": "THROW_ERROR"},
+    "columns": [
+      {"name": "code", "type": "CONDITION", "dataType": "%s"},
+      {"name": "out", "type": "CONCLUSION", "dataType": "STRING"}
+    ],
+    "rules": [
+      {"when": [{"operator": "isNull"}], "then": ["a"]},
+      {"when": [{"operator": "%s", "value": %s}], "then": ["b"]}
+    ]
+  }
+}`
+	jsonCases := []struct {
+		dataType, operator, value, want string
+	}{
+		{"INTEGER", "matchesRegex", `"^1"`, "rule 2: column code: operator MATCHES_REGEX not supported for INTEGER columns"},
+		{"DECIMAL", "in", `[1]`, ""},
+		{"INTEGER", "textEqual", `"1"`, "operator TEXT_EQ not supported for INTEGER columns"},
+		{"DATE", "between", `["2025-01-01", "2025-12-31"]`, ""},
+		{"BOOLEAN", "lessThan", `true`, "rule 2: column code: operator LT not supported for BOOLEAN columns"},
+	}
+	for _, tc := range jsonCases {
+		doc := fmt.Sprintf(jsonDoc, tc.dataType, tc.operator, tc.value)
+		_, err := LoadJSON([]byte(doc), "mismatch.json")
+		if tc.want == "" {
+			if err != nil {
+				t.Fatalf("%s on %s: unexpected error %v", tc.operator, tc.dataType, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("%s on %s: expected %q, got %v", tc.operator, tc.dataType, tc.want, err)
+		}
+	}
+
+	csvDoc := strings.Replace(csvFixture, `"IN US,CA"`, `"CONTAINS_ALL US,CA"`, 1)
+	if _, err := LoadCSV("mismatch.csv", strings.NewReader(csvDoc)); err == nil || !strings.Contains(err.Error(), "column country: operator CONTAINS_ALL not supported for STRING columns") {
+		t.Fatalf("expected CONTAINS_ALL on STRING to fail at load, got %v", err)
+	}
+	csvDoc = strings.Replace(csvFixture, `"ANY_CONTAINED_IN vip,gold"`, `"IN vip,gold"`, 1)
+	if _, err := LoadCSV("mismatch.csv", strings.NewReader(csvDoc)); err == nil || !strings.Contains(err.Error(), "operator IN not supported for LIST_STRING columns") {
+		t.Fatalf("expected IN on LIST_STRING to fail at load, got %v", err)
+	}
+
+	path := buildExcelFixture(t, func(f *excelize.File) {
+		if err := f.SetCellValue(excelSheetName, "C10", "> CA"); err != nil {
+			t.Fatalf("set cell: %v", err)
+		}
+	})
+	if _, err := LoadExcelFile(path); err == nil || !strings.Contains(err.Error(), `row 10 (id "row2"): column country: operator GT not supported for STRING columns`) {
+		t.Fatalf("expected GT on STRING to fail at load, got %v", err)
+	}
+}
+
+func TestValidateJSONReportsEveryError(t *testing.T) {
+	const doc = `
+{
+  "decisionTable": {
+    "name": "broken",
+    "policies": {"matchPolicy": "FIRST", "noMatchPolicy": "RETURN_DEFAULT"},
+    "columns": [
+      {"name": "age", "type": "CONDITION", "dataType": "INTEGER"},
+      {"name": "tier", "type": "CONCLUSION", "dataType": "STRING"}
+    ],
+    "rules": [
+      {"id": "r1", "when": [{"operator": "greaterThan", "value": 18}], "then": ["adult"]},
+      {"id": "r1", "when": [{"operator": "lessThan", "value": 18}], "then": ["minor"]},
+      {"id": "r3", "when": [], "then": ["none"]},
+      {"id": "r4", "when": [{"operator": "roughly", "value": 1}], "then": ["x"]},
+      {"id": "r5", "when": [{"operator": "equal", "value": "old"}], "then": ["x"]}
+    ]
+  }
+}`
+	errs := ValidateJSON([]byte(doc))
+	want := []string{
+		`rule 2 (id "r1"): duplicate rule id`,
+		`rule 3 (id "r3"): expected 1 when cells`,
+		`rule 4 (id "r4"): column age`,
+		`rule 5 (id "r5"): column age`,
+		"defaultRule section required",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("expected %d errors, got %d: %v", len(want), len(errs), errs)
+	}
+	for i, w := range want {
+		if !strings.Contains(errs[i].Error(), w) {
+			t.Fatalf("error %d: expected %q, got %v", i, w, errs[i])
+		}
+	}
+
+	colErrs := ValidateJSON([]byte(`{"decisionTable": {"policies": {"matchPolicy": "FIRST", "noMatchPolicy": "NONE"},
+		"columns": [{"name": "a", "type": "CONDTION", "dataType": "INTEGER"}, {"name": "b", "type": "CONCLUSION", "dataType": "NUMBER"}],
+		"rules": [{"when": [], "then": [1]}]}}`))
+	if len(colErrs) != 3 {
+		t.Fatalf("expected policy and column errors, got %v", colErrs)
+	}
+
+	if errs := ValidateJSON([]byte(greetingTableJSON)); errs != nil {
+		t.Fatalf("expected a valid document to pass, got %v", errs)
+	}
+	if _, err := LoadJSON([]byte(doc), "broken.json"); err == nil || !strings.Contains(err.Error(), "rule 2") {
+		t.Fatalf("expected LoadJSON to stop at the first error, got %v", err)
+	}
+}
+
+func TestLoadExcelYesNoBooleanOutputs(t *testing.T) {
+	path := buildExcelFixture(t, func(f *excelize.File) {
+		cells := map[string]string{"B2": "FIRST", "D8": "Boolean", "D9": "Yes", "D10": " n ", "D11": "No"}
+		for cell, value := range cells {
+			if err := f.SetCellValue(excelSheetName, cell, value); err != nil {
+				t.Fatalf("set cell %s: %v", cell, err)
+			}
+		}
+	})
+	dt, err := LoadExcelFile(path)
+	if err != nil {
+		t.Fatalf("load excel: %v", err)
+	}
+
+	cases := []struct {
+		input map[string]any
+		want  bool
+	}{
+		{map[string]any{"age": 30, "country": "US"}, true},
+		{map[string]any{"age": 30, "country": "CA"}, false},
+		{map[string]any{"age": 10, "country": "US"}, false},
+	}
+	for _, tc := range cases {
+		rows, err := dt.Evaluate(tc.input, nil)
+		if err != nil {
+			t.Fatalf("evaluate %v returned error: %v", tc.input, err)
+		}
+		if len(rows) != 1 {
+			t.Fatalf("input %v: expected one row, got %#v", tc.input, rows)
+		}
+		if got, ok := rows[0].Values["tier"].(bool); !ok || got != tc.want {
+			t.Fatalf("input %v: expected %v, got %#v", tc.input, tc.want, rows[0].Values["tier"])
+		}
+	}
+
+	bad := buildExcelFixture(t, func(f *excelize.File) {
+		for cell, value := range map[string]string{"D8": "Boolean", "D9": "Maybe"} {
+			if err := f.SetCellValue(excelSheetName, cell, value); err != nil {
+				t.Fatalf("set cell %s: %v", cell, err)
+			}
+		}
+	})
+	if _, err := LoadExcelFile(bad); err == nil || !strings.Contains(err.Error(), "Maybe") {
+		t.Fatalf("expected an uncoercible boolean output to fail, got %v", err)
+	}
+}
+
+func TestLoadErrorLocatesFailingRule(t *testing.T) {
+	path := buildExcelFixture(t, func(f *excelize.File) {
+		if err := f.SetCellValue(excelSheetName, "B10", ">= twenty"); err != nil {
+			t.Fatalf("set cell: %v", err)
+		}
+	})
+	_, err := LoadExcelFile(path)
+	var loadErr *LoadError
+	if !errors.As(err, &loadErr) {
+		t.Fatalf("expected a LoadError, got %v", err)
+	}
+	if loadErr.Sheet != excelSheetName || loadErr.Row != 10 || loadErr.RuleID != "row2" || !strings.Contains(loadErr.Err.Error(), "column age") {
+		t.Fatalf("unexpected excel load error %#v", loadErr)
+	}
+
+	const doc = `
+{
+  "decisionTable": {
+    "name": "ages",
+    "policies": {"matchPolicy": "FIRST", "noMatchPolicy": "THROW_ERROR"},
+    "columns": [
+      {"name": "age", "type": "CONDITION", "dataType": "INTEGER"},
+      {"name": "tier", "type": "CONCLUSION", "dataType": "STRING"}
+    ],
+    "rules": [
+      {"id": "adult", "when": [{"operator": "greaterThan", "value": 18}], "then": ["adult"]},
+      {"id": "teen", "when": [{"operator": "greaterThan", "value": "thirteen"}], "then": ["teen"]}
+    ]
+  }
+}`
+	_, err = LoadJSON([]byte(doc), "ages.json")
+	if !errors.As(err, &loadErr) {
+		t.Fatalf("expected a LoadError, got %v", err)
+	}
+	if loadErr.Sheet != "" || loadErr.Row != 0 || loadErr.Rule != 2 || loadErr.RuleID != "teen" {
+		t.Fatalf("unexpected json load error %#v", loadErr)
+	}
+	if !strings.HasPrefix(err.Error(), `rule 2 (id "teen"): `) {
+		t.Fatalf("unexpected json load error message %q", err)
+	}
+}
+
+func TestLoadersFillRowMetadata(t *testing.T) {
+	path := buildExcelFixture(t, func(f *excelize.File) {
+		cells := map[string]string{
+			"F5": "", "G5": "Last Column",
+			"G6": "owner", "G7": "Metadata", "G8": "String",
+			"G9": " risk-team ", "G11": "platform",
+		}
+		for cell, value := range cells {
+			if err := f.SetCellValue(excelSheetName, cell, value); err != nil {
+				t.Fatalf("set cell %s: %v", cell, err)
+			}
+		}
+	})
+	dt, err := LoadExcelFile(path)
+	if err != nil {
+		t.Fatalf("load excel: %v", err)
+	}
+	rows := dt.Rows()
+	if !reflect.DeepEqual(rows[0].Metadata, map[string]string{"owner": "risk-team"}) || rows[1].Metadata != nil {
+		t.Fatalf("unexpected row metadata %v / %v", rows[0].Metadata, rows[1].Metadata)
+	}
+	matches, err := dt.Evaluate(map[string]any{"age": 10, "country": "US"}, nil)
+	if err != nil {
+		t.Fatalf("evaluate returned error: %v", err)
+	}
+	if len(matches) != 1 || matches[0].Metadata["owner"] != "platform" || matches[0].Values["owner"] != "platform" {
+		t.Fatalf("expected default row metadata, got %#v", matches)
+	}
+
+	const doc = `
+{
+  "decisionTable": {
+    "name": "owners",
+    "policies": {"matchPolicy": "FIRST", "noMatchPolicy": "THROW_ERROR"},
+    "columns": [
+      {"name": "age", "type": "CONDITION", "dataType": "INTEGER"},
+      {"name": "tier", "type": "CONCLUSION", "dataType": "STRING"},
+      {"name": "ticket", "type": "METADATA", "dataType": "STRING"},
+      {"name": "priority", "type": "METADATA", "dataType": "INTEGER"}
+    ],
+    "rules": [
+      {"id": "adult", "when": [{"operator": "greaterThan", "value": 18}], "then": ["adult", "RISK-42", 3]}
+    ]
+  }
+}`
+	dt, err = LoadJSON([]byte(doc), "owners.json")
+	if err != nil {
+		t.Fatalf("load json: %v", err)
+	}
+	matches, err = dt.Evaluate(map[string]any{"age": 30}, nil)
+	if err != nil {
+		t.Fatalf("evaluate returned error: %v", err)
+	}
+	if !reflect.DeepEqual(matches[0].Metadata, map[string]string{"ticket": "RISK-42"}) {
+		t.Fatalf("expected only the ticket in metadata, got %v", matches[0].Metadata)
+	}
+	matches[0].Metadata["ticket"] = "changed"
+	if row, _ := dt.GetRow("adult"); row.Metadata["ticket"] != "RISK-42" {
+		t.Fatalf("changing a result's metadata changed the rule: %v", row.Metadata)
+	}
+}
+
+func TestLoadJSONEnums(t *testing.T) {
+	const doc = `
+{
+  "decisionTable": {
+    "name": "tiers",
+    "policies": {"matchPolicy": "FIRST", "noMatchPolicy": "RETURN_DEFAULT"},
+    "enums": {"premiumTiers": ["PREMIUM", "VIP"]},
+    "columns": [
+      {"name": "category", "type": "CONDITION", "dataType": "STRING"},
+      {"name": "rate", "type": "CONCLUSION", "dataType": "DECIMAL"}
+    ],
+    "rules": [
+      {"id": "premium", "when": [{"operator": "in", "valueRef": "@premiumTiers"}], "then": [3.5]},
+      {"id": "other", "when": [{"operator": "notIn", "valueRef": "@premiumTiers"}], "then": [4.8]}
+    ],
+    "defaultRule": {"then": [null]}
+  }
+}`
+	dt, err := LoadJSON([]byte(doc), "tiers.json")
+	if err != nil {
+		t.Fatalf("load json: %v", err)
+	}
+	for input, want := range map[string]string{"VIP": "premium", "PREMIUM": "premium", "BASIC": "other"} {
+		rows, err := dt.Evaluate(map[string]any{"category": input}, nil)
+		if err != nil {
+			t.Fatalf("evaluate %s returned error: %v", input, err)
+		}
+		if rows[0].RuleID != want {
+			t.Fatalf("category %s: expected %s, got %s", input, want, rows[0].RuleID)
+		}
+	}
+	if cell := dt.Rows()[0].EvalCells[0]; cell.ValueRef != "" || len(cell.Value.([]any)) != 2 {
+		t.Fatalf("expected the enum to resolve to its list, got %#v", cell)
+	}
+
+	unknown := strings.Replace(doc, `"@premiumTiers"}], "then": [4.8]`, `"@vipTiers"}], "then": [4.8]`, 1)
+	if _, err := LoadJSON([]byte(unknown), "tiers.json"); err == nil || !strings.Contains(err.Error(), "column category: unknown enum @vipTiers") {
+		t.Fatalf("expected unknown enum error, got %v", err)
+	}
+	both := strings.Replace(doc, `"valueRef": "@premiumTiers"}], "then": [3.5]`, `"value": ["X"], "valueRef": "@premiumTiers"}], "then": [3.5]`, 1)
+	if _, err := LoadJSON([]byte(both), "tiers.json"); err == nil || !strings.Contains(err.Error(), "mutually exclusive") {
+		t.Fatalf("expected value and enum to be rejected together, got %v", err)
+	}
+}
+
+func TestLoadGzipStreams(t *testing.T) {
+	compress := func(data []byte) *bytes.Buffer {
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		if _, err := gz.Write(data); err != nil {
+			t.Fatalf("gzip write: %v", err)
+		}
+		if err := gz.Close(); err != nil {
+			t.Fatalf("gzip close: %v", err)
+		}
+		return &buf
+	}
+
+	dt, err := LoadJSONGzip(compress([]byte(greetingTableJSON)), "greeting.json.gz")
+	if err != nil {
+		t.Fatalf("load json gzip: %v", err)
+	}
+	if dt.Name != "greeting" || dt.RowCount() != 1 {
+		t.Fatalf("unexpected table %s with %d rows", dt.Name, dt.RowCount())
+	}
+
+	workbook, err := os.ReadFile(buildExcelFixture(t))
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+	dt, err = LoadExcelGzip("rules.xlsx.gz", compress(workbook))
+	if err != nil {
+		t.Fatalf("load excel gzip: %v", err)
+	}
+	if dt.RowCount() != 2 {
+		t.Fatalf("expected 2 rows, got %d", dt.RowCount())
+	}
+
+	if _, err := LoadExcelGzip("plain.xlsx", bytes.NewReader(workbook)); err == nil || !strings.Contains(err.Error(), "open gzip stream plain.xlsx") || !errors.Is(err, gzip.ErrHeader) {
+		t.Fatalf("expected gzip header error, got %v", err)
+	}
+	truncated := compress([]byte(greetingTableJSON)).Bytes()
+	if _, err := LoadJSONGzip(bytes.NewReader(truncated[:len(truncated)-10]), "cut.json.gz"); err == nil || !strings.Contains(err.Error(), "read gzip stream cut.json.gz") {
+		t.Fatalf("expected truncated stream error, got %v", err)
+	}
+}
+
+func TestDecimalScaleRejectsOverPreciseRuleValues(t *testing.T) {
+	const doc = `
+{
+  "decisionTable": {
+    "name": "fees",
+    "policies": {"matchPolicy": "FIRST", "noMatchPolicy": "THROW_ERROR"},
+    "columns": [
+      {"name": "price", "type": "CONDITION", "dataType": "DECIMAL", "scale": 2},
+      {"name": "fee", "type": "CONCLUSION", "dataType": "DECIMAL", "scale": 2}
+    ],
+    "rules": [
+      {"id": "cheap", "when": [{"operator": "lessThan", "value": "10.50"}], "then": ["0.99"]},
+      {"id": "listed", "when": [{"operator": "in", "value": [12, 19.9, "25.000"]}], "then": [1.5]}
+    ]
+  }
+}`
+	dt, err := LoadJSON([]byte(doc), "fees.json")
+	if err != nil {
+		t.Fatalf("expected values within scale to load, got %v", err)
+	}
+	if dt.ConditionColumns()[0].Scale != 2 {
+		t.Fatalf("expected price to have scale 2, got %d", dt.ConditionColumns()[0].Scale)
+	}
+
+	_, err = LoadJSON([]byte(strings.Replace(doc, `"10.50"`, `"3.555"`, 1)), "fees.json")
+	var loadErr *LoadError
+	if !errors.As(err, &loadErr) || loadErr.RuleID != "cheap" || !strings.Contains(err.Error(), "column price: value 3.555 has 3 decimal places, scale allows 2") {
+		t.Fatalf("expected the over-scale condition to be rejected, got %v", err)
+	}
+	_, err = LoadJSON([]byte(strings.Replace(doc, `[1.5]`, `[1.505]`, 1)), "fees.json")
+	if !errors.As(err, &loadErr) || loadErr.RuleID != "listed" || !strings.Contains(err.Error(), "return column fee: value 1.505") {
+		t.Fatalf("expected the over-scale return value to be rejected, got %v", err)
+	}
+
+	_, err = LoadExcelFile(buildExcelFixture(t, func(f *excelize.File) {
+		if err := f.InsertRows(excelSheetName, 9, 1); err != nil {
+			t.Fatalf("insert rows: %v", err)
+		}
+		for cell, value := range map[string]string{"A9": "Scale", "B9": "2"} {
+			if err := f.SetCellValue(excelSheetName, cell, value); err != nil {
+				t.Fatalf("set cell %s: %v", cell, err)
+			}
+		}
+	}))
+	if err == nil || !strings.Contains(err.Error(), "column age: only DECIMAL columns can have a scale") {
+		t.Fatalf("expected a scale on an INTEGER column to be rejected, got %v", err)
+	}
+}
+
+func TestMissingPoliciesPerColumn(t *testing.T) {
+	const doc = `
+{
+  "decisionTable": {
+    "name": "pricing",
+    "policies": {"matchPolicy": "FIRST", "noMatchPolicy": "RETURN_DEFAULT"},
+    "columns": [
+      {"name": "region", "type": "CONDITION", "dataType": "STRING"},
+      {"name": "score", "type": "CONDITION", "dataType": "INTEGER", "missing": "error"},
+      {"name": "tier", "type": "CONDITION", "dataType": "STRING", "default": "basic", "missing": "DEFAULT"},
+      {"name": "price", "type": "CONCLUSION", "dataType": "STRING"}
+    ],
+    "rules": [
+      {"id": "no-region", "when": [{"operator": "isNull"}, null, null], "then": ["list"]},
+      {"id": "basic", "when": [null, null, {"operator": "equal", "value": "basic"}], "then": ["basic"]},
+      {"id": "scored", "when": [null, {"operator": "greaterThan", "value": 700}, null], "then": ["discount"]}
+    ],
+    "defaultRule": {"then": ["premium"]}
+  }
+}`
+	dt, err := LoadJSON([]byte(doc), "pricing.json")
+	if err != nil {
+		t.Fatalf("load json: %v", err)
+	}
+	price := func(input map[string]any) (any, error) {
+		matches, err := dt.Evaluate(input, nil)
+		if err != nil {
+			return nil, err
+		}
+		if len(matches) != 1 {
+			return nil, fmt.Errorf("expected one match, got %d", len(matches))
+		}
+		return matches[0].Values["price"], nil
+	}
+
+	// region is missing and evaluates as null
+	if got, err := price(map[string]any{}); err != nil || got != "list" {
+		t.Fatalf("expected a missing region to be null, got %v (err %v)", got, err)
+	}
+	// tier is missing and evaluates as its default, so the score rule is never reached
+	if got, err := price(map[string]any{"region": "EU"}); err != nil || got != "basic" {
+		t.Fatalf("expected a missing tier to use its default, got %v (err %v)", got, err)
+	}
+	// an explicit null is not missing
+	_, err = price(map[string]any{"region": "EU", "tier": nil})
+	if !errors.Is(err, ErrMissingInput) || !strings.Contains(err.Error(), "missing input score") {
+		t.Fatalf("expected a missing score to fail the evaluation, got %v", err)
+	}
+	if got, err := price(map[string]any{"region": "EU", "tier": nil, "score": 720}); err != nil || got != "discount" {
+		t.Fatalf("expected the score rule, got %v (err %v)", got, err)
+	}
+
+	bad := strings.Replace(doc, `"missing": "error"`, `"missing": "skip"`, 1)
+	if _, err := LoadJSON([]byte(bad), "pricing.json"); err == nil || !strings.Contains(err.Error(), `unknown missing policy "skip"`) {
+		t.Fatalf("expected an unknown missing policy to be rejected, got %v", err)
+	}
+
+	dtExcel, err := LoadExcelFile(buildExcelFixture(t, func(f *excelize.File) {
+		if err := f.InsertRows(excelSheetName, 9, 1); err != nil {
+			t.Fatalf("insert rows: %v", err)
+		}
+		for cell, value := range map[string]string{"A9": "Missing", "B9": "error", "C9": "null"} {
+			if err := f.SetCellValue(excelSheetName, cell, value); err != nil {
+				t.Fatalf("set cell %s: %v", cell, err)
+			}
+		}
+	}))
+	if err != nil {
+		t.Fatalf("load excel: %v", err)
+	}
+	cols := dtExcel.ConditionColumns()
+	if cols[0].MissingPolicy != MissingPolicyError || cols[1].MissingPolicy != MissingPolicyNull {
+		t.Fatalf("unexpected missing policies %q %q", cols[0].MissingPolicy, cols[1].MissingPolicy)
+	}
+}
+
+func TestLoadJSONVerboseReportsWarnings(t *testing.T) {
+	const doc = `
+{
+  "decisionTable": {
+    "name": "warned",
+    "policies": {"matchPolicy": "FIRST", "noMatchPolicy": "RETURN_NIL"},
+    "columns": [
+      {"name": "age", "type": "CONDITION", "dataType": "INTEGER"},
+      {"name": "tier", "type": "CONCLUSION", "dataType": "STRING"},
+      {"name": "note", "type": "CONCLUSION", "dataType": "STRING"}
+    ],
+    "rules": [
+      {"id": "adult", "when": [{"operator": "greaterThan", "value": 18}], "then": ["adult", null]},
+      {"when": [{"operator": "lessThan", "value": 13}], "then": ["child", null]},
+      {"id": "rest", "when": [null], "then": ["other", null]}
+    ]
+  }
+}`
+	result := LoadJSONVerbose([]byte(doc), "warned.json", WithRowValidationPolicy(RowValidationLenient))
+	if result.Table == nil || len(result.Errors) != 0 {
+		t.Fatalf("expected the table to load, got %v", result.Errors)
+	}
+	want := []struct {
+		kind   WarningKind
+		rule   string
+		column string
+	}{
+		{WarningAutoRuleID, result.Table.Rows()[1].RuleID, ""},
+		{WarningCatchAllRule, "rest", ""},
+		{WarningEmptyOutputColumn, "", "note"},
+	}
+	if len(result.Warnings) != len(want) {
+		t.Fatalf("expected %d warnings, got %v", len(want), result.Warnings)
+	}
+	for i, w := range want {
+		got := result.Warnings[i]
+		if got.Kind != w.kind || got.RuleID != w.rule || got.Column != w.column {
+			t.Fatalf("warning %d: expected %s for rule %q column %q, got %+v", i, w.kind, w.rule, w.column, got)
+		}
+	}
+
+	broken := LoadJSONVerbose([]byte(strings.Replace(doc, `"id": "rest"`, `"id": "adult"`, 1)), "broken.json")
+	if broken.Table != nil || len(broken.Errors) != 1 || !strings.Contains(broken.Errors[0].Error(), "duplicate rule id") {
+		t.Fatalf("expected a duplicate id error and no table, got %+v", broken)
+	}
+}
+
+func TestLoadJSONVerboseAppliesOptions(t *testing.T) {
+	const doc = `
+{
+  "decisionTable": {
+    "name": "overlapping",
+    "policies": {"matchPolicy": "UNIQUE", "noMatchPolicy": "RETURN_NIL"},
+    "columns": [
+      {"name": "age", "type": "CONDITION", "dataType": "INTEGER"},
+      {"name": "tier", "type": "CONCLUSION", "dataType": "STRING"}
+    ],
+    "rules": [
+      {"id": "adult", "when": [{"operator": "greaterThan", "value": 18}], "then": ["adult"]},
+      {"id": "senior", "when": [{"operator": "greaterThan", "value": 65}], "then": ["senior"]},
+      {"id": "voter", "when": [{"operator": "greaterThan", "value": 21}], "then": ["voter"]}
+    ]
+  }
+}`
+	if result := LoadJSONVerbose([]byte(doc), "overlapping.json"); len(result.Errors) != 0 {
+		t.Fatalf("expected the document to load without options, got %v", result.Errors)
+	}
+	result := LoadJSONVerbose([]byte(doc), "overlapping.json", WithValidateUniqueness())
+	if result.Table != nil || len(result.Errors) != 2 {
+		t.Fatalf("expected both overlapping rules to be reported under the option, got %v", result.Errors)
+	}
+}
+
+func TestLoadExcelVerboseReportsAutoIDs(t *testing.T) {
+	path := buildExcelFixture(t, func(f *excelize.File) {
+		if err := f.SetCellValue(excelSheetName, "E10", ""); err != nil {
+			t.Fatalf("clear rule id: %v", err)
+		}
+	})
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+	result := LoadExcelVerbose("fixture.xlsx", bytes.NewReader(data))
+	if len(result.Errors) != 0 {
+		t.Fatalf("expected the workbook to load, got %v", result.Errors)
+	}
+	if len(result.Warnings) != 1 || result.Warnings[0].Kind != WarningAutoRuleID || result.Warnings[0].RowNumber != 2 {
+		t.Fatalf("expected one auto id warning for rule 2, got %v", result.Warnings)
+	}
+}
+
+func TestDefaultRuleDesignatedByID(t *testing.T) {
+	const doc = `
+{
+  "decisionTable": {
+    "name": "designated",
+    "policies": {"matchPolicy": "FIRST", "noMatchPolicy": "RETURN_DEFAULT"},
+    "columns": [
+      {"name": "age", "type": "CONDITION", "dataType": "INTEGER"},
+      {"name": "tier", "type": "CONCLUSION", "dataType": "STRING"}
+    ],
+    "rules": [
+      {"id": "adult", "when": [{"operator": "greaterThan", "value": 18}], "then": ["adult"]},
+      {"id": "fallback", "when": [null], "then": ["minor"]}
+    ],
+    "defaultRuleId": "fallback"
+  }
+}`
+	dt, err := LoadJSON([]byte(doc), "designated.json")
+	if err != nil {
+		t.Fatalf("load json: %v", err)
+	}
+	if len(dt.Rows()) != 1 || !dt.HasDefaultRow() {
+		t.Fatalf("expected the fallback rule to move into the default slot, got %d rules", len(dt.Rows()))
+	}
+	rows, err := dt.Evaluate(map[string]any{"age": 12}, nil)
+	if err != nil {
+		t.Fatalf("evaluate: %v", err)
+	}
+	if len(rows) != 1 || !rows[0].IsDefault || rows[0].RuleID != "fallback" || rows[0].Values["tier"] != "minor" {
+		t.Fatalf("expected the designated default, got %#v", rows)
+	}
+
+	broken := []struct {
+		name string
+		doc  string
+		want string
+	}{
+		{"conditions", strings.Replace(doc, `"defaultRuleId": "fallback"`, `"defaultRuleId": "adult"`, 1), "has conditions"},
+		{"unknown id", strings.Replace(strings.Replace(doc, "[null]", `[{"operator": "lessThan", "value": 18}]`, 1), `"defaultRuleId": "fallback"`, `"defaultRuleId": "missing"`, 1), "does not name"},
+		{"both set", strings.Replace(doc, `"defaultRuleId": "fallback"`, `"defaultRuleId": "fallback", "defaultRule": {"then": ["x"]}`, 1), "cannot both"},
+		{"return nil", strings.Replace(doc, `"RETURN_DEFAULT"`, `"RETURN_NIL"`, 1), "defaultRuleId requires the RETURN_DEFAULT or THROW_ERROR"},
+	}
+	for _, tc := range broken {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := LoadJSON([]byte(tc.doc), "broken.json"); err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("expected an error containing %q, got %v", tc.want, err)
+			}
+		})
+	}
+
+	path := buildExcelFixture(t, func(f *excelize.File) {
+		cells := map[string]string{"C3": "Default Rule ID", "D3": "row2", "B10": "", "C10": "", "D11": "", "E11": "", "F11": ""}
+		for cell, value := range cells {
+			if err := f.SetCellValue(excelSheetName, cell, value); err != nil {
+				t.Fatalf("set cell %s: %v", cell, err)
+			}
+		}
+	})
+	xdt, err := LoadExcelFile(path)
+	if err != nil {
+		t.Fatalf("load excel: %v", err)
+	}
+	rows, err = xdt.Evaluate(map[string]any{"age": 10, "country": "US"}, nil)
+	if err != nil {
+		t.Fatalf("evaluate excel table: %v", err)
+	}
+	if len(xdt.Rows()) != 1 || len(rows) != 1 || !rows[0].IsDefault || rows[0].Values["tier"] != "neighbor" {
+		t.Fatalf("expected row2 as the default row, got %d rules and %#v", len(xdt.Rows()), rows)
+	}
+
+	returnNil := buildExcelFixture(t, func(f *excelize.File) {
+		cells := map[string]string{"B3": "RETURN_NIL", "C3": "Default Rule ID", "D3": "row2", "B10": "", "C10": "", "D11": "", "E11": "", "F11": ""}
+		for cell, value := range cells {
+			if err := f.SetCellValue(excelSheetName, cell, value); err != nil {
+				t.Fatalf("set cell %s: %v", cell, err)
+			}
+		}
+	})
+	if _, err := LoadExcelFile(returnNil); err == nil || !strings.Contains(err.Error(), "Default Rule ID requires the RETURN_DEFAULT or THROW_ERROR") {
+		t.Fatalf("expected RETURN_NIL with a Default Rule ID to be rejected, got %v", err)
+	}
+}
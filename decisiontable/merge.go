@@ -0,0 +1,133 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import (
+	"fmt"
+	"text/template"
+)
+
+// Merge concatenates the rows of tables that share a schema into a new table, in argument order.
+// Every table must declare the same condition and output columns (by name, type, data type, group
+// and bounds; order may differ) and the same match and no-match policies. The result takes its name,
+// column order, version and options from the first table, rows are renumbered from 1, and rule IDs
+// must be unique across all inputs. At most one input may carry a default row. Guarded defaults are
+// kept in argument order; those of different inputs must not overlap, since the order of the inputs
+// would otherwise decide which one applies. Rows keep the sanitization of their source table, and
+// the inputs are left unchanged.
+func Merge(tables ...*DecisionTable) (*DecisionTable, error) {
+	if len(tables) == 0 {
+		return nil, fmt.Errorf("merge requires at least one table")
+	}
+	for i, dt := range tables {
+		if dt == nil {
+			return nil, fmt.Errorf("table %d is nil", i)
+		}
+	}
+	first := tables[0]
+	merged := *first
+	merged.rows = nil
+	merged.defaultRow = nil
+	merged.guardedDefaults = nil
+	// the parsed templates of every input are cached again, in a map the inputs do not share
+	merged.outputTemplates = nil
+
+	owners := make(map[string]string)
+	var defaultOwner string
+	// guardedOwners[i] holds the input table and 1-based position of merged.guardedDefaults[i]
+	var guardedOwners [][2]int
+	for ti, dt := range tables {
+		if dt != first {
+			if err := checkMergeCompatible(first, dt); err != nil {
+				return nil, err
+			}
+		}
+		for _, row := range dt.rows {
+			if row.RuleID != "" {
+				if owner, dup := owners[row.RuleID]; dup {
+					return nil, fmt.Errorf("rule ID %q appears in tables %s and %s", row.RuleID, owner, dt.Name)
+				}
+				owners[row.RuleID] = dt.Name
+			}
+			row.Number = len(merged.rows) + 1
+			merged.rows = append(merged.rows, row)
+		}
+		if dt.defaultRow != nil {
+			if merged.defaultRow != nil {
+				return nil, fmt.Errorf("tables %s and %s both define a default row", defaultOwner, dt.Name)
+			}
+			copied := *dt.defaultRow
+			merged.defaultRow = &copied
+			defaultOwner = dt.Name
+		}
+		for gi, guarded := range dt.guardedDefaults {
+			for i, other := range merged.guardedDefaults {
+				if owner := guardedOwners[i]; owner[0] != ti && rulesOverlap(other, guarded) {
+					return nil, fmt.Errorf("guarded default %d of table %s overlaps guarded default %d of table %s", gi+1, dt.Name, owner[1], tables[owner[0]].Name)
+				}
+			}
+		}
+		for gi, guarded := range dt.guardedDefaults {
+			merged.guardedDefaults = append(merged.guardedDefaults, guarded)
+			guardedOwners = append(guardedOwners, [2]int{ti, gi + 1})
+		}
+		for key, tmpl := range dt.outputTemplates {
+			if merged.outputTemplates == nil {
+				merged.outputTemplates = make(map[outputTemplateKey]*template.Template)
+			}
+			merged.outputTemplates[key] = tmpl
+		}
+	}
+	if merged.defaultRow != nil {
+		merged.defaultRow.Number = len(merged.rows) + 1
+	}
+	return &merged, nil
+}
+
+func checkMergeCompatible(first, other *DecisionTable) error {
+	if first.matchPolicy != other.matchPolicy {
+		return fmt.Errorf("table %s uses match policy %s, table %s uses %s", other.Name, other.matchPolicy, first.Name, first.matchPolicy)
+	}
+	if first.noMatchPolicy != other.noMatchPolicy {
+		return fmt.Errorf("table %s uses no-match policy %s, table %s uses %s", other.Name, other.noMatchPolicy, first.Name, first.noMatchPolicy)
+	}
+	if err := sameColumns(first.conditionColumns, other.conditionColumns); err != nil {
+		return fmt.Errorf("table %s: %w", other.Name, err)
+	}
+	if err := sameColumns(first.outputColumns, other.outputColumns); err != nil {
+		return fmt.Errorf("table %s: %w", other.Name, err)
+	}
+	return nil
+}
+
+func sameColumns(want, got map[string]Column) error {
+	for name, col := range want {
+		other, ok := got[name]
+		if !ok {
+			return fmt.Errorf("missing column %s", name)
+		}
+		if col.Type != other.Type || col.DataType != other.DataType || col.Group != other.Group || col.Template != other.Template || col.Unit != other.Unit || col.Required != other.Required || col.Scale != other.Scale || col.Path != other.Path || col.MissingPolicy != other.MissingPolicy || col.Currency != other.Currency || col.CurrencyColumn != other.CurrencyColumn ||
+			formatBound(col, col.Min) != formatBound(other, other.Min) || formatBound(col, col.Max) != formatBound(other, other.Max) ||
+			(col.Default == nil) != (other.Default == nil) || formatBound(col, col.Default) != formatBound(other, other.Default) {
+			return fmt.Errorf("column %s differs", name)
+		}
+	}
+	for name := range got {
+		if _, ok := want[name]; !ok {
+			return fmt.Errorf("unexpected column %s", name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import (
+	"strings"
+	"testing"
+)
+
+func newMergeTable(t *testing.T, name string, mp MatchPolicy, ids ...string) *DecisionTable {
+	t.Helper()
+	dt, err := NewDecisionTable(name,
+		[]Column{{Name: "age", Type: ColumnTypeCondition, DataType: DataTypeInteger}},
+		[]Column{{Name: "tier", Type: ColumnTypeConclusion, DataType: DataTypeString}},
+		WithMatchPolicy(mp))
+	if err != nil {
+		t.Fatalf("new table: %v", err)
+	}
+	for i, id := range ids {
+		if err := dt.AddRow(Row{
+			RuleID:      id,
+			EvalCells:   []EvalCell{{Column: "age", Operator: OperatorGreaterOrEqual, Value: 10 * (i + 1)}},
+			ReturnCells: []ReturnCell{{Column: "tier", Value: id}},
+		}); err != nil {
+			t.Fatalf("add row: %v", err)
+		}
+	}
+	return dt
+}
+
+func TestMergeConcatenatesRowsInOrder(t *testing.T) {
+	a := newMergeTable(t, "retail", MatchPolicyAll, "r1", "r2")
+	b := newMergeTable(t, "wholesale", MatchPolicyAll, "w1")
+	if err := b.SetDefaultRow(Row{RuleID: "fallback", ReturnCells: []ReturnCell{{Column: "tier", Value: "none"}}}); err != nil {
+		t.Fatalf("set default: %v", err)
+	}
+
+	merged, err := Merge(a, b)
+	if err != nil {
+		t.Fatalf("merge: %v", err)
+	}
+	if merged.Name != "retail" || merged.RowCount() != 3 || !merged.HasDefaultRow() {
+		t.Fatalf("unexpected merged table %s with %d rows", merged.Name, merged.RowCount())
+	}
+	rows, err := merged.Evaluate(map[string]any{"age": 25}, nil)
+	if err != nil {
+		t.Fatalf("evaluate: %v", err)
+	}
+	if len(rows) != 3 || rows[0].RuleID != "r1" || rows[1].RuleID != "r2" || rows[2].RuleID != "w1" || rows[2].RowNumber != 3 {
+		t.Fatalf("unexpected merged matches %#v", rows)
+	}
+	if a.RowCount() != 2 || b.RowCount() != 1 {
+		t.Fatalf("merge must not modify its inputs")
+	}
+}
+
+func TestMergeRejectsConflicts(t *testing.T) {
+	a := newMergeTable(t, "a", MatchPolicyAll, "r1")
+	decimalAge, err := NewDecisionTable("d",
+		[]Column{{Name: "age", Type: ColumnTypeCondition, DataType: DataTypeDecimal}},
+		[]Column{{Name: "tier", Type: ColumnTypeConclusion, DataType: DataTypeString}})
+	if err != nil {
+		t.Fatalf("new table: %v", err)
+	}
+	cases := []struct {
+		name  string
+		other *DecisionTable
+		want  string
+	}{
+		{"duplicate rule", newMergeTable(t, "b", MatchPolicyAll, "r1"), `rule ID "r1" appears in tables a and b`},
+		{"policy", newMergeTable(t, "c", MatchPolicyFirst, "c1"), "match policy FIRST"},
+		{"schema", decimalAge, "column age differs"},
+	}
+
+	for _, tc := range cases {
+		if _, err := Merge(a, tc.other); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("%s: expected error containing %q, got %v", tc.name, tc.want, err)
+		}
+	}
+	if _, err := Merge(); err == nil {
+		t.Fatalf("expected merge without tables to fail")
+	}
+}
+
+func TestMergeGuardedDefaults(t *testing.T) {
+	guarded := func(name string, tier string, cells ...EvalCell) *DecisionTable {
+		t.Helper()
+		dt := newMergeTable(t, name, MatchPolicyAll, name+"-adult")
+		if err := dt.AddGuardedDefault(cells, []ReturnCell{{Column: "tier", Value: tier}}); err != nil {
+			t.Fatalf("add guarded default: %v", err)
+		}
+		return dt
+	}
+	toddler := guarded("a", "toddler", EvalCell{Column: "age", Operator: OperatorLess, Value: 3})
+	child := guarded("b", "child", EvalCell{Column: "age", Operator: OperatorBetween, Value: []any{3, 9}})
+
+	merged, err := Merge(toddler, child)
+	if err != nil {
+		t.Fatalf("merge: %v", err)
+	}
+	for age, want := range map[int]string{1: "toddler", 5: "child"} {
+		rows, err := merged.Evaluate(map[string]any{"age": age}, nil)
+		if err != nil || len(rows) != 1 || rows[0].Values["tier"] != want {
+			t.Fatalf("age %d: expected %s, got %#v (%v)", age, want, rows, err)
+		}
+	}
+
+	young := guarded("c", "young", EvalCell{Column: "age", Operator: OperatorLess, Value: 5})
+	if _, err := Merge(toddler, young); err == nil || !strings.Contains(err.Error(), "guarded default 1 of table c overlaps guarded default 1 of table a") {
+		t.Fatalf("expected overlapping guarded defaults to be rejected, got %v", err)
+	}
+}
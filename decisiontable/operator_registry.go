@@ -0,0 +1,136 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// OperatorFunc evaluates a user-defined operator. It receives the column data type, the raw input
+// value and the raw expected value exactly as authored; no coercion is applied to either side.
+type OperatorFunc func(dt DataType, actual, expected any) (bool, error)
+
+var builtinOperators = []OperatorType{
+	OperatorGreaterOrEqual,
+	OperatorGreater,
+	OperatorEqual,
+	OperatorLess,
+	OperatorLessOrEqual,
+	OperatorNotEqual,
+	OperatorIn,
+	OperatorNotIn,
+	OperatorAnyContained,
+	OperatorNotAnyContained,
+	OperatorAllContained,
+	OperatorNotAllContained,
+	OperatorContainsAll,
+	OperatorNotContainsAll,
+	OperatorSetEquals,
+	OperatorMultisetContained,
+	OperatorAllEqual,
+	OperatorMatchesRegex,
+	OperatorIsNull,
+	OperatorIsNotNull,
+	OperatorTextEqual,
+	OperatorBetween,
+	OperatorNotBetween,
+	OperatorDefault,
+}
+
+// builtinOperatorSet indexes builtinOperators by their canonical spelling.
+var builtinOperatorSet = func() map[OperatorType]struct{} {
+	set := make(map[OperatorType]struct{}, len(builtinOperators))
+	for _, op := range builtinOperators {
+		set[op] = struct{}{}
+	}
+	return set
+}()
+
+var operatorRegistry = struct {
+	sync.RWMutex
+	funcs map[OperatorType]OperatorFunc
+}{funcs: make(map[OperatorType]OperatorFunc)}
+
+// RegisterOperator makes a user-defined operator available to tables and both loaders.
+// Names are matched case-insensitively by the loaders and must not shadow a built-in operator.
+func RegisterOperator(name OperatorType, fn OperatorFunc) error {
+	key := OperatorType(normalizeKeyword(string(name)))
+	if key == "" {
+		return fmt.Errorf("operator name must not be empty")
+	}
+	if fn == nil {
+		return fmt.Errorf("operator %s requires a function", name)
+	}
+	if isBuiltinOperator(key) {
+		return fmt.Errorf("operator %s is built in and cannot be replaced", name)
+	}
+	operatorRegistry.Lock()
+	defer operatorRegistry.Unlock()
+	operatorRegistry.funcs[key] = fn
+	return nil
+}
+
+// UnregisterOperator removes a user-defined operator. Tables that already reference it will fail to evaluate.
+func UnregisterOperator(name OperatorType) {
+	operatorRegistry.Lock()
+	defer operatorRegistry.Unlock()
+	delete(operatorRegistry.funcs, OperatorType(normalizeKeyword(string(name))))
+}
+
+// lookupOperator finds the registered operator named op, matching names the way RegisterOperator
+// stores them. Built-in operators return early, so ordinary cells never take the registry lock.
+func lookupOperator(op OperatorType) (OperatorFunc, bool) {
+	if _, builtin := builtinOperatorSet[op]; builtin || isAggregateOperator(op) {
+		return nil, false
+	}
+	key := OperatorType(normalizeKeyword(string(op)))
+	operatorRegistry.RLock()
+	defer operatorRegistry.RUnlock()
+	fn, ok := operatorRegistry.funcs[key]
+	return fn, ok
+}
+
+func isCustomOperator(op OperatorType) bool {
+	_, ok := lookupOperator(op)
+	return ok
+}
+
+// lookupOperatorToken resolves a loader token to a registered operator, ignoring case and underscores
+// so that both JSON camelCase ("withinGeofence") and Excel ("WITHIN_GEOFENCE") spellings match.
+func lookupOperatorToken(token string) (OperatorType, bool) {
+	want := strings.ReplaceAll(normalizeKeyword(token), "_", "")
+	operatorRegistry.RLock()
+	defer operatorRegistry.RUnlock()
+	for name := range operatorRegistry.funcs {
+		if strings.ReplaceAll(string(name), "_", "") == want {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+func isBuiltinOperator(op OperatorType) bool {
+	if isAggregateOperator(OperatorType(strings.ToUpper(string(op)))) {
+		return true
+	}
+	for _, builtin := range builtinOperators {
+		if strings.EqualFold(string(builtin), string(op)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,190 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func registerWithinRange(t *testing.T) {
+	t.Helper()
+	err := RegisterOperator("WITHIN_RANGE", func(dt DataType, actual, expected any) (bool, error) {
+		bounds, ok := expected.([]any)
+		if !ok || len(bounds) != 2 {
+			return false, fmt.Errorf("WITHIN_RANGE expects two bounds, got %#v", expected)
+		}
+		value, err := toInt64(actual)
+		if err != nil {
+			return false, err
+		}
+		lo, err := toInt64(bounds[0])
+		if err != nil {
+			return false, err
+		}
+		hi, err := toInt64(bounds[1])
+		if err != nil {
+			return false, err
+		}
+		return value >= lo && value <= hi, nil
+	})
+	if err != nil {
+		t.Fatalf("register operator: %v", err)
+	}
+	t.Cleanup(func() { UnregisterOperator("WITHIN_RANGE") })
+}
+
+func TestRegisterOperatorRejectsBuiltins(t *testing.T) {
+	if err := RegisterOperator(OperatorEqual, func(DataType, any, any) (bool, error) { return true, nil }); err == nil {
+		t.Fatalf("expected built-in operator to be protected")
+	}
+	if err := RegisterOperator("CUSTOM", nil); err == nil {
+		t.Fatalf("expected nil function to be rejected")
+	}
+}
+
+func TestCustomOperatorProgrammatic(t *testing.T) {
+	registerWithinRange(t)
+
+	evalCols := []Column{{Name: "score", Type: ColumnTypeCondition, DataType: DataTypeInteger}}
+	retCols := []Column{{Name: "band", Type: ColumnTypeConclusion, DataType: DataTypeString}}
+	dt, err := NewDecisionTable("bands", evalCols, retCols)
+	if err != nil {
+		t.Fatalf("failed to build table: %v", err)
+	}
+	err = dt.AddRow(Row{
+		RuleID:      "mid",
+		EvalCells:   []EvalCell{{Column: "score", Operator: "WITHIN_RANGE", Value: []any{10, 20}}},
+		ReturnCells: []ReturnCell{{Column: "band", Value: "mid"}},
+	})
+	if err != nil {
+		t.Fatalf("failed to add row: %v", err)
+	}
+	rows, err := dt.Evaluate(map[string]any{"score": 15}, nil)
+	if err != nil {
+		t.Fatalf("evaluate returned error: %v", err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("expected custom operator match, got %#v", rows)
+	}
+	if _, err := dt.Evaluate(map[string]any{"score": 25}, nil); err == nil {
+		t.Fatalf("expected no match outside range")
+	}
+}
+
+func TestCustomOperatorNameIgnoresCase(t *testing.T) {
+	err := RegisterOperator("within_range", func(dt DataType, actual, expected any) (bool, error) {
+		return actual != nil, nil
+	})
+	if err != nil {
+		t.Fatalf("register operator: %v", err)
+	}
+	t.Cleanup(func() { UnregisterOperator("within_range") })
+
+	for _, spelling := range []OperatorType{"WITHIN_RANGE", "within_range", "Within_Range"} {
+		dt, err := NewDecisionTable("bands",
+			[]Column{{Name: "score", Type: ColumnTypeCondition, DataType: DataTypeInteger}},
+			[]Column{{Name: "band", Type: ColumnTypeConclusion, DataType: DataTypeString}})
+		if err != nil {
+			t.Fatalf("new table: %v", err)
+		}
+		if err := dt.AddRow(Row{
+			RuleID:      "any",
+			EvalCells:   []EvalCell{{Column: "score", Operator: spelling, Value: []any{10, 20}}},
+			ReturnCells: []ReturnCell{{Column: "band", Value: "set"}},
+		}); err != nil {
+			t.Fatalf("add row with %s: %v", spelling, err)
+		}
+		rows, err := dt.Evaluate(map[string]any{"score": 15}, nil)
+		if err != nil {
+			t.Fatalf("evaluate with %s: %v", spelling, err)
+		}
+		if len(rows) != 1 || rows[0].Values["band"] != "set" {
+			t.Fatalf("expected %s to resolve to the registered operator, got %#v", spelling, rows)
+		}
+	}
+}
+
+func TestCustomOperatorLoaders(t *testing.T) {
+	registerWithinRange(t)
+
+	const doc = `
+{
+  "decisionTable": {
+    "name": "bands",
+    "policies": {"matchPolicy": "FIRST", "noMatchPolicy": "THROW_ERROR"},
+    "columns": [
+      {"name": "score", "type": "CONDITION", "dataType": "INTEGER"},
+      {"name": "band", "type": "CONCLUSION", "dataType": "STRING"}
+    ],
+    "rules": [
+      {"id": "mid", "when": [{"operator": "withinRange", "value": [10, 20]}], "then": ["mid"]}
+    ]
+  }
+}`
+	dt, err := LoadJSON([]byte(doc), "bands.json")
+	if err != nil {
+		t.Fatalf("load json: %v", err)
+	}
+	if rows, err := dt.Evaluate(map[string]any{"score": 12}, nil); err != nil || len(rows) != 1 {
+		t.Fatalf("expected json custom operator match, got %#v, %v", rows, err)
+	}
+
+	if op, err := parseOperatorToken("within_range"); err != nil || op != "WITHIN_RANGE" {
+		t.Fatalf("expected excel token to resolve, got %q, %v", op, err)
+	}
+}
+
+func TestCompatibleOperatorsFollowTheMatrix(t *testing.T) {
+	registerWithinRange(t)
+	all := SupportedOperators()
+	for _, op := range []OperatorType{OperatorMatchesRegex, OperatorSetEquals, "SUM_GT", "LENGTH_LT", "WITHIN_RANGE"} {
+		if !slices.Contains(all, op) {
+			t.Fatalf("expected %s among the supported operators", op)
+		}
+	}
+	if len(SupportedDataTypes()) != 10 {
+		t.Fatalf("expected ten data types, got %v", SupportedDataTypes())
+	}
+
+	integer := CompatibleOperators(DataTypeInteger)
+	for _, op := range []OperatorType{OperatorMatchesRegex, OperatorContainsAll, "LENGTH_GT", "SUM_EQ"} {
+		if slices.Contains(integer, op) {
+			t.Fatalf("expected %s to be unavailable for INTEGER columns", op)
+		}
+	}
+	for _, op := range []OperatorType{OperatorBetween, OperatorIn, "WITHIN_RANGE"} {
+		if !slices.Contains(integer, op) {
+			t.Fatalf("expected %s to be available for INTEGER columns", op)
+		}
+	}
+	if str := CompatibleOperators(DataTypeString); !slices.Contains(str, OperatorMatchesRegex) || slices.Contains(str, OperatorGreater) {
+		t.Fatalf("unexpected STRING operators %v", str)
+	}
+	if list := CompatibleOperators(DataTypeListString); !slices.Contains(list, "COUNT_GT") || slices.Contains(list, "SUM_GT") {
+		t.Fatalf("unexpected LIST_STRING operators %v", list)
+	}
+	for _, dt := range SupportedDataTypes() {
+		for _, op := range CompatibleOperators(dt) {
+			if err := checkOperatorDataType(op, dt); err != nil {
+				t.Fatalf("listed %s for %s but the loaders reject it: %v", op, dt, err)
+			}
+		}
+	}
+	if CompatibleOperators("MONEY") != nil {
+		t.Fatalf("expected no operators for an unknown data type")
+	}
+}
@@ -21,7 +21,20 @@ import (
 	"time"
 )
 
-func evaluateCell(dt DataType, op OperatorType, actual any, expected any) (bool, error) {
+func evaluateCell(dt DataType, op OperatorType, actual any, expected any, interval string) (bool, error) {
+	if fn, ok := lookupOperator(op); ok {
+		return fn(dt, actual, expected)
+	}
+	if op == OperatorTextEqual {
+		if actual == nil {
+			return false, nil
+		}
+		text, err := decimalText(actual)
+		if err != nil {
+			return false, err
+		}
+		return text == expected, nil
+	}
 	if expectsActualCollection(op) {
 		actualSlice, err := sanitizeActualCollection(dt, actual)
 		if err != nil {
@@ -34,9 +47,39 @@ func evaluateCell(dt DataType, op OperatorType, actual any, expected any) (bool,
 	if err != nil {
 		return false, err
 	}
+	if isStringLengthOperator(op) {
+		return evaluateStringLength(op, actualValue, expected)
+	}
+	if isBetweenOperator(op) {
+		inside, err := inRange(dt, actualValue, expected, interval)
+		if op == OperatorNotBetween {
+			return actualValue == nil || !inside, err
+		}
+		return inside, err
+	}
 	return evaluateScalarOperator(dt, op, actualValue, expected)
 }
 
+// inRange reports whether actual lies between the two bounds, honouring the interval brackets.
+func inRange(dt DataType, actual any, expected any, interval string) (bool, error) {
+	bounds, ok := expected.([]any)
+	if !ok || len(bounds) != 2 {
+		return false, fmt.Errorf("operator BETWEEN expects two bounds, got %T", expected)
+	}
+	lowOp, highOp := OperatorGreaterOrEqual, OperatorLessOrEqual
+	if interval != "" && interval[0] == '(' {
+		lowOp = OperatorGreater
+	}
+	if interval != "" && interval[len(interval)-1] == ')' {
+		highOp = OperatorLess
+	}
+	above, err := compare(dt, lowOp, actual, bounds[0])
+	if err != nil || !above {
+		return false, err
+	}
+	return compare(dt, highOp, actual, bounds[1])
+}
+
 func evaluateScalarOperator(dt DataType, op OperatorType, actual any, expected any) (bool, error) {
 	switch op {
 	case OperatorEqual:
@@ -82,6 +125,9 @@ func evaluateScalarOperator(dt DataType, op OperatorType, actual any, expected a
 }
 
 func evaluateCollectionOperator(dt DataType, op OperatorType, actual []any, expected any) (bool, error) {
+	if isAggregateOperator(op) {
+		return evaluateAggregate(dt, op, actual, expected)
+	}
 	switch op {
 	case OperatorAnyContained:
 		expectedSlice, ok := expected.([]any)
@@ -124,12 +170,19 @@ func evaluateCollectionOperator(dt DataType, op OperatorType, actual []any, expe
 		if !ok {
 			return false, fmt.Errorf("operator CONTAINS_ALL expects slice value, got %T", expected)
 		}
-		for _, v := range expectedSlice {
-			match, err := containsValue(dt, actual, v)
-			if err != nil {
-				return false, err
+		for _, want := range expectedSlice {
+			found := false
+			for _, v := range actual {
+				match, err := equals(elementDataType(dt), v, want)
+				if err != nil {
+					return false, err
+				}
+				if match {
+					found = true
+					break
+				}
 			}
-			if !match {
+			if !found {
 				return false, nil
 			}
 		}
@@ -137,12 +190,58 @@ func evaluateCollectionOperator(dt DataType, op OperatorType, actual []any, expe
 	case OperatorNotContainsAll:
 		match, err := evaluateCollectionOperator(dt, OperatorContainsAll, actual, expected)
 		return !match, err
+	case OperatorSetEquals:
+		expectedSlice, ok := expected.([]any)
+		if !ok {
+			return false, fmt.Errorf("operator SET_EQ expects slice value, got %T", expected)
+		}
+		if actual == nil {
+			return false, nil
+		}
+		// each side must contain every element of the other, so order and duplicates do not matter
+		subset, err := evaluateCollectionOperator(dt, OperatorAllContained, actual, expectedSlice)
+		if err != nil || !subset {
+			return false, err
+		}
+		return evaluateCollectionOperator(dt, OperatorContainsAll, actual, expectedSlice)
+	case OperatorMultisetContained:
+		expectedSlice, ok := expected.([]any)
+		if !ok {
+			return false, fmt.Errorf("operator MULTISET_CONTAINED_IN expects slice value, got %T", expected)
+		}
+		// tally the expected values so each one is consumed by a single input element
+		remaining := make([]bool, len(expectedSlice))
+		for i := range remaining {
+			remaining[i] = true
+		}
+		elemType := elementDataType(dt)
+		for _, v := range actual {
+			found := false
+			for i, want := range expectedSlice {
+				if !remaining[i] {
+					continue
+				}
+				match, err := equals(elemType, v, want)
+				if err != nil {
+					return false, err
+				}
+				if match {
+					remaining[i] = false
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false, nil
+			}
+		}
+		return true, nil
 	case OperatorAllEqual:
 		if expected == nil {
 			return false, fmt.Errorf("operator ALL_EQUAL expects a scalar value")
 		}
 		for _, v := range actual {
-			match, err := equals(dt, v, expected)
+			match, err := equals(elementDataType(dt), v, expected)
 			if err != nil {
 				return false, err
 			}
@@ -156,6 +255,8 @@ func evaluateCollectionOperator(dt DataType, op OperatorType, actual []any, expe
 	}
 }
 
+// equals compares an actual value (left) with an expected rule value (right); type mismatch errors
+// label the two sides accordingly.
 func equals(dt DataType, left any, right any) (bool, error) {
 	if left == nil || right == nil {
 		return left == nil && right == nil, nil
@@ -165,65 +266,83 @@ func equals(dt DataType, left any, right any) (bool, error) {
 		lhs, lok := left.(string)
 		rhs, rok := right.(string)
 		if !lok || !rok {
-			return false, fmt.Errorf("values not strings: %T vs %T", left, right)
+			return false, fmt.Errorf("values not strings: actual %T vs expected %T", left, right)
 		}
 		return lhs == rhs, nil
 	case DataTypeInteger:
 		lhs, lok := left.(int64)
 		rhs, rok := right.(int64)
 		if !lok || !rok {
-			return false, fmt.Errorf("values not int64: %T vs %T", left, right)
+			return false, fmt.Errorf("values not int64: actual %T vs expected %T", left, right)
 		}
 		return lhs == rhs, nil
 	case DataTypeDecimal:
 		lbd, lok := left.(*big.Float)
 		rbd, rok := right.(*big.Float)
 		if !lok || !rok {
-			return false, fmt.Errorf("values not decimal: %T vs %T", left, right)
+			return false, fmt.Errorf("values not decimal: actual %T vs expected %T", left, right)
 		}
 		return lbd.Cmp(rbd) == 0, nil
 	case DataTypeBoolean:
 		lhs, lok := left.(bool)
 		rhs, rok := right.(bool)
 		if !lok || !rok {
-			return false, fmt.Errorf("values not bool: %T vs %T", left, right)
+			return false, fmt.Errorf("values not bool: actual %T vs expected %T", left, right)
 		}
 		return lhs == rhs, nil
 	case DataTypeDate, DataTypeDateTime:
 		lhs, lok := left.(time.Time)
 		rhs, rok := right.(time.Time)
 		if !lok || !rok {
-			return false, fmt.Errorf("values not time.Time: %T vs %T", left, right)
+			return false, fmt.Errorf("values not time.Time: actual %T vs expected %T", left, right)
 		}
 		return lhs.Equal(rhs), nil
+	case DataTypeDuration:
+		lhs, lok := left.(time.Duration)
+		rhs, rok := right.(time.Duration)
+		if !lok || !rok {
+			return false, fmt.Errorf("values not time.Duration: actual %T vs expected %T", left, right)
+		}
+		return lhs == rhs, nil
 	case DataTypeListString:
 		return equalsList(DataTypeString, left, right)
 	case DataTypeListInteger:
 		return equalsList(DataTypeInteger, left, right)
+	case DataTypeListDecimal:
+		return equalsList(DataTypeDecimal, left, right)
 	default:
 		return false, fmt.Errorf("unsupported data type %s", dt)
 	}
 }
 
+// compare orders an actual value (left) against an expected rule value (right).
 func compare(dt DataType, op OperatorType, left any, right any) (bool, error) {
 	if left == nil || right == nil {
 		return false, nil
 	}
-	var l, r float64
+	var l, r int64
 	switch dt {
 	case DataTypeInteger:
 		lv, lok := left.(int64)
 		rv, rok := right.(int64)
 		if !lok || !rok {
-			return false, fmt.Errorf("values not int64: %T vs %T", left, right)
+			return false, fmt.Errorf("values not int64: actual %T vs expected %T", left, right)
+		}
+		l = lv
+		r = rv
+	case DataTypeDuration:
+		lv, lok := left.(time.Duration)
+		rv, rok := right.(time.Duration)
+		if !lok || !rok {
+			return false, fmt.Errorf("values not time.Duration: actual %T vs expected %T", left, right)
 		}
-		l = float64(lv)
-		r = float64(rv)
+		l = int64(lv)
+		r = int64(rv)
 	case DataTypeDecimal:
 		lbd, lok := left.(*big.Float)
 		rbd, rok := right.(*big.Float)
 		if !lok || !rok {
-			return false, fmt.Errorf("values not decimal: %T vs %T", left, right)
+			return false, fmt.Errorf("values not decimal: actual %T vs expected %T", left, right)
 		}
 		switch op {
 		case OperatorGreater:
@@ -241,7 +360,7 @@ func compare(dt DataType, op OperatorType, left any, right any) (bool, error) {
 		lTime, lok := left.(time.Time)
 		rTime, rok := right.(time.Time)
 		if !lok || !rok {
-			return false, fmt.Errorf("values not time.Time: %T vs %T", left, right)
+			return false, fmt.Errorf("values not time.Time: actual %T vs expected %T", left, right)
 		}
 		switch op {
 		case OperatorGreater:
@@ -273,10 +392,11 @@ func compare(dt DataType, op OperatorType, left any, right any) (bool, error) {
 	}
 }
 
+// containsValue reports whether the actual needle equals one of the expected haystack values.
 func containsValue(dt DataType, haystack []any, needle any) (bool, error) {
 	elemType := elementDataType(dt)
 	for _, candidate := range haystack {
-		match, err := equals(elemType, candidate, needle)
+		match, err := equals(elemType, needle, candidate)
 		if err != nil {
 			return false, err
 		}
@@ -291,7 +411,7 @@ func equalsList(elemType DataType, left any, right any) (bool, error) {
 	lhs, lok := left.([]any)
 	rhs, rok := right.([]any)
 	if !lok || !rok {
-		return false, fmt.Errorf("values not list: %T vs %T", left, right)
+		return false, fmt.Errorf("values not list: actual %T vs expected %T", left, right)
 	}
 	if len(lhs) != len(rhs) {
 		return false, nil
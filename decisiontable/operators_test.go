@@ -0,0 +1,710 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import (
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTypeMismatchErrorsLabelActualAndExpected(t *testing.T) {
+	now := time.Now()
+	cases := []struct {
+		name string
+		call func() (bool, error)
+		want string
+	}{
+		{"string", func() (bool, error) { return equals(DataTypeString, int64(1), "a") }, "actual int64 vs expected string"},
+		{"integer", func() (bool, error) { return equals(DataTypeInteger, "1", int64(1)) }, "actual string vs expected int64"},
+		{"decimal", func() (bool, error) { return equals(DataTypeDecimal, 1.5, big.NewFloat(1.5)) }, "actual float64 vs expected *big.Float"},
+		{"boolean", func() (bool, error) { return equals(DataTypeBoolean, "true", true) }, "actual string vs expected bool"},
+		{"date", func() (bool, error) { return equals(DataTypeDate, "2025-01-01", now) }, "actual string vs expected time.Time"},
+		{"list", func() (bool, error) { return equals(DataTypeListString, "a", []any{"a"}) }, "actual string vs expected []interface {}"},
+		{"compare integer", func() (bool, error) { return compare(DataTypeInteger, OperatorGreater, 1.0, int64(1)) }, "actual float64 vs expected int64"},
+		{"compare decimal", func() (bool, error) { return compare(DataTypeDecimal, OperatorLess, big.NewFloat(1), "2") }, "actual *big.Float vs expected string"},
+		{"compare datetime", func() (bool, error) { return compare(DataTypeDateTime, OperatorLess, now, "later") }, "actual time.Time vs expected string"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.call()
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("expected error containing %q, got %v", tc.want, err)
+			}
+		})
+	}
+}
+
+func TestCellErrorsNameColumnAndOperator(t *testing.T) {
+	row := Row{
+		Number: 3,
+		EvalCells: []EvalCell{
+			{Column: "age", Operator: OperatorEqual, Value: "thirty", dataType: DataTypeInteger},
+		},
+	}
+	_, err := row.matches(MapInput{"age": 30})
+	want := "row 3 column age: operator EQ: values not int64: actual int64 vs expected string"
+	if err == nil || err.Error() != want {
+		t.Fatalf("expected %q, got %v", want, err)
+	}
+}
+
+func TestDurationComparisons(t *testing.T) {
+	dt, err := NewDecisionTable("latency",
+		[]Column{{Name: "latency", Type: ColumnTypeCondition, DataType: DataTypeDuration}},
+		[]Column{{Name: "status", Type: ColumnTypeConclusion, DataType: DataTypeString}},
+		WithMatchPolicy(MatchPolicyFirst),
+		WithNoMatchPolicy(NoMatchPolicyReturnDefault),
+	)
+	if err != nil {
+		t.Fatalf("failed to build table: %v", err)
+	}
+	rows := []Row{
+		{RuleID: "slow", EvalCells: []EvalCell{{Column: "latency", Operator: OperatorGreater, Value: "500ms"}}},
+		{RuleID: "ok", EvalCells: []EvalCell{{Column: "latency", Operator: OperatorBetween, Value: []any{"100ms", 500 * time.Millisecond}}}},
+		{RuleID: "fast", EvalCells: []EvalCell{{Column: "latency", Operator: OperatorLess, Value: "0.1s"}}},
+	}
+	for _, row := range rows {
+		row.ReturnCells = []ReturnCell{{Column: "status", Value: row.RuleID}}
+		if err := dt.AddRow(row); err != nil {
+			t.Fatalf("failed to add row %s: %v", row.RuleID, err)
+		}
+	}
+
+	cases := []struct {
+		input any
+		want  string
+	}{
+		{200 * time.Millisecond, "ok"},
+		{"0.2s", "ok"},
+		{"500ms", "ok"},
+		{"100ms", "ok"},
+		{time.Second, "slow"},
+		{"1m", "slow"},
+		{"99ms", "fast"},
+		{int64(50 * time.Millisecond), "fast"},
+	}
+	for _, tc := range cases {
+		matches, err := dt.Evaluate(map[string]any{"latency": tc.input}, nil)
+		if err != nil {
+			t.Fatalf("evaluate %v returned error: %v", tc.input, err)
+		}
+		if len(matches) != 1 || matches[0].RuleID != tc.want {
+			t.Fatalf("latency %v: expected %s, got %#v", tc.input, tc.want, matches)
+		}
+	}
+
+	if _, err := dt.Evaluate(map[string]any{"latency": "fast"}, nil); err == nil {
+		t.Fatalf("expected invalid duration input to fail")
+	}
+}
+
+func TestBetweenRejectsInvalidBounds(t *testing.T) {
+	cases := []struct {
+		dt  DataType
+		raw any
+	}{
+		{DataTypeDuration, []any{"2s", "1s"}},
+		{DataTypeInteger, []any{1}},
+		{DataTypeString, []any{"a", "b"}},
+	}
+	for _, tc := range cases {
+		if _, err := sanitizeExpectedValue(tc.dt, OperatorBetween, tc.raw, "", "", false); err == nil {
+			t.Fatalf("expected BETWEEN %v on %s to be rejected", tc.raw, tc.dt)
+		}
+	}
+}
+
+func TestIntervalBoundaries(t *testing.T) {
+	cases := []struct {
+		interval       string
+		atLow, atHigh  bool
+		belowLow, over bool
+	}{
+		{"", true, true, false, false},
+		{"[)", true, false, false, false},
+		{"(]", false, true, false, false},
+		{"()", false, false, false, false},
+	}
+	for _, tc := range cases {
+		cell := EvalCell{Column: "n", Operator: OperatorBetween, Value: []any{int64(10), int64(20)}, Interval: tc.interval, dataType: DataTypeInteger}
+		notCell := cell
+		notCell.Operator = OperatorNotBetween
+		for _, probe := range []struct {
+			input any
+			want  bool
+		}{{10, tc.atLow}, {20, tc.atHigh}, {9, tc.belowLow}, {21, tc.over}, {15, true}} {
+			got, err := cell.evaluate(probe.input)
+			if err != nil || got != probe.want {
+				t.Fatalf("BETWEEN %q with %v: got %v %v, want %v", tc.interval, probe.input, got, err, probe.want)
+			}
+			got, err = notCell.evaluate(probe.input)
+			if err != nil || got == probe.want {
+				t.Fatalf("NOT_BETWEEN %q with %v: got %v %v, want %v", tc.interval, probe.input, got, err, !probe.want)
+			}
+		}
+		if got, _ := notCell.evaluate(nil); !got {
+			t.Fatalf("NOT_BETWEEN %q expected a null input to match", tc.interval)
+		}
+	}
+}
+
+func TestIntervalNotation(t *testing.T) {
+	dt, err := NewDecisionTable("buckets",
+		[]Column{{Name: "score", Type: ColumnTypeCondition, DataType: DataTypeDecimal}},
+		[]Column{{Name: "bucket", Type: ColumnTypeConclusion, DataType: DataTypeString}},
+		WithMatchPolicy(MatchPolicyFirst))
+	if err != nil {
+		t.Fatalf("new table: %v", err)
+	}
+	for _, text := range []string{"[0..0.5)", "BETWEEN [0.5..1)", "NOT_BETWEEN 0,1"} {
+		op, operand, err := parseConditionString(text, DataTypeDecimal)
+		if err != nil {
+			t.Fatalf("parse %q: %v", text, err)
+		}
+		if err := dt.AddRow(Row{
+			EvalCells:   []EvalCell{{Column: "score", Operator: op, Value: operand}},
+			ReturnCells: []ReturnCell{{Column: "bucket", Value: text}},
+		}); err != nil {
+			t.Fatalf("add %q: %v", text, err)
+		}
+	}
+	for input, want := range map[string]string{"0": "[0..0.5)", "0.5": "BETWEEN [0.5..1)", "2": "NOT_BETWEEN 0,1"} {
+		rows, err := dt.Evaluate(map[string]any{"score": input}, nil)
+		if err != nil || len(rows) != 1 || rows[0].Values["bucket"] != want {
+			t.Fatalf("score %s: expected %q, got %#v %v", input, want, rows, err)
+		}
+	}
+	text, err := formatConditionString(dt.Rows()[1].EvalCells[0])
+	if err != nil || text != "BETWEEN [0.5..1)" {
+		t.Fatalf("unexpected formatted interval %q %v", text, err)
+	}
+	if got := explainCondition(dt.Rows()[2].EvalCells[0]); got != "score not between 0 and 1" {
+		t.Fatalf("unexpected explanation %q", got)
+	}
+
+	for _, raw := range []any{"[5..5)", "[1..2..3]"} {
+		err := dt.AddRow(Row{
+			EvalCells:   []EvalCell{{Column: "score", Operator: OperatorBetween, Value: raw}},
+			ReturnCells: []ReturnCell{{Column: "bucket", Value: "bad"}},
+		})
+		if err == nil {
+			t.Fatalf("expected interval %v to be rejected", raw)
+		}
+	}
+	if err := dt.AddRow(Row{
+		EvalCells:   []EvalCell{{Column: "score", Operator: OperatorBetween, Value: []any{1, 2}, Interval: "[["}},
+		ReturnCells: []ReturnCell{{Column: "bucket", Value: "bad"}},
+	}); err == nil || !strings.Contains(err.Error(), "unknown interval") {
+		t.Fatalf("expected an unknown interval to be rejected, got %v", err)
+	}
+	if err := dt.AddRow(Row{
+		EvalCells:   []EvalCell{{Column: "score", Operator: OperatorBetween, Value: []any{1, 2}, Flags: "[)"}},
+		ReturnCells: []ReturnCell{{Column: "bucket", Value: "bad"}},
+	}); err == nil || !strings.Contains(err.Error(), "regex flags only supported") {
+		t.Fatalf("expected regex flags on BETWEEN to be rejected, got %v", err)
+	}
+}
+
+func TestMaxStringLengthRejectsOversizedRegexInput(t *testing.T) {
+	if _, err := NewDecisionTable("bad", []Column{{Name: "code", Type: ColumnTypeCondition, DataType: DataTypeString}}, []Column{{Name: "out", Type: ColumnTypeConclusion, DataType: DataTypeString}}, WithMaxStringLength(-1)); err == nil {
+		t.Fatalf("expected negative max string length to be rejected")
+	}
+	dt, err := NewDecisionTable("codes",
+		[]Column{{Name: "code", Type: ColumnTypeCondition, DataType: DataTypeString}},
+		[]Column{{Name: "out", Type: ColumnTypeConclusion, DataType: DataTypeString}},
+		WithMatchPolicy(MatchPolicyFirst), WithMaxStringLength(1024))
+	if err != nil {
+		t.Fatalf("new table: %v", err)
+	}
+	if err := dt.AddRow(Row{
+		EvalCells:   []EvalCell{{Column: "code", Operator: OperatorMatchesRegex, Value: "(a|aa)*b$"}},
+		ReturnCells: []ReturnCell{{Column: "out", Value: "hit"}},
+	}); err != nil {
+		t.Fatalf("add row: %v", err)
+	}
+
+	huge := strings.Repeat("a", 1<<20)
+	if _, err := dt.Evaluate(map[string]any{"code": huge}, nil); err == nil || !strings.Contains(err.Error(), "input length 1048576 exceeds maximum 1024") {
+		t.Fatalf("expected oversized input to be rejected, got %v", err)
+	}
+	rows, err := dt.Evaluate(map[string]any{"code": "aaab"}, nil)
+	if err != nil || len(rows) != 1 {
+		t.Fatalf("expected short input to match, got %v %v", rows, err)
+	}
+}
+
+func TestAllEqualEmptyInput(t *testing.T) {
+	build := func(opts ...Option) *DecisionTable {
+		t.Helper()
+		dt, err := NewDecisionTable("transactions",
+			[]Column{{Name: "statuses", Type: ColumnTypeCondition, DataType: DataTypeListString}},
+			[]Column{{Name: "out", Type: ColumnTypeConclusion, DataType: DataTypeString}},
+			append([]Option{WithMatchPolicy(MatchPolicyFirst), WithAllowEmptyCollections()}, opts...)...)
+		if err != nil {
+			t.Fatalf("new table: %v", err)
+		}
+		if err := dt.AddRow(Row{
+			RuleID:      "all-approved",
+			EvalCells:   []EvalCell{{Column: "statuses", Operator: OperatorAllEqual, Value: "APPROVED"}},
+			ReturnCells: []ReturnCell{{Column: "out", Value: "approved"}},
+		}); err != nil {
+			t.Fatalf("add row: %v", err)
+		}
+		if err := dt.SetDefaultRow(Row{ReturnCells: []ReturnCell{{Column: "out", Value: "review"}}}); err != nil {
+			t.Fatalf("set default row: %v", err)
+		}
+		return dt
+	}
+
+	cases := []struct {
+		input   any
+		strict  string
+		vacuous string
+	}{
+		{[]string{}, "review", "approved"},
+		{nil, "review", "review"},
+		{[]string{"APPROVED", "APPROVED"}, "approved", "approved"},
+		{[]string{"APPROVED", "DECLINED"}, "review", "review"},
+	}
+	strict, vacuous := build(), build(WithVacuousAllEqual())
+	for _, tc := range cases {
+		for _, run := range []struct {
+			dt   *DecisionTable
+			want string
+		}{{strict, tc.strict}, {vacuous, tc.vacuous}} {
+			rows, err := run.dt.Evaluate(map[string]any{"statuses": tc.input}, nil)
+			if err != nil {
+				t.Fatalf("evaluate %v returned error: %v", tc.input, err)
+			}
+			if len(rows) != 1 || rows[0].Values["out"] != run.want {
+				t.Fatalf("input %v (vacuous %v): expected %s, got %#v", tc.input, run.dt == vacuous, run.want, rows)
+			}
+		}
+	}
+}
+
+func TestSetEqualsIgnoresOrderAndDuplicates(t *testing.T) {
+	dt, err := NewDecisionTable("bundles",
+		[]Column{{Name: "products", Type: ColumnTypeCondition, DataType: DataTypeListString}},
+		[]Column{{Name: "bundle", Type: ColumnTypeConclusion, DataType: DataTypeString}},
+		WithMatchPolicy(MatchPolicyFirst), WithNoMatchPolicy(NoMatchPolicyReturnDefault))
+	if err != nil {
+		t.Fatalf("new table: %v", err)
+	}
+	op, value, err := parseConditionString("SET_EQ tv,internet", DataTypeListString)
+	if err != nil || op != OperatorSetEquals {
+		t.Fatalf("parse SET_EQ: %v %v", op, err)
+	}
+	if err := dt.AddRow(Row{
+		RuleID:      "duo",
+		EvalCells:   []EvalCell{{Column: "products", Operator: op, Value: value}},
+		ReturnCells: []ReturnCell{{Column: "bundle", Value: "duo"}},
+	}); err != nil {
+		t.Fatalf("add row: %v", err)
+	}
+	if err := dt.SetDefaultRow(Row{ReturnCells: []ReturnCell{{Column: "bundle", Value: "none"}}}); err != nil {
+		t.Fatalf("set default row: %v", err)
+	}
+
+	cases := []struct {
+		input any
+		want  string
+	}{
+		{[]string{"tv", "internet"}, "duo"},
+		{[]string{"internet", "tv"}, "duo"},
+		{[]string{"tv", "internet", "tv"}, "duo"},
+		{[]string{"tv"}, "none"},
+		{[]string{"tv", "internet", "phone"}, "none"},
+		{[]string{}, "none"},
+		{nil, "none"},
+	}
+	for _, tc := range cases {
+		rows, err := dt.Evaluate(map[string]any{"products": tc.input}, nil)
+		if err != nil {
+			t.Fatalf("evaluate %v returned error: %v", tc.input, err)
+		}
+		if len(rows) != 1 || rows[0].Values["bundle"] != tc.want {
+			t.Fatalf("input %v: expected %s, got %#v", tc.input, tc.want, rows)
+		}
+	}
+
+	// EQ on the same list keeps comparing order and length
+	if match, err := equalsList(DataTypeString, []any{"internet", "tv"}, []any{"tv", "internet"}); err != nil || match {
+		t.Fatalf("expected EQ to stay order sensitive, got %v (%v)", match, err)
+	}
+	for _, token := range []string{"setEquals", "setEq", "SET_EQ"} {
+		if op, err := parseJSONOperatorToken(token); err != nil || op != OperatorSetEquals {
+			t.Fatalf("parse %s: %v %v", token, op, err)
+		}
+	}
+	if err := checkOperatorDataType(OperatorSetEquals, DataTypeString); err == nil {
+		t.Fatalf("expected SET_EQ to be rejected on scalar columns")
+	}
+	// type errors name the input element as actual and the rule value as expected
+	if _, err := evaluateCollectionOperator(DataTypeListInteger, OperatorSetEquals, []any{int64(1)}, []any{"1"}); err == nil || !strings.Contains(err.Error(), "actual int64 vs expected string") {
+		t.Fatalf("expected labelled type error, got %v", err)
+	}
+}
+
+func TestMultisetContainedCountsDuplicates(t *testing.T) {
+	dt, err := NewDecisionTable("inventory",
+		[]Column{{Name: "items", Type: ColumnTypeCondition, DataType: DataTypeListString}},
+		[]Column{{Name: "stock", Type: ColumnTypeConclusion, DataType: DataTypeString}},
+		WithMatchPolicy(MatchPolicyFirst), WithNoMatchPolicy(NoMatchPolicyReturnDefault))
+	if err != nil {
+		t.Fatalf("new table: %v", err)
+	}
+	op, value, err := parseConditionString("MULTISET_CONTAINED_IN bolt,bolt,nut", DataTypeListString)
+	if err != nil || op != OperatorMultisetContained {
+		t.Fatalf("parse MULTISET_CONTAINED_IN: %v %v", op, err)
+	}
+	if err := dt.AddRow(Row{
+		RuleID:      "in-stock",
+		EvalCells:   []EvalCell{{Column: "items", Operator: op, Value: value}},
+		ReturnCells: []ReturnCell{{Column: "stock", Value: "ok"}},
+	}); err != nil {
+		t.Fatalf("add row: %v", err)
+	}
+	if err := dt.SetDefaultRow(Row{ReturnCells: []ReturnCell{{Column: "stock", Value: "short"}}}); err != nil {
+		t.Fatalf("set default row: %v", err)
+	}
+
+	cases := []struct {
+		input any
+		want  string
+	}{
+		{[]string{"bolt", "nut"}, "ok"},
+		{[]string{"nut", "bolt", "bolt"}, "ok"},
+		{[]string{"bolt", "bolt", "bolt"}, "short"},
+		{[]string{"nut", "nut"}, "short"},
+		{[]string{"washer"}, "short"},
+		{[]string{}, "ok"},
+	}
+	for _, tc := range cases {
+		rows, err := dt.Evaluate(map[string]any{"items": tc.input}, nil)
+		if err != nil {
+			t.Fatalf("evaluate %v returned error: %v", tc.input, err)
+		}
+		if len(rows) != 1 || rows[0].Values["stock"] != tc.want {
+			t.Fatalf("input %v: expected %s, got %#v", tc.input, tc.want, rows)
+		}
+	}
+
+	// the same duplicated inputs pass under set semantics
+	for _, input := range [][]any{{"bolt", "bolt", "bolt"}, {"nut", "nut"}} {
+		match, err := evaluateCollectionOperator(DataTypeListString, OperatorAllContained, input, []any{"bolt", "bolt", "nut"})
+		if err != nil || !match {
+			t.Fatalf("expected ALL_CONTAINED_IN to ignore duplicates for %v, got %v (%v)", input, match, err)
+		}
+	}
+	if op, err := parseJSONOperatorToken("multisetContainedIn"); err != nil || op != OperatorMultisetContained {
+		t.Fatalf("parse multisetContainedIn: %v %v", op, err)
+	}
+	if err := checkOperatorDataType(OperatorMultisetContained, DataTypeString); err == nil {
+		t.Fatalf("expected MULTISET_CONTAINED_IN to be rejected on scalar columns")
+	}
+}
+
+func TestAllEqualComparesListItemsWithScalarOperand(t *testing.T) {
+	dt, err := NewDecisionTable("scores",
+		[]Column{{Name: "scores", Type: ColumnTypeCondition, DataType: DataTypeListInteger}},
+		[]Column{{Name: "out", Type: ColumnTypeConclusion, DataType: DataTypeString}},
+		WithMatchPolicy(MatchPolicyFirst))
+	if err != nil {
+		t.Fatalf("new table: %v", err)
+	}
+	if err := dt.AddRow(Row{
+		RuleID:      "all-tens",
+		EvalCells:   []EvalCell{{Column: "scores", Operator: OperatorAllEqual, Value: "10"}},
+		ReturnCells: []ReturnCell{{Column: "out", Value: "perfect"}},
+	}); err != nil {
+		t.Fatalf("expected a scalar ALL_EQUAL operand on a list column, got %v", err)
+	}
+	if err := dt.SetDefaultRow(Row{ReturnCells: []ReturnCell{{Column: "out", Value: "mixed"}}}); err != nil {
+		t.Fatalf("set default row: %v", err)
+	}
+
+	cases := []struct {
+		input any
+		want  string
+	}{
+		{[]int{10, 10}, "perfect"},
+		{[]string{"10", "10"}, "perfect"},
+		{[]int{10, 9}, "mixed"},
+	}
+	for _, tc := range cases {
+		rows, err := dt.Evaluate(map[string]any{"scores": tc.input}, nil)
+		if err != nil {
+			t.Fatalf("evaluate %v returned error: %v", tc.input, err)
+		}
+		if len(rows) != 1 || rows[0].Values["out"] != tc.want {
+			t.Fatalf("input %v: expected %s, got %#v", tc.input, tc.want, rows)
+		}
+	}
+}
+
+func TestAggregateOperators(t *testing.T) {
+	cases := []struct {
+		op       OperatorType
+		expected any
+		actual   []any
+		want     bool
+	}{
+		{OperatorSumGreater, int64(10), []any{int64(4), int64(7)}, true},
+		{OperatorSumGreater, int64(10), []any{int64(4), int64(6)}, false},
+		{OperatorSumEqual, int64(0), []any{}, true},
+		{OperatorCountEqual, int64(0), []any{}, true},
+		{OperatorCountGreaterOrEqual, int64(2), []any{int64(1), int64(1)}, true},
+		{OperatorMaxLess, int64(5), []any{int64(1), int64(4)}, true},
+		{OperatorMaxLess, int64(5), []any{int64(1), int64(5)}, false},
+		{OperatorMinGreater, int64(0), []any{int64(3), int64(1)}, true},
+		{OperatorMinGreater, int64(0), []any{}, false},
+		{OperatorMaxLessOrEqual, int64(100), []any{}, false},
+		{OperatorCountEqual, int64(0), nil, false},
+	}
+	for _, tc := range cases {
+		got, err := evaluateAggregate(DataTypeListInteger, tc.op, tc.actual, tc.expected)
+		if err != nil {
+			t.Fatalf("%s %v: unexpected error: %v", tc.op, tc.actual, err)
+		}
+		if got != tc.want {
+			t.Fatalf("%s %v against %v: expected %v, got %v", tc.op, tc.actual, tc.expected, tc.want, got)
+		}
+	}
+
+	if _, err := evaluateAggregate(DataTypeListInteger, OperatorSumGreater, []any{int64(math.MaxInt64), int64(1)}, int64(0)); err == nil {
+		t.Fatalf("expected sum overflow to fail")
+	}
+}
+
+func TestAggregateOperatorsInTable(t *testing.T) {
+	dt, err := NewDecisionTable("orders",
+		[]Column{
+			{Name: "amounts", Type: ColumnTypeCondition, DataType: DataTypeListInteger},
+			{Name: "tags", Type: ColumnTypeCondition, DataType: DataTypeListString},
+		},
+		[]Column{{Name: "tier", Type: ColumnTypeConclusion, DataType: DataTypeString}},
+		WithMatchPolicy(MatchPolicyFirst),
+	)
+	if err != nil {
+		t.Fatalf("failed to build table: %v", err)
+	}
+	if err := dt.AddRow(Row{
+		RuleID:      "big",
+		EvalCells:   []EvalCell{{Column: "amounts", Operator: OperatorSumGreater, Value: "1000"}},
+		ReturnCells: []ReturnCell{{Column: "tier", Value: "gold"}},
+	}); err != nil {
+		t.Fatalf("failed to add sum row: %v", err)
+	}
+	if err := dt.AddRow(Row{
+		RuleID:      "tagged",
+		EvalCells:   []EvalCell{{Column: "tags", Operator: OperatorCountGreaterOrEqual, Value: 2}},
+		ReturnCells: []ReturnCell{{Column: "tier", Value: "silver"}},
+	}); err != nil {
+		t.Fatalf("failed to add count row: %v", err)
+	}
+
+	matches, err := dt.Evaluate(map[string]any{"amounts": []any{600, 500}, "tags": []any{}}, nil)
+	if err != nil || len(matches) != 1 || matches[0].RuleID != "big" {
+		t.Fatalf("expected big, got %#v (err %v)", matches, err)
+	}
+	matches, err = dt.Evaluate(map[string]any{"amounts": []any{}, "tags": []any{"a", "b"}}, nil)
+	if err != nil || len(matches) != 1 || matches[0].RuleID != "tagged" {
+		t.Fatalf("expected tagged, got %#v (err %v)", matches, err)
+	}
+	if got := matches[0].Explain(); !strings.Contains(got, "count(tags) >= 2") {
+		t.Fatalf("unexpected explanation %q", got)
+	}
+
+	err = dt.AddRow(Row{
+		EvalCells:   []EvalCell{{Column: "tags", Operator: OperatorSumGreater, Value: 1}},
+		ReturnCells: []ReturnCell{{Column: "tier", Value: "x"}},
+	})
+	if err == nil || !strings.Contains(err.Error(), "not supported for LIST_STRING") {
+		t.Fatalf("expected SUM on string list to fail, got %v", err)
+	}
+}
+
+func TestDecimalListAggregates(t *testing.T) {
+	const doc = `
+{
+  "decisionTable": {
+    "name": "baskets",
+    "policies": {"matchPolicy": "FIRST", "noMatchPolicy": "RETURN_DEFAULT"},
+    "columns": [
+      {"name": "prices", "type": "CONDITION", "dataType": "LIST_DECIMAL"},
+      {"name": "tier", "type": "CONCLUSION", "dataType": "STRING"}
+    ],
+    "rules": [
+      {"id": "premium", "when": [{"operator": "minGt", "value": "99.5"}], "then": ["premium"]},
+      {"id": "bulk", "when": [{"operator": "sumGtEq", "value": "1000.10"}], "then": ["bulk"]},
+      {"id": "cheap", "when": [{"operator": "maxLt", "value": 1}], "then": ["cheap"]},
+      {"id": "empty", "when": [{"operator": "countEq", "value": 0}], "then": ["empty"]}
+    ],
+    "defaultRule": {"then": ["standard"]}
+  }
+}`
+	dt, err := LoadJSON([]byte(doc), "baskets.json")
+	if err != nil {
+		t.Fatalf("load json: %v", err)
+	}
+	if got := dt.ConditionColumns()[0].DataType; got != DataTypeListDecimal {
+		t.Fatalf("unexpected data type %s", got)
+	}
+	cases := []struct {
+		prices any
+		want   string
+	}{
+		{[]string{"99.75", "120"}, "premium"},
+		{[]any{"99.5", "900.60"}, "bulk"},
+		{[]any{"99.5", "900.59"}, "standard"},
+		{[]float64{0.25, 0.5}, "cheap"},
+		{[]*big.Float{big.NewFloat(0.99)}, "cheap"},
+		// an empty list sums and counts to 0 and has no minimum or maximum
+		{[]any{}, "empty"},
+	}
+	for _, tc := range cases {
+		matches, err := dt.Evaluate(map[string]any{"prices": tc.prices}, nil)
+		if err != nil || len(matches) != 1 || matches[0].Values["tier"] != tc.want {
+			t.Fatalf("prices %v: expected %s, got %#v (err %v)", tc.prices, tc.want, matches, err)
+		}
+	}
+	if got := dt.Rows()[1].EvalCells[0].Value; formatValue(DataTypeDecimal, got) != "1000.1" {
+		t.Fatalf("expected a decimal sum operand, got %#v", got)
+	}
+	if got := dt.Rows()[3].EvalCells[0].Value; got != int64(0) {
+		t.Fatalf("expected an integer count operand, got %#v", got)
+	}
+
+	if _, err := evaluateAggregate(DataTypeListDecimal, OperatorSumGreater, []any{int64(1)}, big.NewFloat(0)); err == nil || !strings.Contains(err.Error(), "expects decimal elements") {
+		t.Fatalf("expected a non-decimal element to fail, got %v", err)
+	}
+}
+
+func TestParseAggregateOperatorTokens(t *testing.T) {
+	for _, token := range []string{"SUM_GT", "sum_gt", "sumGt"} {
+		op, err := parseJSONOperatorToken(token)
+		if err != nil || op != OperatorSumGreater {
+			t.Fatalf("json token %q: expected SUM_GT, got %s (err %v)", token, op, err)
+		}
+	}
+	op, err := parseOperatorToken("COUNT_LT_EQ")
+	if err != nil || op != OperatorCountLessOrEqual {
+		t.Fatalf("excel token: expected COUNT_LT_EQ, got %s (err %v)", op, err)
+	}
+}
+
+func TestStringLengthCountsRunes(t *testing.T) {
+	dt, err := NewDecisionTable("names",
+		[]Column{{Name: "name", Type: ColumnTypeCondition, DataType: DataTypeString}},
+		[]Column{{Name: "fits", Type: ColumnTypeConclusion, DataType: DataTypeBoolean}},
+		WithMatchPolicy(MatchPolicyFirst),
+	)
+	if err != nil {
+		t.Fatalf("failed to build table: %v", err)
+	}
+	if err := dt.AddRow(Row{
+		RuleID:      "short",
+		EvalCells:   []EvalCell{{Column: "name", Operator: OperatorLengthLessOrEqual, Value: "4"}},
+		ReturnCells: []ReturnCell{{Column: "fits", Value: true}},
+	}); err != nil {
+		t.Fatalf("failed to add row: %v", err)
+	}
+
+	// "José" is 5 bytes and "日本語" is 9, but both are at most 4 runes long.
+	for _, name := range []string{"José", "日本語", "abcd"} {
+		matches, err := dt.Evaluate(map[string]any{"name": name}, nil)
+		if err != nil || len(matches) != 1 {
+			t.Fatalf("expected %q to match, got %#v (err %v)", name, matches, err)
+		}
+	}
+	if got := explainCondition(dt.Rows()[0].EvalCells[0]); got != "length(name) <= 4" {
+		t.Fatalf("unexpected explanation %q", got)
+	}
+	matches, err := dt.Evaluate(map[string]any{"name": "Zoë Ann"}, nil)
+	if err == nil || len(matches) != 0 {
+		t.Fatalf("expected no match for a 7 rune name, got %#v (err %v)", matches, err)
+	}
+	if ok, err := evaluateStringLength(OperatorLengthEqual, nil, int64(0)); err != nil || ok {
+		t.Fatalf("expected null input not to match, got %v (err %v)", ok, err)
+	}
+
+	err = dt.AddRow(Row{
+		EvalCells:   []EvalCell{{Column: "name", Operator: OperatorCountEqual, Value: 1}},
+		ReturnCells: []ReturnCell{{Column: "fits", Value: false}},
+	})
+	if err == nil || !strings.Contains(err.Error(), "not supported for STRING") {
+		t.Fatalf("expected COUNT on a string column to fail, got %v", err)
+	}
+	if op, err := parseJSONOperatorToken("lengthGtEq"); err != nil || op != OperatorLengthGreaterOrEqual {
+		t.Fatalf("expected LENGTH_GT_EQ, got %s (err %v)", op, err)
+	}
+}
+
+func TestNullInputAgainstInAndNotIn(t *testing.T) {
+	build := func(opts ...Option) *DecisionTable {
+		dt, err := NewDecisionTable("regions",
+			[]Column{{Name: "country", Type: ColumnTypeCondition, DataType: DataTypeString}},
+			[]Column{{Name: "region", Type: ColumnTypeConclusion, DataType: DataTypeString}},
+			append([]Option{WithMatchPolicy(MatchPolicyAll), WithNoMatchPolicy(NoMatchPolicyReturnNil)}, opts...)...,
+		)
+		if err != nil {
+			t.Fatalf("failed to build table: %v", err)
+		}
+		for _, row := range []Row{
+			{RuleID: "north-america", EvalCells: []EvalCell{{Column: "country", Operator: OperatorIn, Value: []string{"US", "CA"}}}, ReturnCells: []ReturnCell{{Column: "region", Value: "na"}}},
+			{RuleID: "elsewhere", EvalCells: []EvalCell{{Column: "country", Operator: OperatorNotIn, Value: []string{"US", "CA"}}}, ReturnCells: []ReturnCell{{Column: "region", Value: "row"}}},
+			{RuleID: "not-us", EvalCells: []EvalCell{{Column: "country", Operator: OperatorNotEqual, Value: "US"}}, ReturnCells: []ReturnCell{{Column: "region", Value: "non-us"}}},
+		} {
+			if err := dt.AddRow(row); err != nil {
+				t.Fatalf("failed to add %s: %v", row.RuleID, err)
+			}
+		}
+		return dt
+	}
+	ruleIDs := func(dt *DecisionTable, input map[string]any) string {
+		matches, err := dt.Evaluate(input, nil)
+		if err != nil {
+			t.Fatalf("evaluate %v: %v", input, err)
+		}
+		ids := make([]string, len(matches))
+		for i, m := range matches {
+			ids[i] = m.RuleID
+		}
+		return strings.Join(ids, ",")
+	}
+
+	dt := build()
+	for _, input := range []map[string]any{{}, {"country": nil}} {
+		if got := ruleIDs(dt, input); got != "elsewhere,not-us" {
+			t.Fatalf("expected a null country to be outside the list by default, got %q for %v", got, input)
+		}
+	}
+	dt = build(WithThreeValuedNulls())
+	for _, input := range []map[string]any{{}, {"country": nil}} {
+		if got := ruleIDs(dt, input); got != "" {
+			t.Fatalf("expected a null country to match nothing with three-valued nulls, got %q for %v", got, input)
+		}
+	}
+	if got := ruleIDs(dt, map[string]any{"country": "FR"}); got != "elsewhere,not-us" {
+		t.Fatalf("expected FR to match the negations, got %q", got)
+	}
+	if got := ruleIDs(dt, map[string]any{"country": "CA"}); got != "north-america,not-us" {
+		t.Fatalf("expected CA to match north-america and not-us, got %q", got)
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// outputTemplateKey identifies a parsed template by its column and source.
+type outputTemplateKey struct {
+	column string
+	source string
+}
+
+// parseOutputTemplate parses the source of a Template column's value.
+func parseOutputTemplate(column, source string) (*template.Template, error) {
+	tmpl, err := template.New(column).Option("missingkey=error").Parse(source)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// prepareOutputTemplate validates a Template column's return value and caches its parsed form.
+func (dt *DecisionTable) prepareOutputTemplate(col Column, value any) error {
+	source, ok := value.(string)
+	if !ok {
+		return nil
+	}
+	key := outputTemplateKey{col.Name, source}
+	if _, cached := dt.outputTemplates[key]; cached {
+		return nil
+	}
+	tmpl, err := parseOutputTemplate(col.Name, source)
+	if err != nil {
+		return err
+	}
+	if dt.outputTemplates == nil {
+		dt.outputTemplates = make(map[outputTemplateKey]*template.Template)
+	}
+	dt.outputTemplates[key] = tmpl
+	return nil
+}
+
+// pruneOutputTemplates drops cached templates that no rule, default row or guarded default returns
+// any more, after rows were replaced or removed.
+func (dt *DecisionTable) pruneOutputTemplates() {
+	if len(dt.outputTemplates) == 0 {
+		return
+	}
+	used := make(map[outputTemplateKey]struct{}, len(dt.outputTemplates))
+	note := func(row Row) {
+		for _, cell := range row.ReturnCells {
+			if source, ok := cell.Value.(string); ok {
+				used[outputTemplateKey{cell.Column, source}] = struct{}{}
+			}
+		}
+	}
+	for _, row := range dt.rows {
+		note(row)
+	}
+	for _, row := range dt.guardedDefaults {
+		note(row)
+	}
+	if dt.defaultRow != nil {
+		note(*dt.defaultRow)
+	}
+	for key := range dt.outputTemplates {
+		if _, ok := used[key]; !ok {
+			delete(dt.outputTemplates, key)
+		}
+	}
+}
+
+// hasOutputTemplates reports whether any output column is a Template column.
+func (dt *DecisionTable) hasOutputTemplates() bool {
+	for _, col := range dt.outputOrder {
+		if col.Template {
+			return true
+		}
+	}
+	return false
+}
+
+// templateContext is the data templates are rendered with: every condition column, with absent and
+// null inputs as empty strings, plus any other keys of a map input.
+func (dt *DecisionTable) templateContext(input InputProvider) map[string]any {
+	data := make(map[string]any, len(dt.conditionOrder))
+	if m, ok := input.(MapInput); ok {
+		for k, v := range m {
+			data[k] = v
+		}
+	}
+	for _, col := range dt.conditionOrder {
+		if v, _ := dt.columnInput(input, col); v != nil {
+			data[col.Name] = v
+		} else {
+			data[col.Name] = ""
+		}
+	}
+	return data
+}
+
+// renderScored renders the Template columns of scored results.
+func (dt *DecisionTable) renderScored(scored []ScoredRow, input InputProvider) error {
+	if !dt.hasOutputTemplates() {
+		return nil
+	}
+	data := dt.templateContext(input)
+	for _, row := range scored {
+		if err := dt.renderTemplates(row.Values, row.Groups, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderTemplates replaces the values of Template columns in values with their rendering. Values
+// supplied by the caller as a default map are rendered as well, since the column holds templates.
+func (dt *DecisionTable) renderTemplates(values map[string]any, groups map[string]map[string]any, data map[string]any) error {
+	for _, col := range dt.outputOrder {
+		if !col.Template {
+			continue
+		}
+		source, ok := values[col.Name].(string)
+		if !ok {
+			continue
+		}
+		tmpl, cached := dt.outputTemplates[outputTemplateKey{col.Name, source}]
+		if !cached {
+			var err error
+			if tmpl, err = parseOutputTemplate(col.Name, source); err != nil {
+				return fmt.Errorf("output column %s: %w", col.Name, err)
+			}
+		}
+		var out strings.Builder
+		if err := tmpl.Execute(&out, data); err != nil {
+			return fmt.Errorf("output column %s: %w", col.Name, err)
+		}
+		values[col.Name] = out.String()
+		if group, ok := groups[col.Group]; ok && col.Group != "" {
+			group[col.Name] = out.String()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+const greetingTableJSON = `
+{
+  "decisionTable": {
+    "name": "greeting",
+    "policies": {"matchPolicy": "FIRST", "noMatchPolicy": "RETURN_DEFAULT"},
+    "columns": [
+      {"name": "name", "type": "CONDITION", "dataType": "STRING"},
+      {"name": "tier", "type": "CONDITION", "dataType": "STRING"},
+      {"name": "message", "type": "CONCLUSION", "dataType": "STRING", "template": true},
+      {"name": "code", "type": "CONCLUSION", "dataType": "STRING"}
+    ],
+    "rules": [
+      {"id": "vip", "when": [null, {"operator": "equal", "value": "VIP"}], "then": ["Welcome back, {{.name}} <VIP>", "{{.name}}"]}
+    ],
+    "defaultRule": {"then": ["Hello {{.name}}", "plain"]}
+  }
+}`
+
+func TestTemplateColumnRendersInput(t *testing.T) {
+	dt, err := LoadJSON([]byte(greetingTableJSON), "greeting.json")
+	if err != nil {
+		t.Fatalf("load json: %v", err)
+	}
+
+	cases := []struct {
+		input       map[string]any
+		wantMessage string
+		wantCode    string
+	}{
+		{map[string]any{"name": "Ann", "tier": "VIP"}, "Welcome back, Ann <VIP>", "{{.name}}"},
+		{map[string]any{"name": "Bob"}, "Hello Bob", "plain"},
+		{map[string]any{"tier": "VIP"}, "Welcome back,  <VIP>", "{{.name}}"},
+		{map[string]any{"name": nil}, "Hello ", "plain"},
+	}
+	for _, tc := range cases {
+		matches, err := dt.Evaluate(tc.input, nil)
+		if err != nil {
+			t.Fatalf("evaluate %v returned error: %v", tc.input, err)
+		}
+		if len(matches) != 1 || matches[0].Values["message"] != tc.wantMessage || matches[0].Values["code"] != tc.wantCode {
+			t.Fatalf("input %v: expected %q/%q, got %#v", tc.input, tc.wantMessage, tc.wantCode, matches)
+		}
+	}
+
+	matches, err := dt.Evaluate(map[string]any{"name": "Cy"}, map[string]any{"message": "Hi {{.name}}"})
+	if err != nil {
+		t.Fatalf("evaluate with caller default returned error: %v", err)
+	}
+	if len(matches) != 1 || matches[0].Values["message"] != "Hello Cy" {
+		t.Fatalf("expected the table default row to win, got %#v", matches)
+	}
+}
+
+func TestTemplateColumnUnknownKeyFailsEvaluation(t *testing.T) {
+	dt := newTemplateTable(t)
+	if err := dt.AddRow(Row{
+		RuleID:      "r1",
+		EvalCells:   []EvalCell{{Column: "name", Operator: OperatorNotEqual, Value: "nobody"}},
+		ReturnCells: []ReturnCell{{Column: "message", Value: "Hi {{.nickname}}"}},
+	}); err != nil {
+		t.Fatalf("add row: %v", err)
+	}
+
+	if _, err := dt.Evaluate(map[string]any{"name": "Ann"}, nil); err == nil || !strings.Contains(err.Error(), "output column message") {
+		t.Fatalf("expected missing key error, got %v", err)
+	}
+	matches, err := dt.Evaluate(map[string]any{"name": "Ann", "nickname": "A"}, nil)
+	if err != nil {
+		t.Fatalf("evaluate with extra input key returned error: %v", err)
+	}
+	if matches[0].Values["message"] != "Hi A" {
+		t.Fatalf("expected extra map keys to render, got %#v", matches[0].Values)
+	}
+}
+
+func TestTemplateColumnValidation(t *testing.T) {
+	dt := newTemplateTable(t)
+	err := dt.AddRow(Row{
+		RuleID:      "r1",
+		EvalCells:   []EvalCell{{Column: "name", Operator: OperatorNotEqual, Value: "nobody"}},
+		ReturnCells: []ReturnCell{{Column: "message", Value: "Hi {{.name"}},
+	})
+	if err == nil || !strings.Contains(err.Error(), "invalid template") {
+		t.Fatalf("expected invalid template error, got %v", err)
+	}
+
+	_, err = NewDecisionTable("bad",
+		[]Column{{Name: "name", Type: ColumnTypeCondition, DataType: DataTypeString}},
+		[]Column{{Name: "count", Type: ColumnTypeConclusion, DataType: DataTypeInteger, Template: true}},
+	)
+	if err == nil || !strings.Contains(err.Error(), "only STRING output columns") {
+		t.Fatalf("expected template data type error, got %v", err)
+	}
+}
+
+func TestTemplateColumnCSVRoundTrip(t *testing.T) {
+	dt, err := LoadJSON([]byte(greetingTableJSON), "greeting.json")
+	if err != nil {
+		t.Fatalf("load json: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := WriteCSV(dt, &buf); err != nil {
+		t.Fatalf("write csv: %v", err)
+	}
+	loaded, err := LoadCSV("greeting", &buf)
+	if err != nil {
+		t.Fatalf("load csv: %v", err)
+	}
+	matches, err := loaded.Evaluate(map[string]any{"name": "Ann", "tier": "VIP"}, nil)
+	if err != nil {
+		t.Fatalf("evaluate returned error: %v", err)
+	}
+	if matches[0].Values["message"] != "Welcome back, Ann <VIP>" {
+		t.Fatalf("expected template to survive the round trip, got %#v", matches[0].Values)
+	}
+}
+
+func newTemplateTable(t *testing.T) *DecisionTable {
+	t.Helper()
+	dt, err := NewDecisionTable("greeting",
+		[]Column{{Name: "name", Type: ColumnTypeCondition, DataType: DataTypeString}},
+		[]Column{{Name: "message", Type: ColumnTypeConclusion, DataType: DataTypeString, Template: true}},
+	)
+	if err != nil {
+		t.Fatalf("new table: %v", err)
+	}
+	return dt
+}
+
+func TestTemplateCacheFollowsMergeAndRowChanges(t *testing.T) {
+	build := func(id, source string) *DecisionTable {
+		dt := newTemplateTable(t)
+		if err := dt.AddRow(Row{
+			RuleID:      id,
+			EvalCells:   []EvalCell{{Column: "name", Operator: OperatorEqual, Value: id}},
+			ReturnCells: []ReturnCell{{Column: "message", Value: source}},
+		}); err != nil {
+			t.Fatalf("add row: %v", err)
+		}
+		return dt
+	}
+	first, second := build("ann", "Hi {{.name}}"), build("bob", "Yo {{.name}}")
+	merged, err := Merge(first, second)
+	if err != nil {
+		t.Fatalf("merge: %v", err)
+	}
+	if len(merged.outputTemplates) != 2 {
+		t.Fatalf("expected templates from both inputs to be cached, got %v", merged.outputTemplates)
+	}
+
+	if err := merged.UpdateRow("ann", Row{
+		EvalCells:   []EvalCell{{Column: "name", Operator: OperatorEqual, Value: "ann"}},
+		ReturnCells: []ReturnCell{{Column: "message", Value: "Hello {{.name}}"}},
+	}); err != nil {
+		t.Fatalf("update row: %v", err)
+	}
+	if _, stale := merged.outputTemplates[outputTemplateKey{"message", "Hi {{.name}}"}]; stale {
+		t.Fatalf("expected the replaced template to be pruned")
+	}
+	if len(first.outputTemplates) != 1 {
+		t.Fatalf("expected the input table's cache to stay untouched, got %v", first.outputTemplates)
+	}
+	if err := merged.RemoveRow("bob"); err != nil {
+		t.Fatalf("remove row: %v", err)
+	}
+	if len(merged.outputTemplates) != 1 {
+		t.Fatalf("expected only the remaining template to stay cached, got %v", merged.outputTemplates)
+	}
+	rows, err := merged.Evaluate(map[string]any{"name": "ann"}, nil)
+	if err != nil || len(rows) != 1 || rows[0].Values["message"] != "Hello ann" {
+		t.Fatalf("expected the updated template to render, got %#v (%v)", rows, err)
+	}
+}
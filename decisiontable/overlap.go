@@ -0,0 +1,130 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import "fmt"
+
+// OverlapReport names two enabled rules that an input may match at the same time.
+type OverlapReport struct {
+	FirstRuleID     string
+	FirstRowNumber  int
+	SecondRuleID    string
+	SecondRowNumber int
+}
+
+// DetectOverlaps statically compares every pair of enabled rules and reports the pairs that cannot be
+// shown to exclude each other. Two rules exclude each other when some cell of one contradicts a cell
+// of the other on the same column, using the same pairwise checks as DetectDeadRules; BETWEEN ranges
+// are compared as their two bounds. Rules that can never match are skipped. The analysis is
+// conservative: pairs it cannot separate, for instance through custom operators, are reported.
+func (dt *DecisionTable) DetectOverlaps() []OverlapReport {
+	return dt.detectOverlaps(func(a, b Row) bool { return true })
+}
+
+// DetectGroupOverlaps is DetectOverlaps restricted to pairs of rules in the same group (Row.Group), for
+// tables whose groups are each expected to be UNIQUE. Rows without a group are compared with each other.
+func (dt *DecisionTable) DetectGroupOverlaps() []OverlapReport {
+	return dt.detectOverlaps(func(a, b Row) bool { return a.Group == b.Group })
+}
+
+// detectOverlaps reports the overlapping pairs of rules for which compare returns true.
+func (dt *DecisionTable) detectOverlaps(compare func(a, b Row) bool) []OverlapReport {
+	if dt == nil {
+		return nil
+	}
+	var reports []OverlapReport
+	for i, a := range dt.rows {
+		for _, b := range dt.rows[i+1:] {
+			if compare(a, b) && rulesOverlap(a, b) {
+				reports = append(reports, OverlapReport{
+					FirstRuleID:     a.RuleID,
+					FirstRowNumber:  a.Number,
+					SecondRuleID:    b.RuleID,
+					SecondRowNumber: b.Number,
+				})
+			}
+		}
+	}
+	return reports
+}
+
+// checkUniqueness rejects row when WithValidateUniqueness is set on a UNIQUE table and row may match
+// together with a rule that is already registered.
+func (dt *DecisionTable) checkUniqueness(row Row, skip int) error {
+	if !dt.validateUniqueness || dt.matchPolicy != MatchPolicyUnique {
+		return nil
+	}
+	for i, existing := range dt.rows {
+		if dt.uniquePerGroup && existing.Group != row.Group {
+			continue
+		}
+		if i != skip && rulesOverlap(existing, row) {
+			return fmt.Errorf("rule %s overlaps rule %s under UNIQUE match policy", ruleLabel(row), ruleLabel(existing))
+		}
+	}
+	return nil
+}
+
+func ruleLabel(row Row) string {
+	if row.RuleID != "" {
+		return row.RuleID
+	}
+	return fmt.Sprintf("at row %d", row.Number)
+}
+
+func rulesOverlap(a, b Row) bool {
+	if a.Disabled || b.Disabled || !validityOverlaps(a, b) {
+		return false
+	}
+	if _, dead := deadRuleReport(a); dead {
+		return false
+	}
+	if _, dead := deadRuleReport(b); dead {
+		return false
+	}
+	left, right := expandRanges(a.EvalCells), expandRanges(b.EvalCells)
+	for _, x := range left {
+		for _, y := range right {
+			if x.Column == y.Column && contradicts(x, y) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// expandRanges rewrites each BETWEEN cell as its lower and upper comparison so that range checks
+// apply to it. NOT_BETWEEN is kept as is.
+func expandRanges(cells []EvalCell) []EvalCell {
+	out := make([]EvalCell, 0, len(cells))
+	for _, cell := range cells {
+		bounds, ok := cell.Value.([]any)
+		if cell.Operator != OperatorBetween || !ok || len(bounds) != 2 {
+			out = append(out, cell)
+			continue
+		}
+		lower, upper := cell, cell
+		lower.Operator, lower.Value = OperatorGreaterOrEqual, bounds[0]
+		upper.Operator, upper.Value = OperatorLessOrEqual, bounds[1]
+		if cell.Interval != "" && cell.Interval[0] == '(' {
+			lower.Operator = OperatorGreater
+		}
+		if cell.Interval != "" && cell.Interval[1] == ')' {
+			upper.Operator = OperatorLess
+		}
+		out = append(out, lower, upper)
+	}
+	return out
+}
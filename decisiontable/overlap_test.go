@@ -0,0 +1,177 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import (
+	"strings"
+	"testing"
+)
+
+func newBucketTable(t *testing.T, opts ...Option) *DecisionTable {
+	t.Helper()
+	dt, err := NewDecisionTable("buckets",
+		[]Column{
+			{Name: "score", Type: ColumnTypeCondition, DataType: DataTypeInteger},
+			{Name: "region", Type: ColumnTypeCondition, DataType: DataTypeString},
+		},
+		[]Column{{Name: "bucket", Type: ColumnTypeConclusion, DataType: DataTypeString}},
+		append([]Option{WithMatchPolicy(MatchPolicyUnique)}, opts...)...)
+	if err != nil {
+		t.Fatalf("new table: %v", err)
+	}
+	return dt
+}
+
+func bucketRow(id string, cells ...EvalCell) Row {
+	return Row{RuleID: id, EvalCells: cells, ReturnCells: []ReturnCell{{Column: "bucket", Value: id}}}
+}
+
+func TestDetectOverlaps(t *testing.T) {
+	dt := newBucketTable(t)
+	rows := []Row{
+		bucketRow("low", EvalCell{Column: "score", Operator: OperatorBetween, Value: "[0..10)"}),
+		bucketRow("mid", EvalCell{Column: "score", Operator: OperatorBetween, Value: "[10..20)"}),
+		bucketRow("eu-high", EvalCell{Column: "score", Operator: OperatorGreaterOrEqual, Value: 15}, EvalCell{Column: "region", Operator: OperatorEqual, Value: "EU"}),
+		bucketRow("us-high", EvalCell{Column: "score", Operator: OperatorGreaterOrEqual, Value: 20}, EvalCell{Column: "region", Operator: OperatorEqual, Value: "US"}),
+	}
+	for _, row := range rows {
+		if err := dt.AddRow(row); err != nil {
+			t.Fatalf("add row: %v", err)
+		}
+	}
+	reports := dt.DetectOverlaps()
+	if len(reports) != 1 || reports[0].FirstRuleID != "mid" || reports[0].SecondRuleID != "eu-high" {
+		t.Fatalf("expected only mid and eu-high to overlap, got %#v", reports)
+	}
+	if err := dt.DisableRule("mid"); err != nil {
+		t.Fatalf("disable: %v", err)
+	}
+	if reports := dt.DetectOverlaps(); len(reports) != 0 {
+		t.Fatalf("expected disabled rules to be ignored, got %#v", reports)
+	}
+}
+
+func TestValidateUniqueness(t *testing.T) {
+	dt := newBucketTable(t, WithValidateUniqueness())
+	if err := dt.AddRow(bucketRow("low", EvalCell{Column: "score", Operator: OperatorLess, Value: 10})); err != nil {
+		t.Fatalf("add row: %v", err)
+	}
+	if err := dt.AddRow(bucketRow("high", EvalCell{Column: "score", Operator: OperatorGreaterOrEqual, Value: 10})); err != nil {
+		t.Fatalf("expected disjoint rule to be accepted: %v", err)
+	}
+	err := dt.AddRow(bucketRow("eu", EvalCell{Column: "region", Operator: OperatorEqual, Value: "EU"}))
+	if err == nil || !strings.Contains(err.Error(), "rule eu overlaps rule low under UNIQUE match policy") {
+		t.Fatalf("expected overlap error, got %v", err)
+	}
+	if dt.RowCount() != 2 {
+		t.Fatalf("expected rejected rule not to be added, got %d rows", dt.RowCount())
+	}
+
+	relaxed := newBucketTable(t, WithValidateUniqueness(), WithMatchPolicy(MatchPolicyFirst))
+	for _, row := range []Row{
+		bucketRow("a", EvalCell{Column: "score", Operator: OperatorLess, Value: 10}),
+		bucketRow("b", EvalCell{Column: "score", Operator: OperatorLess, Value: 20}),
+	} {
+		if err := relaxed.AddRow(row); err != nil {
+			t.Fatalf("expected non-UNIQUE table to skip the check: %v", err)
+		}
+	}
+}
+
+func TestValidateUniquenessOnLoad(t *testing.T) {
+	const doc = `
+{
+  "decisionTable": {
+    "name": "unique",
+    "policies": {"matchPolicy": "UNIQUE", "noMatchPolicy": "THROW_ERROR"},
+    "columns": [
+      {"name": "score", "type": "CONDITION", "dataType": "INTEGER"},
+      {"name": "segment", "type": "CONCLUSION", "dataType": "STRING"}
+    ],
+    "rules": [
+      {"id": "high", "when": [{"operator": "greaterThan", "value": 50}], "then": ["high"]},
+      {"id": "higher", "when": [{"operator": "greaterThan", "value": 60}], "then": ["higher"]}
+    ]
+  }
+}`
+	if _, err := LoadJSON([]byte(doc), "unique.json"); err != nil {
+		t.Fatalf("expected the check to be opt-in, got %v", err)
+	}
+	if _, err := LoadJSON([]byte(doc), "unique.json", WithValidateUniqueness()); err == nil || !strings.Contains(err.Error(), "rule higher overlaps rule high") {
+		t.Fatalf("expected overlap error, got %v", err)
+	}
+}
+
+func TestRuleGroupsAreUniqueIndependently(t *testing.T) {
+	grouped := func(group string, row Row) Row {
+		row.Group = group
+		return row
+	}
+	rows := []Row{
+		grouped("score", bucketRow("low", EvalCell{Column: "score", Operator: OperatorLess, Value: 50})),
+		grouped("score", bucketRow("high", EvalCell{Column: "score", Operator: OperatorGreaterOrEqual, Value: 50})),
+		grouped("region", bucketRow("eu", EvalCell{Column: "region", Operator: OperatorEqual, Value: "EU"})),
+		grouped("region", bucketRow("elsewhere", EvalCell{Column: "region", Operator: OperatorNotEqual, Value: "EU"})),
+	}
+	dt := newBucketTable(t, WithUniquePerGroup(), WithValidateUniqueness())
+	for _, row := range rows {
+		if err := dt.AddRow(row); err != nil {
+			t.Fatalf("add row %s: %v", row.RuleID, err)
+		}
+	}
+	if reports := dt.DetectGroupOverlaps(); len(reports) != 0 {
+		t.Fatalf("expected both groups to be internally unique, got %#v", reports)
+	}
+	if reports := dt.DetectOverlaps(); len(reports) != 4 {
+		t.Fatalf("expected rules of different groups to overlap, got %#v", reports)
+	}
+
+	results, err := dt.Evaluate(map[string]any{"score": 70, "region": "EU"}, nil)
+	if err != nil {
+		t.Fatalf("evaluate returned error: %v", err)
+	}
+	if len(results) != 2 || results[0].RuleID != "high" || results[0].RuleGroup != "score" || results[1].RuleID != "eu" || results[1].RuleGroup != "region" {
+		t.Fatalf("expected one match per group, got %#v", results)
+	}
+
+	err = dt.AddRow(grouped("score", bucketRow("mid", EvalCell{Column: "score", Operator: OperatorBetween, Value: "[40..60]"})))
+	if err == nil || !strings.Contains(err.Error(), "rule mid overlaps rule low") {
+		t.Fatalf("expected overlap within the score group to be rejected, got %v", err)
+	}
+
+	loose := newBucketTable(t, WithUniquePerGroup())
+	for _, row := range append(rows, grouped("score", bucketRow("mid", EvalCell{Column: "score", Operator: OperatorBetween, Value: "[40..60]"}))) {
+		if err := loose.AddRow(row); err != nil {
+			t.Fatalf("add row %s: %v", row.RuleID, err)
+		}
+	}
+	if reports := loose.DetectGroupOverlaps(); len(reports) != 2 {
+		t.Fatalf("expected mid to overlap low and high, got %#v", reports)
+	}
+	_, err = loose.Evaluate(map[string]any{"score": 55, "region": "US"}, nil)
+	if err == nil || !strings.Contains(err.Error(), `at most one match in group "score", found high and mid`) {
+		t.Fatalf("expected per-group UNIQUE error, got %v", err)
+	}
+
+	global := newBucketTable(t)
+	for _, row := range rows {
+		if err := global.AddRow(row); err != nil {
+			t.Fatalf("add row %s: %v", row.RuleID, err)
+		}
+	}
+	if _, err := global.Evaluate(map[string]any{"score": 70, "region": "EU"}, nil); err == nil {
+		t.Fatalf("expected table-wide UNIQUE to reject matches from two groups")
+	}
+}
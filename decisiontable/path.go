@@ -0,0 +1,169 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// inputPath is a compiled Column.Path. It supports a JSONPath subset: the root $, child keys written
+// .key or ['key'], array indexes [n] and the wildcards [*] and .*, which visit every element of an
+// array. The first step must be a key, which is looked up on the InputProvider.
+type inputPath struct {
+	root  string
+	steps []pathStep
+}
+
+type pathStep struct {
+	key      string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+func parseInputPath(path string) (*inputPath, error) {
+	s := strings.TrimSpace(path)
+	rest, ok := strings.CutPrefix(s, "$")
+	if !ok {
+		return nil, fmt.Errorf("path %q must start with $", path)
+	}
+	var steps []pathStep
+	for rest != "" {
+		var step pathStep
+		switch {
+		case strings.HasPrefix(rest, ".*"):
+			step.wildcard, rest = true, rest[2:]
+		case rest[0] == '.':
+			end := strings.IndexAny(rest[1:], ".[")
+			if end < 0 {
+				end = len(rest) - 1
+			}
+			step.key, rest = rest[1:end+1], rest[end+1:]
+			if step.key == "" {
+				return nil, fmt.Errorf("path %q has an empty key", path)
+			}
+		case rest[0] == '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("path %q has an unclosed [", path)
+			}
+			inner := strings.TrimSpace(rest[1:end])
+			rest = rest[end+1:]
+			switch {
+			case inner == "*":
+				step.wildcard = true
+			case len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0]:
+				step.key = inner[1 : len(inner)-1]
+			default:
+				n, err := strconv.Atoi(inner)
+				if err != nil || n < 0 {
+					return nil, fmt.Errorf("path %q has invalid index [%s]", path, inner)
+				}
+				step.index, step.isIndex = n, true
+			}
+		default:
+			return nil, fmt.Errorf("path %q: unexpected %q", path, rest)
+		}
+		steps = append(steps, step)
+	}
+	if len(steps) == 0 || steps[0].wildcard || steps[0].isIndex {
+		return nil, fmt.Errorf("path %q must start with a key", path)
+	}
+	return &inputPath{root: steps[0].key, steps: steps[1:]}, nil
+}
+
+// collects reports whether the path has a wildcard and so yields a list.
+func (p *inputPath) collects() bool {
+	for _, step := range p.steps {
+		if step.wildcard {
+			return true
+		}
+	}
+	return false
+}
+
+// resolve extracts the path's value from input. A missing key or index makes the value absent; under
+// a wildcard, elements lacking the rest of the path are skipped, and nested wildcards are flattened
+// into one list.
+func (p *inputPath) resolve(input InputProvider) (any, bool) {
+	if input == nil {
+		return nil, false
+	}
+	v, ok := input.Get(p.root)
+	if !ok {
+		return nil, false
+	}
+	return walkPath(v, p.steps)
+}
+
+func walkPath(v any, steps []pathStep) (any, bool) {
+	for i, step := range steps {
+		if step.wildcard {
+			items, err := toInterfaceSlice(v)
+			if err != nil || v == nil {
+				return nil, false
+			}
+			rest := steps[i+1:]
+			flatten := (&inputPath{steps: rest}).collects()
+			out := make([]any, 0, len(items))
+			for _, item := range items {
+				got, ok := walkPath(item, rest)
+				if !ok {
+					continue
+				}
+				if nested, isList := got.([]any); isList && flatten {
+					out = append(out, nested...)
+				} else {
+					out = append(out, got)
+				}
+			}
+			return out, true
+		}
+		if step.isIndex {
+			items, err := toInterfaceSlice(v)
+			if err != nil || step.index >= len(items) {
+				return nil, false
+			}
+			v = items[step.index]
+			continue
+		}
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if v, ok = m[step.key]; !ok {
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+// lookupInput reads a condition column's input, through its compiled Path when it has one.
+func lookupInput(input InputProvider, column string, path *inputPath) (any, bool) {
+	if path != nil {
+		return path.resolve(input)
+	}
+	if input == nil {
+		return nil, false
+	}
+	return input.Get(column)
+}
+
+// columnInput reads a condition column's input as evaluation does.
+func (dt *DecisionTable) columnInput(input InputProvider, col Column) (any, bool) {
+	return lookupInput(input, col.Name, dt.inputPaths[col.Name])
+}
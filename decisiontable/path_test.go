@@ -0,0 +1,138 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPathColumnsExtractNestedArrays(t *testing.T) {
+	const doc = `
+{
+  "decisionTable": {
+    "name": "orders",
+    "policies": {"matchPolicy": "FIRST", "noMatchPolicy": "RETURN_DEFAULT"},
+    "columns": [
+      {"name": "country", "type": "CONDITION", "dataType": "STRING", "path": "$.customer.address.country"},
+      {"name": "prices", "type": "CONDITION", "dataType": "LIST_INTEGER", "path": "$.items[*].price"},
+      {"name": "tags", "type": "CONDITION", "dataType": "LIST_STRING", "path": "$.items[*].tags[*]"},
+      {"name": "action", "type": "CONCLUSION", "dataType": "STRING"}
+    ],
+    "rules": [
+      {"id": "bulk", "when": [{"operator": "equal", "value": "US"}, {"operator": "sumGt", "value": 100}, null], "then": ["discount"]}
+    ],
+    "defaultRule": {"then": ["ship"]}
+  }
+}`
+	dt, err := LoadJSON([]byte(doc), "orders.json")
+	if err != nil {
+		t.Fatalf("load json: %v", err)
+	}
+	if err := dt.InsertRowAt(0, Row{
+		RuleID:      "hazmat",
+		EvalCells:   []EvalCell{{Column: "tags", Operator: OperatorAnyContained, Value: []string{"hazmat"}}},
+		ReturnCells: []ReturnCell{{Column: "action", Value: "inspect"}},
+	}); err != nil {
+		t.Fatalf("insert hazmat rule: %v", err)
+	}
+	order := func(country string, items ...map[string]any) map[string]any {
+		list := make([]any, len(items))
+		for i, item := range items {
+			list[i] = item
+		}
+		return map[string]any{
+			"customer": map[string]any{"address": map[string]any{"country": country}},
+			"items":    list,
+		}
+	}
+	cases := []struct {
+		input map[string]any
+		want  string
+	}{
+		{order("US", map[string]any{"price": 60, "tags": []any{"fragile"}}, map[string]any{"price": 50}), "discount"},
+		{order("CA", map[string]any{"price": 60}, map[string]any{"price": 50}), "ship"},
+		{order("US", map[string]any{"price": 10, "tags": []any{"fragile"}}, map[string]any{"price": 500, "tags": []any{"hazmat"}}), "inspect"},
+		{order("US", map[string]any{"price": 60}), "ship"},
+		{map[string]any{}, "ship"},
+	}
+	for _, tc := range cases {
+		rows, err := dt.Evaluate(tc.input, nil)
+		if err != nil {
+			t.Fatalf("evaluate %v returned error: %v", tc.input, err)
+		}
+		if len(rows) != 1 || rows[0].Values["action"] != tc.want {
+			t.Fatalf("expected %s for %v, got %#v", tc.want, tc.input, rows)
+		}
+	}
+
+	coerced, err := dt.CoerceInput(order("US", map[string]any{"price": "7", "tags": []any{"a", "b"}}, map[string]any{"price": 8, "tags": []any{"c"}}))
+	if err != nil {
+		t.Fatalf("coerce input: %v", err)
+	}
+	prices, _ := coerced["prices"].([]any)
+	tags, _ := coerced["tags"].([]any)
+	if coerced["country"] != "US" || len(prices) != 2 || prices[0] != int64(7) || len(tags) != 3 {
+		t.Fatalf("unexpected coerced input %#v", coerced)
+	}
+}
+
+func TestParseInputPath(t *testing.T) {
+	input := MapInput{"order": map[string]any{
+		"lines": []any{
+			map[string]any{"sku": "a"},
+			map[string]any{"sku": "b"},
+			map[string]any{"qty": 1},
+		},
+		"ship to": "home",
+	}}
+	cases := []struct {
+		path string
+		want any
+		ok   bool
+	}{
+		{"$.order.lines[1].sku", "b", true},
+		{"$['order']['ship to']", "home", true},
+		{"$.order.lines[*].sku", []any{"a", "b"}, true},
+		{"$.order.lines.*.qty", []any{1}, true},
+		{"$.order.lines[3].sku", nil, false},
+		{"$.order.missing", nil, false},
+		{"$.customer.name", nil, false},
+	}
+	for _, tc := range cases {
+		path, err := parseInputPath(tc.path)
+		if err != nil {
+			t.Fatalf("parse %s: %v", tc.path, err)
+		}
+		got, ok := path.resolve(input)
+		if ok != tc.ok || (tc.ok && !valuesEqual(got, tc.want)) {
+			t.Fatalf("%s: expected %#v (%v), got %#v (%v)", tc.path, tc.want, tc.ok, got, ok)
+		}
+	}
+
+	for _, bad := range []string{"order.lines", "$", "$[0]", "$.order[", "$.order[x]", "$..order"} {
+		if _, err := parseInputPath(bad); err == nil {
+			t.Fatalf("expected %q to be rejected", bad)
+		}
+	}
+
+	_, err := NewDecisionTable("orders",
+		[]Column{{Name: "sku", Type: ColumnTypeCondition, DataType: DataTypeString, Path: "$.order.lines[*].sku"}},
+		[]Column{{Name: "ok", Type: ColumnTypeConclusion, DataType: DataTypeBoolean}},
+	)
+	if err == nil || !strings.Contains(err.Error(), "needs a LIST data type") {
+		t.Fatalf("expected a wildcard path on a STRING column to fail, got %v", err)
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// jsonMatchedRow is the JSON form of a MatchedRow written by EvaluateToJSON.
+type jsonMatchedRow struct {
+	RuleID       string                    `json:"ruleId"`
+	Comments     string                    `json:"comments,omitempty"`
+	RowNumber    int                       `json:"rowNumber"`
+	IsDefault    bool                      `json:"isDefault"`
+	Values       map[string]any            `json:"values"`
+	MatchedRules []string                  `json:"matchedRules,omitempty"`
+	Groups       map[string]map[string]any `json:"groups,omitempty"`
+	Metadata     map[string]string         `json:"metadata,omitempty"`
+	Input        map[string]any            `json:"input,omitempty"`
+}
+
+// EvaluateToJSON evaluates input with no caller default and marshals the matches as a JSON array, the
+// primitive a command-line wrapper prints. DECIMAL values are written as exact JSON numbers, DATE
+// values as YYYY-MM-DD, DATETIME values as RFC 3339 strings and DURATION values as Go duration
+// strings such as "1m30s", so every value reads back the way the loaders accept it.
+func EvaluateToJSON(dt *DecisionTable, input map[string]any) ([]byte, error) {
+	if dt == nil {
+		return nil, fmt.Errorf("decision table is nil")
+	}
+	matches, err := dt.Evaluate(input, nil)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]jsonMatchedRow, len(matches))
+	for i, m := range matches {
+		out[i] = jsonMatchedRow{
+			RuleID:       m.RuleID,
+			Comments:     m.Comments,
+			RowNumber:    m.RowNumber,
+			IsDefault:    m.IsDefault,
+			Values:       jsonValues(m.Values, dt.outputColumns),
+			MatchedRules: m.MatchedRules,
+			Metadata:     m.Metadata,
+			Input:        jsonValues(m.Input, dt.conditionColumns),
+		}
+		if m.Groups != nil {
+			out[i].Groups = make(map[string]map[string]any, len(m.Groups))
+			for name, group := range m.Groups {
+				out[i].Groups[name] = jsonValues(group, dt.outputColumns)
+			}
+		}
+	}
+	return json.Marshal(out)
+}
+
+// jsonValues converts values keyed by column name with jsonValue; nil stays nil.
+func jsonValues(values map[string]any, columns map[string]Column) map[string]any {
+	if values == nil {
+		return nil
+	}
+	out := make(map[string]any, len(values))
+	for name, v := range values {
+		out[name] = jsonValue(columns[name].DataType, v)
+	}
+	return out
+}
+
+// jsonValue converts a materialized value to one encoding/json renders faithfully. Values of other
+// types, such as those of a caller-supplied default map, are passed through.
+func jsonValue(dt DataType, v any) any {
+	switch val := v.(type) {
+	case *big.Float:
+		return json.Number(val.Text('f', -1))
+	case time.Time:
+		return formatValue(dt, val)
+	case time.Duration:
+		return val.String()
+	case []any:
+		elemType := elementDataType(dt)
+		items := make([]any, len(val))
+		for i, item := range val {
+			items[i] = jsonValue(elemType, item)
+		}
+		return items
+	default:
+		return v
+	}
+}
@@ -16,13 +16,12 @@ package decisiontable
 
 import "fmt"
 
-func (r Row) matches(input map[string]any) (bool, error) {
+func (r Row) matches(input InputProvider) (bool, error) {
 	for _, cell := range r.EvalCells {
 		if cell.dataType == "" {
 			return false, fmt.Errorf("row %d column %s missing data type metadata", r.Number, cell.Column)
 		}
-		actual := input[cell.Column]
-		match, err := evaluateCell(cell.dataType, cell.Operator, actual, cell.Value)
+		match, err := cell.evaluateInput(input)
 		if err != nil {
 			return false, fmt.Errorf("row %d column %s: %w", r.Number, cell.Column, err)
 		}
@@ -33,10 +32,153 @@ func (r Row) matches(input map[string]any) (bool, error) {
 	return true, nil
 }
 
-func (r Row) materializeReturnValues() map[string]any {
+// evaluateInput evaluates the cell against its column's input value, first resolving a ValueRef to
+// the referenced column's input.
+func (c EvalCell) evaluateInput(input InputProvider) (bool, error) {
+	actual, present := lookupInput(input, c.Column, c.path)
+	if !present {
+		switch c.missing {
+		case MissingPolicyError:
+			return false, fmt.Errorf("%w %s", ErrMissingInput, c.Column)
+		case MissingPolicyDefault:
+			actual = c.missingDefault
+		}
+	}
+	if err := c.checkCurrency(input, actual); err != nil {
+		return false, err
+	}
+	if c.ValueRef == "" {
+		return c.evaluate(actual)
+	}
+	raw, _ := lookupInput(input, c.ValueRef, c.refPath)
+	if c.unit != nil {
+		raw = applyUnit(c.unit, raw)
+	}
+	ref, err := sanitizeActualValue(c.dataType, raw)
+	if err != nil {
+		return false, fmt.Errorf("value reference %s: %w", c.ValueRef, err)
+	}
+	if ref == nil {
+		return false, nil
+	}
+	if s, ok := ref.(string); ok && c.normalize != nil {
+		ref = c.normalize(s)
+	}
+	c.Value = ref
+	return c.evaluate(actual)
+}
+
+// evaluate compares the actual input value against the sanitized cell, applying any per-cell
+// preprocessing configured when the row was prepared.
+func (c EvalCell) evaluate(actual any) (bool, error) {
+	if c.nullUnknown && actual == nil {
+		return false, nil
+	}
+	if c.unit != nil {
+		actual = applyUnit(c.unit, actual)
+	}
+	if c.normalize != nil && actual != nil {
+		normalized, err := normalizeActualStrings(c.dataType, c.Operator, actual, c.normalize)
+		if err != nil {
+			return false, err
+		}
+		actual = normalized
+	}
+	if c.valueRange != nil && actual != nil && c.Operator != OperatorIsNull && c.Operator != OperatorIsNotNull && !isCustomOperator(c.Operator) {
+		coerced, err := sanitizeActualValue(c.dataType, actual)
+		if err != nil {
+			return false, fmt.Errorf("operator %s: %w", c.Operator, err)
+		}
+		if err := c.valueRange.check(coerced); err != nil {
+			return false, fmt.Errorf("input %w", err)
+		}
+		actual = coerced
+	}
+	if c.maxLength > 0 && actual != nil {
+		coerced, err := sanitizeActualValue(c.dataType, actual)
+		if err != nil {
+			return false, fmt.Errorf("operator %s: %w", c.Operator, err)
+		}
+		if s, ok := coerced.(string); ok && len(s) > c.maxLength {
+			return false, fmt.Errorf("operator %s: input length %d exceeds maximum %d", c.Operator, len(s), c.maxLength)
+		}
+		actual = coerced
+	}
+	if c.vacuousAllEqual && actual != nil {
+		items, err := sanitizeActualCollection(c.dataType, actual)
+		if err != nil {
+			return false, fmt.Errorf("operator %s: %w", c.Operator, err)
+		}
+		if len(items) == 0 {
+			return true, nil
+		}
+	}
+	op, want := c.Operator, c.Value
+	if op == OperatorDefault {
+		op, want = OperatorEqual, c.defaultValue
+	}
+	match, err := evaluateCell(c.dataType, op, actual, want, c.Interval)
+	if err != nil {
+		return false, fmt.Errorf("operator %s: %w", c.Operator, err)
+	}
+	return match, nil
+}
+
+// score returns the weighted fraction of satisfied conditions and how many conditions were satisfied.
+// Every condition is evaluated. Rows without conditions score 1.
+func (r Row) score(input InputProvider) (float64, int, error) {
+	var total, satisfied float64
+	matched := 0
+	for _, cell := range r.EvalCells {
+		if cell.dataType == "" {
+			return 0, 0, fmt.Errorf("row %d column %s missing data type metadata", r.Number, cell.Column)
+		}
+		weight := cell.Weight
+		if weight == 0 {
+			weight = 1
+		}
+		total += weight
+		match, err := cell.evaluateInput(input)
+		if err != nil {
+			return 0, 0, fmt.Errorf("row %d column %s: %w", r.Number, cell.Column, err)
+		}
+		if match {
+			satisfied += weight
+			matched++
+		}
+	}
+	if total == 0 {
+		return 1, 0, nil
+	}
+	return satisfied / total, matched, nil
+}
+
+// ConditionsByColumn aligns the row's evaluation cells with cols: the result has one entry per column,
+// nil where the row has no cell for it, whatever order the cells were added in. A column with several
+// cells (WithAllowRepeatedColumns) gets its first. The pointers refer to r.EvalCells.
+func (r Row) ConditionsByColumn(cols []Column) []*EvalCell {
+	aligned := make([]*EvalCell, len(cols))
+	for i, col := range cols {
+		for j := range r.EvalCells {
+			if r.EvalCells[j].Column == col.Name {
+				aligned[i] = &r.EvalCells[j]
+				break
+			}
+		}
+	}
+	return aligned
+}
+
+// materializeReturnValues maps the row's outputs by column, copying reference values unless clone is
+// false.
+func (r Row) materializeReturnValues(clone bool) map[string]any {
 	values := make(map[string]any, len(r.ReturnCells))
 	for _, cell := range r.ReturnCells {
-		values[cell.Column] = cloneValueForType(cell.Value, cell.dataType)
+		if clone {
+			values[cell.Column] = cloneValueForType(cell.Value, cell.dataType)
+		} else {
+			values[cell.Column] = cell.Value
+		}
 	}
 	return values
 }
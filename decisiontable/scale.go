@@ -0,0 +1,96 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// checkScale rejects a rule value of a DECIMAL column written with more fractional digits than the
+// column's Scale. Values are examined in their written form, before they are converted, so "3.555"
+// fails a scale of 2 even though it is a valid decimal; trailing zeros do not count. Lists and
+// intervals are checked element by element.
+func (c Column) checkScale(raw any) error {
+	if c.Scale == 0 {
+		return nil
+	}
+	switch v := raw.(type) {
+	case nil:
+		return nil
+	case []any:
+		for _, elem := range v {
+			if err := c.checkScale(elem); err != nil {
+				return err
+			}
+		}
+		return nil
+	case []string:
+		for _, elem := range v {
+			if err := c.checkScale(elem); err != nil {
+				return err
+			}
+		}
+		return nil
+	case []float64:
+		for _, elem := range v {
+			if err := c.checkScale(elem); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	text, ok := scaleText(raw)
+	if !ok {
+		return nil
+	}
+	if digits := fractionDigits(text); digits > c.Scale {
+		return fmt.Errorf("value %s has %d decimal places, scale allows %d", text, digits, c.Scale)
+	}
+	return nil
+}
+
+// scaleText returns the written form of a decimal value, or false when it has none to examine.
+func scaleText(raw any) (string, bool) {
+	switch v := raw.(type) {
+	case string:
+		return strings.TrimSpace(v), true
+	case json.Number:
+		return v.String(), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32), true
+	default:
+		return "", false
+	}
+}
+
+// fractionDigits counts the significant digits after the decimal point of s, allowing for an exponent.
+func fractionDigits(s string) int {
+	mantissa, exp := s, 0
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		mantissa = s[:i]
+		exp, _ = strconv.Atoi(s[i+1:])
+	}
+	_, frac, _ := strings.Cut(mantissa, ".")
+	n := len(strings.TrimRight(frac, "0")) - exp
+	if n < 0 {
+		return 0
+	}
+	return n
+}
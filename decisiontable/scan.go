@@ -0,0 +1,189 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// ScanTag is the struct tag used by Scan to map output columns onto struct fields.
+// Untagged exported fields match a column with the same name, ignoring case; a tag of "-" skips the field.
+const ScanTag = "dt"
+
+var (
+	bigFloatType = reflect.TypeOf(big.Float{})
+	timeType     = reflect.TypeOf(time.Time{})
+)
+
+// Scan copies the matched values into the struct pointed to by dest.
+// Columns without a corresponding field are ignored, as are fields without a corresponding column.
+func (m MatchedRow) Scan(dest any) error {
+	rv := reflect.ValueOf(dest)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("scan destination must be a non-nil pointer, got %T", dest)
+	}
+	target := rv.Elem()
+	if target.Kind() != reflect.Struct {
+		return fmt.Errorf("scan destination must point to a struct, got %T", dest)
+	}
+
+	lookup := make(map[string]string, len(m.Values))
+	for name := range m.Values {
+		lookup[strings.ToLower(name)] = name
+	}
+
+	targetType := target.Type()
+	for i := 0; i < targetType.NumField(); i++ {
+		field := targetType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tag := field.Tag.Get(ScanTag)
+		if tag == "-" {
+			continue
+		}
+		column := tag
+		if column == "" {
+			resolved, ok := lookup[strings.ToLower(field.Name)]
+			if !ok {
+				continue
+			}
+			column = resolved
+		}
+		value, ok := m.Values[column]
+		if !ok {
+			continue
+		}
+		if err := assignScanValue(target.Field(i), value); err != nil {
+			return fmt.Errorf("column %s: %w", column, err)
+		}
+	}
+	return nil
+}
+
+// EvaluateTyped evaluates the table and scans every resulting row into a new T.
+// T must be a struct type; see MatchedRow.Scan for the field mapping rules.
+func EvaluateTyped[T any](dt *DecisionTable, input map[string]any, defaultReturn map[string]any) ([]T, error) {
+	matches, err := dt.Evaluate(input, defaultReturn)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]T, 0, len(matches))
+	for _, match := range matches {
+		var item T
+		if err := match.Scan(&item); err != nil {
+			return nil, fmt.Errorf("rule %s: %w", match.RuleID, err)
+		}
+		out = append(out, item)
+	}
+	return out, nil
+}
+
+func assignScanValue(dst reflect.Value, src any) error {
+	if src == nil {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+
+	sv := reflect.ValueOf(src)
+	if sv.Type().AssignableTo(dst.Type()) {
+		dst.Set(sv)
+		return nil
+	}
+
+	switch dst.Kind() {
+	case reflect.Pointer:
+		elem := reflect.New(dst.Type().Elem())
+		if err := assignScanValue(elem.Elem(), src); err != nil {
+			return err
+		}
+		dst.Set(elem)
+		return nil
+	case reflect.String:
+		switch v := src.(type) {
+		case string:
+			dst.SetString(v)
+			return nil
+		case *big.Float:
+			dst.SetString(v.Text('g', -1))
+			return nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := toInt64(src)
+		if err != nil {
+			return err
+		}
+		if dst.OverflowInt(i) {
+			return fmt.Errorf("value %d overflows %s", i, dst.Type())
+		}
+		dst.SetInt(i)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		i, err := toInt64(src)
+		if err != nil {
+			return err
+		}
+		if i < 0 || dst.OverflowUint(uint64(i)) {
+			return fmt.Errorf("value %d overflows %s", i, dst.Type())
+		}
+		dst.SetUint(uint64(i))
+		return nil
+	case reflect.Float32, reflect.Float64:
+		dec, err := toBigFloat(src)
+		if err != nil {
+			return err
+		}
+		f, _ := dec.Float64()
+		if dst.OverflowFloat(f) {
+			return fmt.Errorf("value %s overflows %s", dec.Text('g', -1), dst.Type())
+		}
+		dst.SetFloat(f)
+		return nil
+	case reflect.Bool:
+		if b, ok := src.(bool); ok {
+			dst.SetBool(b)
+			return nil
+		}
+	case reflect.Struct:
+		if dst.Type() == bigFloatType {
+			if dec, ok := src.(*big.Float); ok {
+				dst.Set(reflect.ValueOf(cloneDecimal(dec)).Elem())
+				return nil
+			}
+		}
+		if dst.Type() == timeType {
+			if ts, ok := src.(time.Time); ok {
+				dst.Set(reflect.ValueOf(ts))
+				return nil
+			}
+		}
+	case reflect.Slice:
+		if list, ok := src.([]any); ok {
+			out := reflect.MakeSlice(dst.Type(), len(list), len(list))
+			for i, item := range list {
+				if err := assignScanValue(out.Index(i), item); err != nil {
+					return fmt.Errorf("element %d: %w", i, err)
+				}
+			}
+			dst.Set(out)
+			return nil
+		}
+	}
+	return fmt.Errorf("cannot scan %T into %s", src, dst.Type())
+}
@@ -0,0 +1,89 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+)
+
+type eligibilityResult struct {
+	Tier       string
+	Discount   float64
+	DiscountBF *big.Float `dt:"discount"`
+	Ignored    string     `dt:"-"`
+}
+
+func TestEvaluateTypedAllMatches(t *testing.T) {
+	dt := buildSampleTable(t)
+
+	results, err := EvaluateTyped[eligibilityResult](dt, map[string]any{
+		"age":      32,
+		"country":  "US",
+		"segments": []string{"vip"},
+	}, nil)
+	if err != nil {
+		t.Fatalf("evaluate typed returned error: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+	if results[1].Tier != "premium" || results[1].Discount != 0.15 {
+		t.Fatalf("unexpected premium result %#v", results[1])
+	}
+	if results[1].DiscountBF == nil || results[1].DiscountBF.Cmp(big.NewFloat(0.15)) != 0 {
+		t.Fatalf("unexpected decimal discount %v", results[1].DiscountBF)
+	}
+}
+
+func TestEvaluateTypedFirstMatch(t *testing.T) {
+	dt := buildSampleTable(t, WithMatchPolicy(MatchPolicyFirst))
+
+	results, err := EvaluateTyped[eligibilityResult](dt, map[string]any{"age": 20, "country": "CA"}, nil)
+	if err != nil {
+		t.Fatalf("evaluate typed returned error: %v", err)
+	}
+	if len(results) != 1 || results[0].Tier != "standard" {
+		t.Fatalf("unexpected results %#v", results)
+	}
+
+	if _, err := EvaluateTyped[eligibilityResult](dt, map[string]any{"age": 5}, nil); err == nil {
+		t.Fatalf("expected no-match error to propagate")
+	}
+}
+
+func TestEvaluateTypedConversionError(t *testing.T) {
+	dt := buildSampleTable(t)
+
+	type badResult struct {
+		Tier int
+	}
+	_, err := EvaluateTyped[badResult](dt, map[string]any{"age": 20, "country": "CA"}, nil)
+	if err == nil || !strings.Contains(err.Error(), "column tier") {
+		t.Fatalf("expected conversion error naming the column, got %v", err)
+	}
+}
+
+func TestMatchedRowScanRequiresStructPointer(t *testing.T) {
+	row := MatchedRow{Values: map[string]any{"tier": "x"}}
+	var s string
+	if err := row.Scan(&s); err == nil {
+		t.Fatalf("expected error scanning into non-struct")
+	}
+	if err := row.Scan(eligibilityResult{}); err == nil {
+		t.Fatalf("expected error scanning into non-pointer")
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// EvaluateScored scores every row by the weighted fraction of its conditions the input satisfies and
+// returns the rows scoring at least threshold, highest score first. Ties keep table order.
+// The match and no-match policies are not consulted.
+func (dt *DecisionTable) EvaluateScored(input map[string]any, threshold float64) ([]ScoredRow, error) {
+	if math.IsNaN(threshold) {
+		return nil, fmt.Errorf("threshold must be a number")
+	}
+	if err := dt.checkInputKeys(MapInput(input)); err != nil {
+		return nil, err
+	}
+	var scored []ScoredRow
+	now := time.Now()
+	for _, row := range dt.rows {
+		if row.Disabled || !row.activeAt(now) {
+			continue
+		}
+		score, matched, err := row.score(MapInput(input))
+		if err != nil {
+			return nil, err
+		}
+		if score < threshold {
+			continue
+		}
+		scored = append(scored, ScoredRow{
+			MatchedRow:        dt.rowMatch(row),
+			Score:             score,
+			ConditionsMatched: matched,
+			ConditionsTotal:   len(row.EvalCells),
+		})
+	}
+	sort.SliceStable(scored, func(i, j int) bool {
+		return scored[i].Score > scored[j].Score
+	})
+	if err := dt.renderScored(scored, MapInput(input)); err != nil {
+		return nil, err
+	}
+	return scored, nil
+}
+
+// EvaluateTopN returns the n highest-scoring rows, scored as in EvaluateScored, highest score first.
+// Rows with equal scores are ordered by row number. Only the rows kept are materialized, so ranking
+// a large table costs O(rows log n).
+func (dt *DecisionTable) EvaluateTopN(input map[string]any, n int) ([]ScoredRow, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("n must be positive, got %d", n)
+	}
+	if err := dt.checkInputKeys(MapInput(input)); err != nil {
+		return nil, err
+	}
+	h := make(scoreHeap, 0, min(n, len(dt.rows)))
+	now := time.Now()
+	for idx := range dt.rows {
+		row := &dt.rows[idx]
+		if row.Disabled || !row.activeAt(now) {
+			continue
+		}
+		score, matched, err := row.score(MapInput(input))
+		if err != nil {
+			return nil, err
+		}
+		entry := scoredEntry{row: row, score: score, matched: matched}
+		if len(h) < n {
+			heap.Push(&h, entry)
+		} else if h.less(h[0], entry) {
+			h[0] = entry
+			heap.Fix(&h, 0)
+		}
+	}
+	top := make([]ScoredRow, len(h))
+	for i := len(h) - 1; i >= 0; i-- {
+		entry := heap.Pop(&h).(scoredEntry)
+		top[i] = ScoredRow{
+			MatchedRow:        dt.rowMatch(*entry.row),
+			Score:             entry.score,
+			ConditionsMatched: entry.matched,
+			ConditionsTotal:   len(entry.row.EvalCells),
+		}
+	}
+	if err := dt.renderScored(top, MapInput(input)); err != nil {
+		return nil, err
+	}
+	return top, nil
+}
+
+type scoredEntry struct {
+	row     *Row
+	score   float64
+	matched int
+}
+
+// scoreHeap is a min-heap whose root is the weakest entry kept: the lowest score, and among equal
+// scores the highest row number.
+type scoreHeap []scoredEntry
+
+func (h scoreHeap) less(a, b scoredEntry) bool {
+	if a.score != b.score {
+		return a.score < b.score
+	}
+	return a.row.Number > b.row.Number
+}
+
+func (h scoreHeap) Len() int           { return len(h) }
+func (h scoreHeap) Less(i, j int) bool { return h.less(h[i], h[j]) }
+func (h scoreHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *scoreHeap) Push(x any)        { *h = append(*h, x.(scoredEntry)) }
+func (h *scoreHeap) Pop() any {
+	old := *h
+	entry := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return entry
+}
@@ -0,0 +1,244 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import "testing"
+
+func buildOfferTable(t *testing.T) *DecisionTable {
+	t.Helper()
+	evalCols := []Column{
+		{Name: "age", Type: ColumnTypeCondition, DataType: DataTypeInteger},
+		{Name: "country", Type: ColumnTypeCondition, DataType: DataTypeString},
+		{Name: "plan", Type: ColumnTypeCondition, DataType: DataTypeString},
+	}
+	retCols := []Column{
+		{Name: "offer", Type: ColumnTypeConclusion, DataType: DataTypeString},
+	}
+	dt, err := NewDecisionTable("offers", evalCols, retCols)
+	if err != nil {
+		t.Fatalf("failed to build table: %v", err)
+	}
+	rows := []Row{
+		{
+			RuleID: "student",
+			EvalCells: []EvalCell{
+				{Column: "age", Operator: OperatorLess, Value: 25},
+				{Column: "country", Operator: OperatorEqual, Value: "US"},
+				{Column: "plan", Operator: OperatorEqual, Value: "basic"},
+			},
+			ReturnCells: []ReturnCell{{Column: "offer", Value: "student"}},
+		},
+		{
+			RuleID: "family",
+			EvalCells: []EvalCell{
+				{Column: "age", Operator: OperatorGreaterOrEqual, Value: 30, Weight: 1},
+				{Column: "plan", Operator: OperatorEqual, Value: "family", Weight: 3},
+			},
+			ReturnCells: []ReturnCell{{Column: "offer", Value: "family"}},
+		},
+		{
+			RuleID: "global",
+			EvalCells: []EvalCell{
+				{Column: "country", Operator: OperatorNotEqual, Value: "US"},
+				{Column: "plan", Operator: OperatorEqual, Value: "basic"},
+			},
+			ReturnCells: []ReturnCell{{Column: "offer", Value: "global"}},
+		},
+	}
+	for _, row := range rows {
+		if err := dt.AddRow(row); err != nil {
+			t.Fatalf("failed to add row %s: %v", row.RuleID, err)
+		}
+	}
+	return dt
+}
+
+func TestEvaluateScored(t *testing.T) {
+	dt := buildOfferTable(t)
+
+	scored, err := dt.EvaluateScored(map[string]any{"age": 20, "country": "US", "plan": "family"}, 0.5)
+	if err != nil {
+		t.Fatalf("evaluate scored returned error: %v", err)
+	}
+	if len(scored) != 2 {
+		t.Fatalf("expected 2 rows above threshold, got %#v", scored)
+	}
+	if scored[0].RuleID != "family" || scored[0].Score != 0.75 {
+		t.Fatalf("expected weighted family rule first, got %#v", scored[0])
+	}
+	if scored[1].RuleID != "student" || scored[1].Score < 0.66 || scored[1].Score > 0.67 {
+		t.Fatalf("expected student rule second, got %#v", scored[1])
+	}
+	if scored[0].Values["offer"] != "family" {
+		t.Fatalf("expected materialized values, got %#v", scored[0].Values)
+	}
+}
+
+func TestEvaluateScoredTiesKeepTableOrder(t *testing.T) {
+	dt := buildOfferTable(t)
+
+	scored, err := dt.EvaluateScored(map[string]any{"age": 40, "country": "FR", "plan": "gold"}, 0.5)
+	if err != nil {
+		t.Fatalf("evaluate scored returned error: %v", err)
+	}
+	if len(scored) != 1 || scored[0].RuleID != "global" {
+		t.Fatalf("unexpected scored rows %#v", scored)
+	}
+
+	scored, err = dt.EvaluateScored(map[string]any{"age": 20, "country": "US", "plan": "gold"}, 0)
+	if err != nil {
+		t.Fatalf("evaluate scored returned error: %v", err)
+	}
+	if len(scored) != 3 || scored[0].RuleID != "student" || scored[1].RuleID != "family" || scored[2].RuleID != "global" {
+		t.Fatalf("unexpected ordering %#v", scored)
+	}
+	if scored[1].Score != 0 || scored[2].Score != 0 {
+		t.Fatalf("expected tied zero scores, got %v and %v", scored[1].Score, scored[2].Score)
+	}
+}
+
+func TestConditionCountsReportNearMisses(t *testing.T) {
+	dt := buildOfferTable(t)
+	input := map[string]any{"age": 20, "country": "US", "plan": "family"}
+
+	verbose, err := dt.EvaluateVerbose(input)
+	if err != nil {
+		t.Fatalf("evaluate verbose returned error: %v", err)
+	}
+	want := []struct{ matched, total int }{{2, 3}, {1, 2}, {0, 2}}
+	for i, result := range verbose {
+		if result.Matched || result.ConditionsMatched != want[i].matched || result.ConditionsTotal != want[i].total {
+			t.Fatalf("row %s: expected %d/%d conditions, got %+v", result.RuleID, want[i].matched, want[i].total, result)
+		}
+	}
+
+	verbose, err = dt.EvaluateVerbose(map[string]any{"age": 20, "country": "US", "plan": "basic"})
+	if err != nil {
+		t.Fatalf("evaluate verbose returned error: %v", err)
+	}
+	if !verbose[0].Matched || verbose[0].ConditionsMatched != 3 {
+		t.Fatalf("expected the student rule to match all conditions, got %+v", verbose[0])
+	}
+
+	scored, err := dt.EvaluateScored(input, 0)
+	if err != nil {
+		t.Fatalf("evaluate scored returned error: %v", err)
+	}
+	if scored[0].RuleID != "family" || scored[0].ConditionsMatched != 1 || scored[0].ConditionsTotal != 2 {
+		t.Fatalf("expected family to satisfy 1/2 conditions, got %+v", scored[0])
+	}
+	top, err := dt.EvaluateTopN(input, 1)
+	if err != nil {
+		t.Fatalf("evaluate top n returned error: %v", err)
+	}
+	if top[0].ConditionsMatched != 1 || top[0].ConditionsTotal != 2 {
+		t.Fatalf("expected top n to carry the counts, got %+v", top[0])
+	}
+}
+
+func TestNegativeWeightRejected(t *testing.T) {
+	dt := buildOfferTable(t)
+	err := dt.AddRow(Row{
+		EvalCells:   []EvalCell{{Column: "age", Operator: OperatorGreater, Value: 1, Weight: -1}},
+		ReturnCells: []ReturnCell{{Column: "offer", Value: "bad"}},
+	})
+	if err == nil {
+		t.Fatalf("expected negative weight to be rejected")
+	}
+}
+
+func TestEvaluateTopN(t *testing.T) {
+	dt := buildOfferTable(t)
+
+	top, err := dt.EvaluateTopN(map[string]any{"age": 20, "country": "FR", "plan": "basic"}, 2)
+	if err != nil {
+		t.Fatalf("evaluate top n returned error: %v", err)
+	}
+	if len(top) != 2 || top[0].RuleID != "global" || top[1].RuleID != "student" {
+		t.Fatalf("expected global then student, got %#v", top)
+	}
+
+	all, err := dt.EvaluateTopN(map[string]any{"age": 20, "country": "FR", "plan": "basic"}, 10)
+	if err != nil {
+		t.Fatalf("evaluate top n returned error: %v", err)
+	}
+	if len(all) != 3 || all[2].RuleID != "family" || all[2].Score != 0 {
+		t.Fatalf("expected every row ranked, got %#v", all)
+	}
+
+	if _, err := dt.EvaluateTopN(map[string]any{}, 0); err == nil {
+		t.Fatalf("expected non-positive n to fail")
+	}
+}
+
+func TestEvaluateTopNBreaksTiesByRowNumber(t *testing.T) {
+	dt, err := NewDecisionTable("ties",
+		[]Column{{Name: "plan", Type: ColumnTypeCondition, DataType: DataTypeString}},
+		[]Column{{Name: "offer", Type: ColumnTypeConclusion, DataType: DataTypeString}},
+	)
+	if err != nil {
+		t.Fatalf("failed to build table: %v", err)
+	}
+	for _, id := range []string{"a", "b", "c", "d"} {
+		if err := dt.AddRow(Row{
+			RuleID:      id,
+			EvalCells:   []EvalCell{{Column: "plan", Operator: OperatorEqual, Value: "basic"}},
+			ReturnCells: []ReturnCell{{Column: "offer", Value: id}},
+		}); err != nil {
+			t.Fatalf("failed to add row %s: %v", id, err)
+		}
+	}
+
+	top, err := dt.EvaluateTopN(map[string]any{"plan": "basic"}, 3)
+	if err != nil {
+		t.Fatalf("evaluate top n returned error: %v", err)
+	}
+	if len(top) != 3 || top[0].RuleID != "a" || top[1].RuleID != "b" || top[2].RuleID != "c" {
+		t.Fatalf("expected ties in row order, got %#v", top)
+	}
+}
+
+func BenchmarkEvaluateTopN(b *testing.B) {
+	dt, err := NewDecisionTable("bench",
+		[]Column{
+			{Name: "age", Type: ColumnTypeCondition, DataType: DataTypeInteger},
+			{Name: "plan", Type: ColumnTypeCondition, DataType: DataTypeString},
+		},
+		[]Column{{Name: "offer", Type: ColumnTypeConclusion, DataType: DataTypeString}},
+	)
+	if err != nil {
+		b.Fatalf("failed to build table: %v", err)
+	}
+	plans := []string{"basic", "family", "pro"}
+	for i := range 5000 {
+		if err := dt.AddRow(Row{
+			EvalCells: []EvalCell{
+				{Column: "age", Operator: OperatorGreaterOrEqual, Value: i % 100},
+				{Column: "plan", Operator: OperatorEqual, Value: plans[i%len(plans)]},
+			},
+			ReturnCells: []ReturnCell{{Column: "offer", Value: "offer"}},
+		}); err != nil {
+			b.Fatalf("failed to add row %d: %v", i, err)
+		}
+	}
+	input := map[string]any{"age": 40, "plan": "family"}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := dt.EvaluateTopN(input, 10); err != nil {
+			b.Fatalf("evaluate top n returned error: %v", err)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import (
+	"fmt"
+	"sort"
+)
+
+// EvaluateSorted returns every matching rule, as Evaluate does under the ALL policy whatever the
+// table's own match policy, ordered by the output column byColumn, ascending or, with desc,
+// descending. No caller default is used; the no-match policy still applies when nothing matches.
+// Values compare by the column's data type, which must be numeric, temporal or STRING; matches
+// without a value for the column sort last in either direction and ties keep table order.
+func (dt *DecisionTable) EvaluateSorted(input map[string]any, byColumn string, desc bool) ([]MatchedRow, error) {
+	col, ok := dt.outputColumns[byColumn]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownColumn, byColumn)
+	}
+	if !isOrderedDataType(col.DataType) && col.DataType != DataTypeString {
+		return nil, fmt.Errorf("column %s: %s values cannot be sorted", byColumn, col.DataType)
+	}
+	all := *dt
+	all.matchPolicy = MatchPolicyAll
+	matches, err := all.Evaluate(input, nil)
+	if err != nil {
+		return nil, err
+	}
+	var sortErr error
+	sort.SliceStable(matches, func(i, j int) bool {
+		a, b := matches[i].Values[byColumn], matches[j].Values[byColumn]
+		if a == nil || b == nil {
+			return a != nil
+		}
+		if desc {
+			a, b = b, a
+		}
+		less, err := lessValue(col.DataType, a, b)
+		if err != nil && sortErr == nil {
+			sortErr = fmt.Errorf("column %s: %w", byColumn, err)
+		}
+		return less
+	})
+	if sortErr != nil {
+		return nil, sortErr
+	}
+	return matches, nil
+}
+
+// lessValue orders two non-nil sanitized values of an ordered or STRING column.
+func lessValue(dt DataType, a, b any) (bool, error) {
+	if dt != DataTypeString {
+		return compare(dt, OperatorLess, a, b)
+	}
+	as, aok := a.(string)
+	bs, bok := b.(string)
+	if !aok || !bok {
+		return false, fmt.Errorf("values not string: %T vs %T", a, b)
+	}
+	return as < bs, nil
+}
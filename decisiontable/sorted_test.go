@@ -0,0 +1,112 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func buildRecommendationTable(t *testing.T, mp MatchPolicy) *DecisionTable {
+	t.Helper()
+	dt, err := NewDecisionTable("recommendations",
+		[]Column{{Name: "segment", Type: ColumnTypeCondition, DataType: DataTypeString}},
+		[]Column{
+			{Name: "product", Type: ColumnTypeConclusion, DataType: DataTypeString},
+			{Name: "score", Type: ColumnTypeMetadata, DataType: DataTypeDecimal},
+			{Name: "active", Type: ColumnTypeMetadata, DataType: DataTypeBoolean},
+		},
+		WithMatchPolicy(mp),
+	)
+	if err != nil {
+		t.Fatalf("new table: %v", err)
+	}
+	products := []struct {
+		id    string
+		score any
+	}{{"card", "0.45"}, {"loan", "0.9"}, {"unscored", nil}, {"savings", "0.450"}, {"insurance", 0.7}}
+	for _, p := range products {
+		if err := dt.AddRow(Row{
+			RuleID:      p.id,
+			EvalCells:   []EvalCell{{Column: "segment", Operator: OperatorEqual, Value: "retail"}},
+			ReturnCells: []ReturnCell{{Column: "product", Value: p.id}, {Column: "score", Value: p.score}},
+		}); err != nil {
+			t.Fatalf("add row %s: %v", p.id, err)
+		}
+	}
+	return dt
+}
+
+func TestEvaluateSortedByDecimalScore(t *testing.T) {
+	dt := buildRecommendationTable(t, MatchPolicyAll)
+	ids := func(rows []MatchedRow) []string {
+		out := make([]string, len(rows))
+		for i, row := range rows {
+			out[i] = row.RuleID
+		}
+		return out
+	}
+
+	desc, err := dt.EvaluateSorted(map[string]any{"segment": "retail"}, "score", true)
+	if err != nil {
+		t.Fatalf("evaluate sorted returned error: %v", err)
+	}
+	if got, want := ids(desc), []string{"loan", "insurance", "card", "savings", "unscored"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("descending: expected %v, got %v", want, got)
+	}
+	asc, err := dt.EvaluateSorted(map[string]any{"segment": "retail"}, "score", false)
+	if err != nil {
+		t.Fatalf("evaluate sorted returned error: %v", err)
+	}
+	if got, want := ids(asc), []string{"card", "savings", "insurance", "loan", "unscored"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("ascending: expected %v, got %v", want, got)
+	}
+	byName, err := dt.EvaluateSorted(map[string]any{"segment": "retail"}, "product", false)
+	if err != nil {
+		t.Fatalf("evaluate sorted returned error: %v", err)
+	}
+	if got, want := ids(byName), []string{"card", "insurance", "loan", "savings", "unscored"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("by product: expected %v, got %v", want, got)
+	}
+}
+
+func TestEvaluateSortedRejectsUnsortableColumns(t *testing.T) {
+	dt := buildRecommendationTable(t, MatchPolicyAll)
+	if _, err := dt.EvaluateSorted(nil, "missing", true); !errors.Is(err, ErrUnknownColumn) {
+		t.Fatalf("expected unknown column error, got %v", err)
+	}
+	if _, err := dt.EvaluateSorted(nil, "active", true); err == nil || !strings.Contains(err.Error(), "BOOLEAN values cannot be sorted") {
+		t.Fatalf("expected boolean column to be rejected, got %v", err)
+	}
+}
+
+func TestEvaluateSortedIgnoresSingleResultPolicy(t *testing.T) {
+	dt := buildRecommendationTable(t, MatchPolicyFirst)
+	rows, err := dt.EvaluateSorted(map[string]any{"segment": "retail"}, "score", true)
+	if err != nil {
+		t.Fatalf("evaluate sorted returned error: %v", err)
+	}
+	if len(rows) != 5 || rows[0].RuleID != "loan" {
+		t.Fatalf("expected every match sorted with loan first, got %#v", rows)
+	}
+	if dt.MatchPolicy() != MatchPolicyFirst {
+		t.Fatalf("expected the table policy to stay FIRST, got %s", dt.MatchPolicy())
+	}
+	if first, err := dt.Evaluate(map[string]any{"segment": "retail"}, nil); err != nil || len(first) != 1 {
+		t.Fatalf("expected Evaluate to keep FIRST semantics, got %#v (%v)", first, err)
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import (
+	"fmt"
+	"iter"
+)
+
+// RowSource supplies a table definition from a store other than a file, such as a database or a
+// rules API. Columns lists condition and output columns in display order. Rows yields rules in table
+// order and stops at the first error, which LoadFromSource reports.
+type RowSource interface {
+	Columns() []Column
+	Policies() (MatchPolicy, NoMatchPolicy)
+	Rows() iter.Seq2[Row, error]
+}
+
+// DefaultRowSource is implemented by sources that also carry a default row.
+type DefaultRowSource interface {
+	DefaultRow() (Row, bool)
+}
+
+// LoadFromSource builds a table from src. Like the file loaders, the source's policies override any
+// passed in opts, and rows are sanitized as they are read; a repeated non-empty rule ID is an error.
+func LoadFromSource(src RowSource, name string, opts ...Option) (*DecisionTable, error) {
+	if src == nil {
+		return nil, fmt.Errorf("row source is nil")
+	}
+	conditions, outputs := splitColumnsByType(src.Columns())
+	mp, nmp := src.Policies()
+	dt, err := NewDecisionTable(name, conditions, outputs, withDeclaredPolicies(opts, mp, nmp)...)
+	if err != nil {
+		return nil, err
+	}
+	ruleIDs := make(map[string]int)
+	i := 0
+	for row, err := range src.Rows() {
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", i, err)
+		}
+		if err := noteRuleID(ruleIDs, row, i); err != nil {
+			return nil, err
+		}
+		if err := dt.AddRow(row); err != nil {
+			return nil, fmt.Errorf("row %d: %w", i, err)
+		}
+		i++
+	}
+	if withDefault, ok := src.(DefaultRowSource); ok {
+		if row, ok := withDefault.DefaultRow(); ok {
+			if err := dt.SetDefaultRow(row); err != nil {
+				return nil, fmt.Errorf("default row: %w", err)
+			}
+		}
+	}
+	return dt, nil
+}
@@ -0,0 +1,101 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import (
+	"errors"
+	"iter"
+	"strings"
+	"testing"
+)
+
+// memorySource is an in-memory RowSource standing in for a database-backed rule store.
+type memorySource struct {
+	columns  []Column
+	rows     []Row
+	fallback *Row
+	failAt   int
+}
+
+func (s memorySource) Columns() []Column { return s.columns }
+
+func (s memorySource) Policies() (MatchPolicy, NoMatchPolicy) {
+	return MatchPolicyFirst, NoMatchPolicyReturnDefault
+}
+
+func (s memorySource) Rows() iter.Seq2[Row, error] {
+	return func(yield func(Row, error) bool) {
+		for i, row := range s.rows {
+			if s.failAt > 0 && i == s.failAt {
+				yield(Row{}, errors.New("connection reset"))
+				return
+			}
+			if !yield(row, nil) {
+				return
+			}
+		}
+	}
+}
+
+func (s memorySource) DefaultRow() (Row, bool) {
+	if s.fallback == nil {
+		return Row{}, false
+	}
+	return *s.fallback, true
+}
+
+func newMemorySource() memorySource {
+	return memorySource{
+		columns: []Column{
+			{Name: "age", Type: ColumnTypeCondition, DataType: DataTypeInteger},
+			{Name: "tier", Type: ColumnTypeConclusion, DataType: DataTypeString},
+		},
+		rows: []Row{
+			{RuleID: "adult", EvalCells: []EvalCell{{Column: "age", Operator: OperatorGreaterOrEqual, Value: 18}}, ReturnCells: []ReturnCell{{Column: "tier", Value: "adult"}}},
+			{RuleID: "teen", EvalCells: []EvalCell{{Column: "age", Operator: OperatorGreaterOrEqual, Value: 13}}, ReturnCells: []ReturnCell{{Column: "tier", Value: "teen"}}},
+		},
+		fallback: &Row{RuleID: "child", ReturnCells: []ReturnCell{{Column: "tier", Value: "child"}}},
+	}
+}
+
+func TestLoadFromSource(t *testing.T) {
+	dt, err := LoadFromSource(newMemorySource(), "tiers", WithMatchPolicy(MatchPolicyAll))
+	if err != nil {
+		t.Fatalf("load from source: %v", err)
+	}
+	if dt.MatchPolicy() != MatchPolicyFirst || dt.RowCount() != 2 {
+		t.Fatalf("expected source policies and rows, got %s with %d rows", dt.MatchPolicy(), dt.RowCount())
+	}
+	for age, want := range map[int]string{30: "adult", 15: "teen", 5: "child"} {
+		rows, err := dt.Evaluate(map[string]any{"age": age}, nil)
+		if err != nil || len(rows) != 1 || rows[0].RuleID != want {
+			t.Fatalf("age %d: expected %s, got %#v %v", age, want, rows, err)
+		}
+	}
+}
+
+func TestLoadFromSourceErrors(t *testing.T) {
+	failing := newMemorySource()
+	failing.failAt = 1
+	if _, err := LoadFromSource(failing, "tiers"); err == nil || !strings.Contains(err.Error(), "row 1: connection reset") {
+		t.Fatalf("expected source error, got %v", err)
+	}
+
+	dup := newMemorySource()
+	dup.rows[1].RuleID = "adult"
+	if _, err := LoadFromSource(dup, "tiers"); err == nil || !strings.Contains(err.Error(), "duplicate rule id") {
+		t.Fatalf("expected duplicate rule id error, got %v", err)
+	}
+}
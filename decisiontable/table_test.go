@@ -14,7 +14,16 @@
 
 package decisiontable
 
-import "testing"
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
 
 func TestDecisionTableReturnsAllMatches(t *testing.T) {
 	dt := buildSampleTable(t)
@@ -93,6 +102,19 @@ func TestDecisionTableDefaultReturn(t *testing.T) {
 	if rows[0].Values["tier"] != "minor" {
 		t.Fatalf("expected tier minor, got %v", rows[0].Values["tier"])
 	}
+	if !rows[0].IsDefault {
+		t.Fatalf("expected default row to be flagged as default")
+	}
+
+	rows, err = dt.Evaluate(map[string]any{"age": 40, "country": "US"}, nil)
+	if err != nil {
+		t.Fatalf("evaluate returned error: %v", err)
+	}
+	for _, row := range rows {
+		if row.IsDefault {
+			t.Fatalf("expected real matches not to be flagged as default: %#v", row)
+		}
+	}
 }
 
 func TestDecisionTableFallbackDefaultReturn(t *testing.T) {
@@ -115,6 +137,9 @@ func TestDecisionTableFallbackDefaultReturn(t *testing.T) {
 	if rows[0].RowNumber != 0 {
 		t.Fatalf("expected fallback row number 0, got %d", rows[0].RowNumber)
 	}
+	if !rows[0].IsDefault {
+		t.Fatalf("expected fallback row to be flagged as default")
+	}
 }
 
 func TestDecisionTableDecimal(t *testing.T) {
@@ -216,3 +241,1174 @@ func buildSampleTable(t *testing.T, opts ...Option) *DecisionTable {
 	}
 	return dt
 }
+
+func TestDecisionTableEvaluateVerbose(t *testing.T) {
+	dt := buildSampleTable(t, WithMatchPolicy(MatchPolicyFirst))
+
+	results, err := dt.EvaluateVerbose(map[string]any{
+		"age":      25,
+		"country":  "US",
+		"segments": []string{"vip"},
+	})
+	if err != nil {
+		t.Fatalf("evaluate verbose returned error: %v", err)
+	}
+	if len(results) != dt.RowCount() {
+		t.Fatalf("expected %d results, got %d", dt.RowCount(), len(results))
+	}
+	if !results[0].Matched || results[0].Values["tier"] != "standard" {
+		t.Fatalf("first result mismatch: %#v", results[0])
+	}
+	if results[1].Matched || results[1].Values != nil {
+		t.Fatalf("expected premium rule to miss: %#v", results[1])
+	}
+	if !results[2].Matched || results[2].RuleID != "eligibility-vip-segment" {
+		t.Fatalf("third result mismatch: %#v", results[2])
+	}
+
+	results, err = dt.EvaluateVerbose(map[string]any{"age": 5, "country": "FR"})
+	if err != nil {
+		t.Fatalf("expected no error without matches, got %v", err)
+	}
+	for _, r := range results {
+		if r.Matched {
+			t.Fatalf("unexpected match %#v", r)
+		}
+	}
+
+	var nilTable *DecisionTable
+	if _, err := nilTable.EvaluateVerbose(map[string]any{"age": 30}); err == nil {
+		t.Fatalf("expected a nil table to be rejected")
+	}
+}
+
+func TestDecisionTableRejectUnknownInputKeys(t *testing.T) {
+	input := map[string]any{"age": 30, "country": "US", "contry": "CA", "agee": 1}
+
+	permissive := buildSampleTable(t)
+	if _, err := permissive.Evaluate(input, nil); err != nil {
+		t.Fatalf("expected unknown keys to be ignored by default, got %v", err)
+	}
+
+	strict := buildSampleTable(t, WithRejectUnknownInputKeys())
+	_, err := strict.Evaluate(input, nil)
+	if !errors.Is(err, ErrUnknownInputKey) {
+		t.Fatalf("expected ErrUnknownInputKey, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "agee, contry") {
+		t.Fatalf("expected sorted unknown keys in error, got %v", err)
+	}
+	if _, err := strict.Evaluate(map[string]any{"age": 30, "country": "US"}, nil); err != nil {
+		t.Fatalf("expected known keys to pass, got %v", err)
+	}
+}
+
+func TestDecisionTableWithoutConditions(t *testing.T) {
+	retCols := []Column{
+		{Name: "endpoint", Type: ColumnTypeConclusion, DataType: DataTypeString},
+	}
+	if _, err := NewDecisionTable("config", nil, retCols); err == nil {
+		t.Fatalf("expected error without condition columns by default")
+	}
+
+	dt, err := NewDecisionTable("config", nil, retCols, WithAllowNoConditions(), WithMatchPolicy(MatchPolicyFirst))
+	if err != nil {
+		t.Fatalf("failed to build table: %v", err)
+	}
+	for _, endpoint := range []string{"primary", "secondary"} {
+		if err := dt.AddRow(Row{RuleID: endpoint, ReturnCells: []ReturnCell{{Column: "endpoint", Value: endpoint}}}); err != nil {
+			t.Fatalf("failed to add unconditional row: %v", err)
+		}
+	}
+	rows, err := dt.Evaluate(map[string]any{"anything": 1}, nil)
+	if err != nil {
+		t.Fatalf("evaluate returned error: %v", err)
+	}
+	if len(rows) != 1 || rows[0].Values["endpoint"] != "primary" {
+		t.Fatalf("expected first unconditional row, got %#v", rows)
+	}
+}
+
+func TestDecisionTableRejectsDuplicateConditionColumn(t *testing.T) {
+	dt := buildSampleTable(t)
+	err := dt.AddRow(Row{
+		RuleID: "double-age",
+		EvalCells: []EvalCell{
+			{Column: "age", Operator: OperatorGreater, Value: 18},
+			{Column: "age", Operator: OperatorLess, Value: 65},
+		},
+		ReturnCells: []ReturnCell{{Column: "tier", Value: "adult"}},
+	})
+	if err == nil || !strings.Contains(err.Error(), "column age") {
+		t.Fatalf("expected duplicate column error naming age, got %v", err)
+	}
+}
+
+func TestDecisionTableEvaluateFunc(t *testing.T) {
+	dt := buildSampleTable(t)
+	input := map[string]any{"age": 32, "country": "US", "segments": []string{"vip"}}
+
+	var seen []string
+	err := dt.EvaluateFunc(input, func(m MatchedRow) bool {
+		seen = append(seen, m.RuleID)
+		return true
+	})
+	if err != nil {
+		t.Fatalf("evaluate func returned error: %v", err)
+	}
+	if len(seen) != 3 {
+		t.Fatalf("expected 3 streamed matches, got %v", seen)
+	}
+
+	seen = nil
+	err = dt.EvaluateFunc(input, func(m MatchedRow) bool {
+		seen = append(seen, m.RuleID)
+		return false
+	})
+	if err != nil {
+		t.Fatalf("evaluate func returned error: %v", err)
+	}
+	if len(seen) != 1 || seen[0] != "eligibility-standard" {
+		t.Fatalf("expected streaming to stop after first match, got %v", seen)
+	}
+
+	unique := buildSampleTable(t, WithMatchPolicy(MatchPolicyUnique))
+	seen = nil
+	err = unique.EvaluateFunc(input, func(m MatchedRow) bool {
+		seen = append(seen, m.RuleID)
+		return true
+	})
+	if err == nil || len(seen) != 1 {
+		t.Fatalf("expected UNIQUE violation after first yield, got %v with %v", err, seen)
+	}
+}
+
+func TestNilTableReportsDefaultPolicies(t *testing.T) {
+	var dt *DecisionTable
+	if dt.MatchPolicy() != MatchPolicyAll || dt.NoMatchPolicy() != NoMatchPolicyThrowError {
+		t.Fatalf("unexpected policies for a nil table: %s/%s", dt.MatchPolicy(), dt.NoMatchPolicy())
+	}
+}
+
+func TestDecisionTableDefaultRowLifecycle(t *testing.T) {
+	dt := buildSampleTable(t, WithNoMatchPolicy(NoMatchPolicyReturnDefault))
+	if dt.HasDefaultRow() {
+		t.Fatalf("expected no default row initially")
+	}
+
+	setDefault := func(tier string) {
+		t.Helper()
+		err := dt.SetDefaultRow(Row{RuleID: tier, ReturnCells: []ReturnCell{{Column: "tier", Value: tier}}})
+		if err != nil {
+			t.Fatalf("failed to set default row: %v", err)
+		}
+	}
+	noMatch := map[string]any{"age": 1, "country": "FR"}
+
+	setDefault("first")
+	setDefault("second")
+	if !dt.HasDefaultRow() {
+		t.Fatalf("expected default row to be configured")
+	}
+	rows, err := dt.Evaluate(noMatch, nil)
+	if err != nil {
+		t.Fatalf("evaluate returned error: %v", err)
+	}
+	if len(rows) != 1 || rows[0].Values["tier"] != "second" {
+		t.Fatalf("expected replaced default row, got %#v", rows)
+	}
+
+	dt.ClearDefaultRow()
+	if dt.HasDefaultRow() {
+		t.Fatalf("expected default row to be cleared")
+	}
+	rows, err = dt.Evaluate(noMatch, map[string]any{"tier": "fallback"})
+	if err != nil {
+		t.Fatalf("evaluate returned error: %v", err)
+	}
+	if len(rows) != 1 || rows[0].Values["tier"] != "fallback" {
+		t.Fatalf("expected caller fallback after clear, got %#v", rows)
+	}
+
+	var nilTable *DecisionTable
+	nilTable.ClearDefaultRow()
+	if nilTable.HasDefaultRow() {
+		t.Fatalf("expected a nil table to report no default row")
+	}
+}
+
+type customerInput struct {
+	Age     int
+	Country string
+}
+
+func (c customerInput) Get(column string) (any, bool) {
+	switch column {
+	case "age":
+		return c.Age, true
+	case "country":
+		return c.Country, true
+	}
+	return nil, false
+}
+
+func TestDecisionTableEvaluateProvider(t *testing.T) {
+	dt := buildSampleTable(t, WithRejectUnknownInputKeys())
+
+	rows, err := dt.EvaluateProvider(customerInput{Age: 25, Country: "US"}, nil)
+	if err != nil {
+		t.Fatalf("evaluate provider returned error: %v", err)
+	}
+	if len(rows) != 1 || rows[0].RuleID != "eligibility-standard" {
+		t.Fatalf("unexpected matches for struct provider: %#v", rows)
+	}
+
+	fromMap, err := dt.EvaluateProvider(MapInput{"age": 25, "country": "US"}, nil)
+	if err != nil {
+		t.Fatalf("evaluate map input returned error: %v", err)
+	}
+	if len(fromMap) != 1 || fromMap[0].RuleID != rows[0].RuleID {
+		t.Fatalf("expected MapInput to match struct provider, got %#v", fromMap)
+	}
+
+	if _, err := dt.EvaluateProvider(MapInput{"age": 35, "extra": true}, nil); !errors.Is(err, ErrUnknownInputKey) {
+		t.Fatalf("expected unknown key error for MapInput, got %v", err)
+	}
+}
+
+func TestRegexFlagsRequireRegexOperator(t *testing.T) {
+	dt := buildSampleTable(t)
+	err := dt.AddRow(Row{
+		EvalCells:   []EvalCell{{Column: "country", Operator: OperatorEqual, Value: "US", Flags: "i"}},
+		ReturnCells: []ReturnCell{{Column: "tier", Value: "flagged"}},
+	})
+	if err == nil || !strings.Contains(err.Error(), "regex flags") {
+		t.Fatalf("expected regex flag error on EQ cell, got %v", err)
+	}
+}
+
+func TestDecimalOutputScale(t *testing.T) {
+	build := func(opts ...Option) *DecisionTable {
+		t.Helper()
+		dt, err := NewDecisionTable("pricing",
+			[]Column{{Name: "plan", Type: ColumnTypeCondition, DataType: DataTypeString}},
+			[]Column{{Name: "rate", Type: ColumnTypeConclusion, DataType: DataTypeDecimal}},
+			opts...,
+		)
+		if err != nil {
+			t.Fatalf("failed to build table: %v", err)
+		}
+		err = dt.AddRow(Row{
+			EvalCells:   []EvalCell{{Column: "plan", Operator: OperatorEqual, Value: "gold"}},
+			ReturnCells: []ReturnCell{{Column: "rate", Value: 3.4999999999}},
+		})
+		if err != nil {
+			t.Fatalf("failed to add row: %v", err)
+		}
+		return dt
+	}
+	rate := func(dt *DecisionTable) string {
+		t.Helper()
+		rows, err := dt.Evaluate(map[string]any{"plan": "gold"}, nil)
+		if err != nil {
+			t.Fatalf("evaluate returned error: %v", err)
+		}
+		if len(rows) != 1 {
+			t.Fatalf("expected one match, got %d", len(rows))
+		}
+		return rows[0].Values["rate"].(*big.Float).Text('g', -1)
+	}
+
+	if got := rate(build()); got == "3.5" {
+		t.Fatalf("expected unscaled output to keep full precision, got %s", got)
+	}
+	if got := rate(build(WithDecimalOutputScale(2))); got != "3.5" {
+		t.Fatalf("expected 3.5 at scale 2, got %s", got)
+	}
+	if got := rate(build(WithDecimalOutputScale(0))); got != "3" {
+		t.Fatalf("expected 3 at scale 0, got %s", got)
+	}
+
+	if _, err := NewDecisionTable("bad",
+		[]Column{{Name: "plan", Type: ColumnTypeCondition, DataType: DataTypeString}},
+		[]Column{{Name: "rate", Type: ColumnTypeConclusion, DataType: DataTypeDecimal}},
+		WithDecimalOutputScale(-1),
+	); err == nil {
+		t.Fatalf("expected negative scale to be rejected")
+	}
+}
+
+func TestDecisionTableRuleCRUD(t *testing.T) {
+	dt := buildSampleTable(t)
+	want := "eligibility-standard,eligibility-premium,eligibility-vip-segment"
+	if got := strings.Join(dt.RuleIDs(), ","); got != want {
+		t.Fatalf("expected rule ids %s, got %s", want, got)
+	}
+
+	row, ok := dt.GetRow("eligibility-premium")
+	if !ok || row.Number != 2 {
+		t.Fatalf("expected premium row number 2, got %#v (found %v)", row, ok)
+	}
+	if _, ok := dt.GetRow("missing"); ok {
+		t.Fatalf("expected missing rule lookup to fail")
+	}
+
+	err := dt.UpdateRow("eligibility-premium", Row{
+		EvalCells:   []EvalCell{{Column: "age", Operator: OperatorGreaterOrEqual, Value: 40}},
+		ReturnCells: []ReturnCell{{Column: "tier", Value: "senior"}},
+	})
+	if err != nil {
+		t.Fatalf("update row returned error: %v", err)
+	}
+	row, _ = dt.GetRow("eligibility-premium")
+	if row.Number != 2 || row.ReturnCells[0].Value != "senior" {
+		t.Fatalf("expected updated row to keep id and number, got %#v", row)
+	}
+
+	err = dt.UpdateRow("eligibility-premium", Row{
+		RuleID:      "eligibility-standard",
+		EvalCells:   []EvalCell{{Column: "age", Operator: OperatorGreaterOrEqual, Value: 40}},
+		ReturnCells: []ReturnCell{{Column: "tier", Value: "senior"}},
+	})
+	if err == nil || !strings.Contains(err.Error(), "duplicate rule id") {
+		t.Fatalf("expected renaming onto another rule's id to fail, got %v", err)
+	}
+	if _, ok := dt.GetRow("eligibility-premium"); !ok {
+		t.Fatalf("expected the rejected update to leave the row in place")
+	}
+
+	if err := dt.RemoveRow("eligibility-standard"); err != nil {
+		t.Fatalf("remove row returned error: %v", err)
+	}
+	if got := strings.Join(dt.RuleIDs(), ","); got != "eligibility-premium,eligibility-vip-segment" {
+		t.Fatalf("unexpected rule ids after removal: %s", got)
+	}
+	if err := dt.RemoveRow("eligibility-standard"); !errors.Is(err, ErrRuleNotFound) {
+		t.Fatalf("expected ErrRuleNotFound, got %v", err)
+	}
+	if err := dt.UpdateRow("missing", Row{}); !errors.Is(err, ErrRuleNotFound) {
+		t.Fatalf("expected ErrRuleNotFound on update, got %v", err)
+	}
+}
+
+func TestNewDecisionTableWithRows(t *testing.T) {
+	conditions := []Column{{Name: "age", Type: ColumnTypeCondition, DataType: DataTypeInteger}}
+	outputs := []Column{{Name: "band", Type: ColumnTypeConclusion, DataType: DataTypeString}}
+	rows := []Row{
+		{
+			RuleID:      "minor",
+			EvalCells:   []EvalCell{{Column: "age", Operator: OperatorLess, Value: 18}},
+			ReturnCells: []ReturnCell{{Column: "band", Value: "minor"}},
+		},
+		{
+			RuleID:      "adult",
+			EvalCells:   []EvalCell{{Column: "age", Operator: OperatorGreaterOrEqual, Value: 18}},
+			ReturnCells: []ReturnCell{{Column: "band", Value: "adult"}},
+		},
+	}
+
+	dt, err := NewDecisionTableWithRows("bands", conditions, outputs, rows, WithMatchPolicy(MatchPolicyUnique))
+	if err != nil {
+		t.Fatalf("failed to build table: %v", err)
+	}
+	matches, err := dt.Evaluate(map[string]any{"age": 40}, nil)
+	if err != nil {
+		t.Fatalf("evaluate returned error: %v", err)
+	}
+	if len(matches) != 1 || matches[0].Values["band"] != "adult" || matches[0].RowNumber != 2 {
+		t.Fatalf("unexpected matches: %#v", matches)
+	}
+
+	bad := append([]Row(nil), rows...)
+	bad[1].EvalCells = []EvalCell{{Column: "height", Operator: OperatorEqual, Value: 1}}
+	if _, err := NewDecisionTableWithRows("bands", conditions, outputs, bad); err == nil || !strings.HasPrefix(err.Error(), "row 1: ") || !errors.Is(err, ErrUnknownColumn) {
+		t.Fatalf("expected indexed unknown column error, got %v", err)
+	}
+
+	dup := append([]Row(nil), rows...)
+	dup[1].RuleID = "minor"
+	if _, err := NewDecisionTableWithRows("bands", conditions, outputs, dup); err == nil || !strings.Contains(err.Error(), "duplicate rule id") {
+		t.Fatalf("expected duplicate rule id error, got %v", err)
+	}
+}
+
+type recordingObserver struct {
+	events []string
+	count  int
+	err    error
+}
+
+func (o *recordingObserver) OnEvaluateStart() { o.events = append(o.events, "start") }
+
+func (o *recordingObserver) OnRowMatch(ruleID string) { o.events = append(o.events, "match:"+ruleID) }
+
+func (o *recordingObserver) OnEvaluateEnd(_ time.Duration, matchCount int, err error) {
+	o.events = append(o.events, "end")
+	o.count = matchCount
+	o.err = err
+}
+
+func TestDecisionTableObserver(t *testing.T) {
+	obs := &recordingObserver{}
+	dt := buildSampleTable(t, WithObserver(obs))
+
+	if _, err := dt.Evaluate(map[string]any{"age": 35, "country": "US"}, nil); err != nil {
+		t.Fatalf("evaluate returned error: %v", err)
+	}
+	want := "start,match:eligibility-standard,match:eligibility-premium,end"
+	if got := strings.Join(obs.events, ","); got != want || obs.count != 2 || obs.err != nil {
+		t.Fatalf("expected events %s with 2 matches, got %s (count %d, err %v)", want, got, obs.count, obs.err)
+	}
+
+	obs.events = nil
+	if _, err := dt.Evaluate(map[string]any{"age": 1, "country": "FR"}, nil); err == nil {
+		t.Fatalf("expected no-match error")
+	}
+	if got := strings.Join(obs.events, ","); got != "start,end" || obs.count != 0 || obs.err == nil {
+		t.Fatalf("expected start,end with error, got %s (count %d, err %v)", got, obs.count, obs.err)
+	}
+}
+
+func TestDecisionTableOutputGroups(t *testing.T) {
+	dt, err := NewDecisionTableWithRows("pricing",
+		[]Column{{Name: "score", Type: ColumnTypeCondition, DataType: DataTypeInteger}},
+		[]Column{
+			{Name: "tier", Type: ColumnTypeConclusion, DataType: DataTypeString, Group: "pricing"},
+			{Name: "discount", Type: ColumnTypeConclusion, DataType: DataTypeInteger, Group: "pricing"},
+			{Name: "band", Type: ColumnTypeConclusion, DataType: DataTypeString, Group: "risk"},
+			{Name: "note", Type: ColumnTypeMetadata, DataType: DataTypeString},
+		},
+		[]Row{{
+			RuleID:    "good",
+			EvalCells: []EvalCell{{Column: "score", Operator: OperatorGreater, Value: 700}},
+			ReturnCells: []ReturnCell{
+				{Column: "tier", Value: "gold"},
+				{Column: "discount", Value: 10},
+				{Column: "band", Value: "low"},
+				{Column: "note", Value: "prime"},
+			},
+		}},
+	)
+	if err != nil {
+		t.Fatalf("failed to build table: %v", err)
+	}
+	rows, err := dt.Evaluate(map[string]any{"score": 750}, nil)
+	if err != nil {
+		t.Fatalf("evaluate returned error: %v", err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("expected one match, got %d", len(rows))
+	}
+	groups := rows[0].Groups
+	if len(groups) != 2 || groups["pricing"]["tier"] != "gold" || groups["pricing"]["discount"] != int64(10) || groups["risk"]["band"] != "low" {
+		t.Fatalf("unexpected groups: %#v", groups)
+	}
+	if _, ok := groups["pricing"]["note"]; ok || len(rows[0].Values) != 4 {
+		t.Fatalf("expected ungrouped column only in Values, got %#v / %#v", groups, rows[0].Values)
+	}
+
+	ungrouped := buildSampleTable(t)
+	plain, err := ungrouped.Evaluate(map[string]any{"age": 25, "country": "US"}, nil)
+	if err != nil {
+		t.Fatalf("evaluate returned error: %v", err)
+	}
+	if plain[0].Groups != nil {
+		t.Fatalf("expected nil groups without grouped columns, got %#v", plain[0].Groups)
+	}
+
+	_, err = NewDecisionTable("bad",
+		[]Column{{Name: "score", Type: ColumnTypeCondition, DataType: DataTypeInteger, Group: "risk"}},
+		[]Column{{Name: "band", Type: ColumnTypeConclusion, DataType: DataTypeString}},
+	)
+	if err == nil {
+		t.Fatalf("expected grouped condition column to be rejected")
+	}
+}
+
+func TestDecisionTableValidatePatterns(t *testing.T) {
+	dt, err := NewDecisionTableWithRows("codes",
+		[]Column{{Name: "code", Type: ColumnTypeCondition, DataType: DataTypeString}},
+		[]Column{{Name: "kind", Type: ColumnTypeConclusion, DataType: DataTypeString}},
+		[]Row{
+			{
+				RuleID:      "promo",
+				EvalCells:   []EvalCell{{Column: "code", Operator: OperatorMatchesRegex, Value: "^promo-"}},
+				ReturnCells: []ReturnCell{{Column: "kind", Value: "promo"}},
+			},
+			{
+				RuleID:      "exact",
+				EvalCells:   []EvalCell{{Column: "code", Operator: OperatorEqual, Value: "x"}},
+				ReturnCells: []ReturnCell{{Column: "kind", Value: "exact"}},
+			},
+		},
+	)
+	if err != nil {
+		t.Fatalf("failed to build table: %v", err)
+	}
+	if errs := dt.ValidatePatterns(); len(errs) != 0 {
+		t.Fatalf("expected no pattern errors, got %v", errs)
+	}
+
+	row, _ := dt.GetRow("promo")
+	row.EvalCells[0].Value = "^promo-"
+	errs := dt.ValidatePatterns()
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "rule promo row 1 column code") {
+		t.Fatalf("expected one error naming the promo cell, got %v", errs)
+	}
+}
+
+func TestDecisionTableEchoInput(t *testing.T) {
+	dt := buildSampleTable(t, WithEchoInput(), WithMatchPolicy(MatchPolicyFirst))
+	segments := []string{"vip"}
+	rows, err := dt.Evaluate(map[string]any{"age": "25", "country": "US", "segments": segments, "unrelated": 1}, nil)
+	if err != nil {
+		t.Fatalf("evaluate returned error: %v", err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("expected one match, got %d", len(rows))
+	}
+	input := rows[0].Input
+	if len(input) != 3 || input["age"] != int64(25) || input["country"] != "US" {
+		t.Fatalf("unexpected echoed input: %#v", input)
+	}
+	list, ok := input["segments"].([]any)
+	if !ok || len(list) != 1 || list[0] != "vip" {
+		t.Fatalf("expected coerced segments list, got %#v", input["segments"])
+	}
+	segments[0] = "changed"
+	if list[0] != "vip" {
+		t.Fatalf("expected echoed input to be independent of caller data")
+	}
+
+	plain := buildSampleTable(t)
+	rows, err = plain.Evaluate(map[string]any{"age": 25, "country": "US"}, nil)
+	if err != nil {
+		t.Fatalf("evaluate returned error: %v", err)
+	}
+	if rows[0].Input != nil {
+		t.Fatalf("expected no echoed input by default, got %#v", rows[0].Input)
+	}
+}
+
+func TestColumnMinMax(t *testing.T) {
+	conditions := []Column{{Name: "score", Type: ColumnTypeCondition, DataType: DataTypeInteger, Min: 300, Max: 850}}
+	outputs := []Column{{Name: "rate", Type: ColumnTypeConclusion, DataType: DataTypeDecimal, Max: "1"}}
+
+	if _, err := NewDecisionTable("bad", []Column{{Name: "name", Type: ColumnTypeCondition, DataType: DataTypeString, Min: 1}}, outputs); err == nil {
+		t.Fatalf("expected min on a string column to be rejected")
+	}
+	if _, err := NewDecisionTable("bad", []Column{{Name: "score", Type: ColumnTypeCondition, DataType: DataTypeInteger, Min: 10, Max: 5}}, outputs); err == nil {
+		t.Fatalf("expected min above max to be rejected")
+	}
+
+	dt, err := NewDecisionTable("bounds", conditions, outputs, WithMatchPolicy(MatchPolicyFirst))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rate := []ReturnCell{{Column: "rate", Value: "0.1"}}
+	if err := dt.AddRow(Row{EvalCells: []EvalCell{{Column: "score", Operator: OperatorIn, Value: []any{400, 900}}}, ReturnCells: rate}); err == nil || !strings.Contains(err.Error(), "value 900 outside column range [300, 850]") {
+		t.Fatalf("expected out of range IN element to fail, got %v", err)
+	}
+	if err := dt.AddRow(Row{EvalCells: []EvalCell{{Column: "score", Operator: OperatorGreater, Value: 700}}, ReturnCells: []ReturnCell{{Column: "rate", Value: "1.5"}}}); err == nil {
+		t.Fatalf("expected out of range return value to fail")
+	}
+	if err := dt.AddRow(Row{EvalCells: []EvalCell{{Column: "score", Operator: OperatorGreater, Value: 700}}, ReturnCells: rate}); err != nil {
+		t.Fatalf("add row: %v", err)
+	}
+
+	if _, err := dt.Evaluate(map[string]any{"score": 200}, nil); err == nil || !strings.Contains(err.Error(), "outside column range") {
+		t.Fatalf("expected input below min to fail, got %v", err)
+	}
+	rows, err := dt.Evaluate(map[string]any{"score": "800"}, nil)
+	if err != nil || len(rows) != 1 {
+		t.Fatalf("expected in range input to match, got %v %v", rows, err)
+	}
+}
+
+func TestDisabledRuleIsSkipped(t *testing.T) {
+	dt, err := NewDecisionTable("tiers",
+		[]Column{{Name: "age", Type: ColumnTypeCondition, DataType: DataTypeInteger}},
+		[]Column{{Name: "tier", Type: ColumnTypeConclusion, DataType: DataTypeString}},
+		WithMatchPolicy(MatchPolicyFirst))
+	if err != nil {
+		t.Fatalf("new table: %v", err)
+	}
+	for _, row := range []Row{
+		{RuleID: "adult", EvalCells: []EvalCell{{Column: "age", Operator: OperatorGreaterOrEqual, Value: 18}}, ReturnCells: []ReturnCell{{Column: "tier", Value: "adult"}}},
+		{RuleID: "any", EvalCells: []EvalCell{{Column: "age", Operator: OperatorGreaterOrEqual, Value: 0}}, ReturnCells: []ReturnCell{{Column: "tier", Value: "any"}}},
+	} {
+		if err := dt.AddRow(row); err != nil {
+			t.Fatalf("add row: %v", err)
+		}
+	}
+
+	if err := dt.DisableRule("adult"); err != nil {
+		t.Fatalf("disable: %v", err)
+	}
+	rows, err := dt.Evaluate(map[string]any{"age": 30}, nil)
+	if err != nil || len(rows) != 1 || rows[0].RuleID != "any" {
+		t.Fatalf("expected disabled first match to be skipped, got %#v %v", rows, err)
+	}
+	verbose, err := dt.EvaluateVerbose(map[string]any{"age": 30})
+	if err != nil || verbose[0].Matched || !verbose[1].Matched {
+		t.Fatalf("expected verbose to report the disabled rule as unmatched, got %#v %v", verbose, err)
+	}
+
+	if err := dt.EnableRule("adult"); err != nil {
+		t.Fatalf("enable: %v", err)
+	}
+	rows, err = dt.Evaluate(map[string]any{"age": 30}, nil)
+	if err != nil || rows[0].RuleID != "adult" {
+		t.Fatalf("expected re-enabled rule to match, got %#v %v", rows, err)
+	}
+	if err := dt.DisableRule("missing"); !errors.Is(err, ErrRuleNotFound) {
+		t.Fatalf("expected ErrRuleNotFound, got %v", err)
+	}
+}
+
+func TestEvaluateWithDefaultsUsesFirstNonNilLayer(t *testing.T) {
+	dt, err := NewDecisionTable("fees",
+		[]Column{{Name: "plan", Type: ColumnTypeCondition, DataType: DataTypeString}},
+		[]Column{{Name: "fee", Type: ColumnTypeConclusion, DataType: DataTypeInteger}},
+		WithNoMatchPolicy(NoMatchPolicyReturnDefault))
+	if err != nil {
+		t.Fatalf("new table: %v", err)
+	}
+	if err := dt.AddRow(Row{
+		EvalCells:   []EvalCell{{Column: "plan", Operator: OperatorEqual, Value: "pro"}},
+		ReturnCells: []ReturnCell{{Column: "fee", Value: 10}},
+	}); err != nil {
+		t.Fatalf("add row: %v", err)
+	}
+
+	tenant := map[string]any{"fee": 5}
+	global := map[string]any{"fee": 1}
+	rows, err := dt.EvaluateWithDefaults(map[string]any{"plan": "free"}, nil, tenant, global)
+	if err != nil || len(rows) != 1 || !rows[0].IsDefault || rows[0].Values["fee"] != 5 {
+		t.Fatalf("expected tenant default, got %#v %v", rows, err)
+	}
+	rows[0].Values["fee"] = 99
+	if tenant["fee"] != 5 {
+		t.Fatalf("expected the chosen default to be copied")
+	}
+	rows, err = dt.EvaluateWithDefaults(map[string]any{"plan": "free"}, nil, nil, global)
+	if err != nil || rows[0].Values["fee"] != 1 {
+		t.Fatalf("expected global default, got %#v %v", rows, err)
+	}
+	rows, err = dt.EvaluateWithDefaults(map[string]any{"plan": "pro"}, tenant, global)
+	if err != nil || rows[0].IsDefault || rows[0].Values["fee"] != int64(10) {
+		t.Fatalf("expected rule match to win over defaults, got %#v %v", rows, err)
+	}
+	rows, err = dt.EvaluateWithDefaults(map[string]any{"plan": "free"})
+	if err != nil || len(rows) != 0 {
+		t.Fatalf("expected no result without defaults, got %#v %v", rows, err)
+	}
+
+	if err := dt.SetDefaultRow(Row{ReturnCells: []ReturnCell{{Column: "fee", Value: 7}}}); err != nil {
+		t.Fatalf("set default row: %v", err)
+	}
+	rows, err = dt.EvaluateWithDefaults(map[string]any{"plan": "free"}, tenant)
+	if err != nil || rows[0].Values["fee"] != int64(7) {
+		t.Fatalf("expected the default row to take precedence, got %#v %v", rows, err)
+	}
+}
+
+func TestEvaluateTypedRowsAttachesDataTypes(t *testing.T) {
+	dt, err := NewDecisionTable("pricing",
+		[]Column{{Name: "plan", Type: ColumnTypeCondition, DataType: DataTypeString}},
+		[]Column{
+			{Name: "discount", Type: ColumnTypeConclusion, DataType: DataTypeDecimal},
+			{Name: "note", Type: ColumnTypeConclusion, DataType: DataTypeString},
+		},
+		WithMatchPolicy(MatchPolicyFirst),
+		WithNoMatchPolicy(NoMatchPolicyReturnDefault),
+	)
+	if err != nil {
+		t.Fatalf("failed to build table: %v", err)
+	}
+	if err := dt.AddRow(Row{
+		EvalCells:   []EvalCell{{Column: "plan", Operator: OperatorEqual, Value: "basic"}},
+		ReturnCells: []ReturnCell{{Column: "discount", Value: nil}, {Column: "note", Value: nil}},
+	}); err != nil {
+		t.Fatalf("failed to add row: %v", err)
+	}
+
+	matches, err := dt.EvaluateTypedRows(map[string]any{"plan": "basic"}, nil)
+	if err != nil || len(matches) != 1 {
+		t.Fatalf("expected one match, got %#v (err %v)", matches, err)
+	}
+	typed := matches[0].Typed
+	if typed["discount"] != (TypedValue{DataType: DataTypeDecimal}) || typed["note"] != (TypedValue{DataType: DataTypeString}) {
+		t.Fatalf("expected null values tagged with their data types, got %#v", typed)
+	}
+
+	matches, err = dt.EvaluateTypedRows(map[string]any{"plan": "pro"}, map[string]any{"note": "fallback", "extra": 1})
+	if err != nil || len(matches) != 1 {
+		t.Fatalf("expected fallback match, got %#v (err %v)", matches, err)
+	}
+	if got := matches[0].Typed["note"]; got.Value != "fallback" || got.DataType != DataTypeString {
+		t.Fatalf("expected typed fallback note, got %#v", got)
+	}
+	if got := matches[0].Typed["extra"]; got.DataType != "" {
+		t.Fatalf("expected unknown fallback key to have no data type, got %#v", got)
+	}
+}
+
+func TestEvaluateJSONKeepsNumberPrecision(t *testing.T) {
+	dt, err := NewDecisionTable("precise",
+		[]Column{
+			{Name: "id", Type: ColumnTypeCondition, DataType: DataTypeInteger},
+			{Name: "rate", Type: ColumnTypeCondition, DataType: DataTypeDecimal},
+			{Name: "timeout", Type: ColumnTypeCondition, DataType: DataTypeDuration},
+		},
+		[]Column{{Name: "result", Type: ColumnTypeConclusion, DataType: DataTypeString}},
+		WithMatchPolicy(MatchPolicyFirst),
+		WithNoMatchPolicy(NoMatchPolicyReturnDefault),
+	)
+	if err != nil {
+		t.Fatalf("failed to build table: %v", err)
+	}
+	if err := dt.AddRow(Row{
+		EvalCells: []EvalCell{
+			{Column: "id", Operator: OperatorEqual, Value: "9007199254740993"},
+			{Column: "rate", Operator: OperatorGreater, Value: "0.1000000000000000001"},
+		},
+		ReturnCells: []ReturnCell{{Column: "result", Value: "hit"}},
+	}); err != nil {
+		t.Fatalf("failed to add row: %v", err)
+	}
+	if err := dt.SetDefaultRow(Row{ReturnCells: []ReturnCell{{Column: "result", Value: "miss"}}}); err != nil {
+		t.Fatalf("failed to set default row: %v", err)
+	}
+
+	cases := []struct {
+		body string
+		want string
+	}{
+		// both values are indistinguishable from their neighbours once decoded into float64
+		{`{"id": 9007199254740993, "rate": 0.1000000000000000002}`, "hit"},
+		{`{"id": 9007199254740992, "rate": 0.1000000000000000002}`, "miss"},
+		{`{"id": 9007199254740993, "rate": 0.1000000000000000001}`, "miss"},
+		{`{"id": 9007199254740993, "rate": 0.2, "timeout": 1000000}`, "hit"},
+	}
+	for _, tc := range cases {
+		matches, err := dt.EvaluateJSON([]byte(tc.body), nil)
+		if err != nil {
+			t.Fatalf("evaluate %s returned error: %v", tc.body, err)
+		}
+		if len(matches) != 1 || matches[0].Values["result"] != tc.want {
+			t.Fatalf("input %s: expected %s, got %#v", tc.body, tc.want, matches)
+		}
+	}
+
+	for _, body := range []string{`[1]`, `null`, `{"id": 1} {}`, `{"id":`} {
+		if _, err := dt.EvaluateJSON([]byte(body), nil); err == nil || !strings.Contains(err.Error(), "decode json input") {
+			t.Fatalf("input %s: expected decode error, got %v", body, err)
+		}
+	}
+}
+
+func TestEvaluateToJSONRendersValues(t *testing.T) {
+	dt, err := NewDecisionTable("offer",
+		[]Column{{Name: "amount", Type: ColumnTypeCondition, DataType: DataTypeDecimal}},
+		[]Column{
+			{Name: "rate", Type: ColumnTypeConclusion, DataType: DataTypeDecimal},
+			{Name: "starts", Type: ColumnTypeConclusion, DataType: DataTypeDate},
+			{Name: "expires", Type: ColumnTypeConclusion, DataType: DataTypeDateTime},
+			{Name: "hold", Type: ColumnTypeConclusion, DataType: DataTypeDuration},
+			{Name: "terms", Type: ColumnTypeConclusion, DataType: DataTypeListInteger},
+		},
+		WithMatchPolicy(MatchPolicyFirst), WithNoMatchPolicy(NoMatchPolicyThrowError), WithEchoInput(),
+	)
+	if err != nil {
+		t.Fatalf("new table: %v", err)
+	}
+	if err := dt.AddRow(Row{
+		RuleID:    "large",
+		EvalCells: []EvalCell{{Column: "amount", Operator: OperatorGreater, Value: "1000"}},
+		ReturnCells: []ReturnCell{
+			{Column: "rate", Value: "0.12345678901234567890"},
+			{Column: "starts", Value: "2025-07-01"},
+			{Column: "expires", Value: "2025-07-31T23:59:59Z"},
+			{Column: "hold", Value: "90s"},
+			{Column: "terms", Value: []any{12, 24}},
+		},
+	}); err != nil {
+		t.Fatalf("add row: %v", err)
+	}
+
+	data, err := EvaluateToJSON(dt, map[string]any{"amount": "1500.50"})
+	if err != nil {
+		t.Fatalf("evaluate to json: %v", err)
+	}
+	want := `[{"ruleId":"large","rowNumber":1,"isDefault":false,` +
+		`"values":{"expires":"2025-07-31T23:59:59Z","hold":"1m30s","rate":0.1234567890123456789,"starts":"2025-07-01","terms":[12,24]},` +
+		`"input":{"amount":1500.5}}]`
+	if string(data) != want {
+		t.Fatalf("unexpected json\n got: %s\nwant: %s", data, want)
+	}
+
+	if _, err := EvaluateToJSON(dt, map[string]any{"amount": 10}); err == nil {
+		t.Fatalf("expected the no-match error to be returned")
+	}
+	if _, err := EvaluateToJSON(nil, nil); err == nil {
+		t.Fatalf("expected a nil table to be rejected")
+	}
+}
+
+func TestRenumberAfterEdits(t *testing.T) {
+	load := func(ids ...string) *DecisionTable {
+		t.Helper()
+		rules := make([]string, len(ids))
+		for i, id := range ids {
+			rules[i] = fmt.Sprintf(`{"id": %q, "when": [{"operator": "greaterThan", "value": %d}], "then": ["r%d"]}`, id, 10*(len(ids)-i), i)
+		}
+		doc := `{"decisionTable": {"name": "ages", "policies": {"matchPolicy": "FIRST", "noMatchPolicy": "RETURN_DEFAULT"},
+			"columns": [{"name": "age", "type": "CONDITION", "dataType": "INTEGER"}, {"name": "out", "type": "CONCLUSION", "dataType": "STRING"}],
+			"rules": [` + strings.Join(rules, ",") + `], "defaultRule": {"then": ["none"]}}}`
+		dt, err := LoadJSON([]byte(doc), "ages.json")
+		if err != nil {
+			t.Fatalf("load json: %v", err)
+		}
+		return dt
+	}
+
+	dt := load("a", "", "")
+	if err := dt.RemoveRow("a"); err != nil {
+		t.Fatalf("remove row: %v", err)
+	}
+	if err := dt.InsertRowAt(1, Row{
+		RuleID:      "x",
+		EvalCells:   []EvalCell{{Column: "age", Operator: OperatorGreater, Value: 15}},
+		ReturnCells: []ReturnCell{{Column: "out", Value: "x"}},
+	}); err != nil {
+		t.Fatalf("insert row: %v", err)
+	}
+	if err := dt.InsertRowAt(5, Row{}); err == nil {
+		t.Fatalf("expected an out of range index to be rejected")
+	}
+	if err := dt.Renumber(true); err != nil {
+		t.Fatalf("renumber: %v", err)
+	}
+	if got := dt.RuleIDs(); !reflect.DeepEqual(got, []string{"1", "x", "3"}) {
+		t.Fatalf("unexpected rule ids %v", got)
+	}
+	for i, row := range dt.Rows() {
+		if row.Number != i+1 {
+			t.Fatalf("row %s: expected number %d, got %d", row.RuleID, i+1, row.Number)
+		}
+	}
+	rows, err := dt.Evaluate(map[string]any{"age": 1}, nil)
+	if err != nil {
+		t.Fatalf("evaluate returned error: %v", err)
+	}
+	if rows[0].RuleID != "4" || rows[0].RowNumber != 4 {
+		t.Fatalf("expected renumbered default row, got %#v", rows[0])
+	}
+
+	dt = load("b", "", "1")
+	if err := dt.RemoveRow("b"); err != nil {
+		t.Fatalf("remove row: %v", err)
+	}
+	if err := dt.Renumber(true); err == nil || !strings.Contains(err.Error(), `duplicate rule id "1"`) {
+		t.Fatalf("expected a regenerated id collision, got %v", err)
+	}
+	if got := dt.RuleIDs(); !reflect.DeepEqual(got, []string{"2", "1"}) {
+		t.Fatalf("expected a failed renumber to leave the table unchanged, got %v", got)
+	}
+	if err := dt.Renumber(false); err != nil {
+		t.Fatalf("renumber without ids: %v", err)
+	}
+	if got := dt.RuleIDs(); !reflect.DeepEqual(got, []string{"2", "1"}) || dt.Rows()[1].Number != 2 {
+		t.Fatalf("expected ids kept and numbers reassigned, got %v", dt.Rows())
+	}
+}
+
+func TestCoerceInputShowsSanitizedValues(t *testing.T) {
+	dt, err := NewDecisionTable("coerce",
+		[]Column{
+			{Name: "age", Type: ColumnTypeCondition, DataType: DataTypeInteger},
+			{Name: "shipped", Type: ColumnTypeCondition, DataType: DataTypeDate},
+			{Name: "amount", Type: ColumnTypeCondition, DataType: DataTypeDecimal},
+			{Name: "tags", Type: ColumnTypeCondition, DataType: DataTypeListString},
+			{Name: "note", Type: ColumnTypeCondition, DataType: DataTypeString},
+		},
+		[]Column{{Name: "out", Type: ColumnTypeConclusion, DataType: DataTypeString}},
+	)
+	if err != nil {
+		t.Fatalf("new table: %v", err)
+	}
+	got, err := dt.CoerceInput(map[string]any{"age": "12", "shipped": "2025-06-01", "amount": "10.50", "tags": []string{"a"}, "note": nil, "extra": 1})
+	if err != nil {
+		t.Fatalf("coerce input: %v", err)
+	}
+	if got["age"] != int64(12) {
+		t.Fatalf("expected int64 age, got %#v", got["age"])
+	}
+	if shipped, ok := got["shipped"].(time.Time); !ok || !shipped.Equal(time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected time.Time date, got %#v", got["shipped"])
+	}
+	if amount, ok := got["amount"].(*big.Float); !ok || amount.Cmp(big.NewFloat(10.5)) != 0 {
+		t.Fatalf("expected decimal amount, got %#v", got["amount"])
+	}
+	if !reflect.DeepEqual(got["tags"], []any{"a"}) {
+		t.Fatalf("expected list of strings, got %#v", got["tags"])
+	}
+	if v, ok := got["note"]; !ok || v != nil {
+		t.Fatalf("expected null note to stay nil, got %#v", got["note"])
+	}
+	if _, ok := got["extra"]; ok {
+		t.Fatalf("expected unknown keys to be left out, got %v", got)
+	}
+
+	if _, err := dt.CoerceInput(map[string]any{"shipped": "June 1st"}); err == nil || !strings.HasPrefix(err.Error(), "column shipped: ") {
+		t.Fatalf("expected coercion error naming the column, got %v", err)
+	}
+}
+
+func TestConditionsByColumnFollowsColumnOrder(t *testing.T) {
+	dt := buildOfferTable(t)
+	if err := dt.AddRow(Row{
+		RuleID: "reversed",
+		EvalCells: []EvalCell{
+			{Column: "plan", Operator: OperatorEqual, Value: "basic"},
+			{Column: "age", Operator: OperatorLess, Value: 30},
+		},
+		ReturnCells: []ReturnCell{{Column: "offer", Value: "reversed"}},
+	}); err != nil {
+		t.Fatalf("add row: %v", err)
+	}
+	row := dt.Rows()[3]
+	cells := row.ConditionsByColumn(dt.ConditionColumns())
+	if len(cells) != 3 || cells[0] == nil || cells[0].Column != "age" || cells[1] != nil || cells[2] == nil || cells[2].Column != "plan" {
+		t.Fatalf("expected cells aligned to age, country, plan, got %v", cells)
+	}
+	if cells[0] != &row.EvalCells[1] {
+		t.Fatalf("expected pointers into the row's cells")
+	}
+	if got := row.ConditionsByColumn(nil); len(got) != 0 {
+		t.Fatalf("expected no entries without columns, got %v", got)
+	}
+}
+
+func TestNoMatchPolicyWithAndWithoutDefaultRow(t *testing.T) {
+	fallback := map[string]any{"offer": "fallback"}
+	cases := []struct {
+		policy     NoMatchPolicy
+		defaultRow bool
+		want       string // the returned offer, "" for no results
+		wantErr    bool
+	}{
+		{NoMatchPolicyReturnDefault, true, "default", false},
+		{NoMatchPolicyReturnDefault, false, "fallback", false},
+		{NoMatchPolicyThrowError, true, "default", false},
+		{NoMatchPolicyThrowError, false, "", true},
+		{NoMatchPolicyReturnNil, false, "", false},
+	}
+	for _, tc := range cases {
+		t.Run(fmt.Sprintf("%s/default=%v", tc.policy, tc.defaultRow), func(t *testing.T) {
+			dt := buildOfferTable(t)
+			WithNoMatchPolicy(tc.policy)(dt)
+			if tc.defaultRow {
+				if err := dt.SetDefaultRow(Row{ReturnCells: []ReturnCell{{Column: "offer", Value: "default"}}}); err != nil {
+					t.Fatalf("set default row: %v", err)
+				}
+			}
+			rows, err := dt.Evaluate(map[string]any{"age": 99, "country": "US", "plan": "gold"}, fallback)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected a no-match error, got %#v", rows)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("evaluate returned error: %v", err)
+			}
+			if tc.want == "" {
+				if len(rows) != 0 {
+					t.Fatalf("expected no results, got %#v", rows)
+				}
+				return
+			}
+			if len(rows) != 1 || rows[0].Values["offer"] != tc.want || !rows[0].IsDefault {
+				t.Fatalf("expected default result %s, got %#v", tc.want, rows)
+			}
+		})
+	}
+
+	dt := buildOfferTable(t)
+	WithNoMatchPolicy(NoMatchPolicyReturnNil)(dt)
+	if err := dt.SetDefaultRow(Row{ReturnCells: []ReturnCell{{Column: "offer", Value: "default"}}}); err == nil {
+		t.Fatalf("expected RETURN_NIL to reject a default row")
+	}
+	if nmp, err := parseNoMatchPolicyString("return nil"); err != nil || nmp != NoMatchPolicyReturnNil {
+		t.Fatalf("parse RETURN_NIL: %v %v", nmp, err)
+	}
+}
+
+func TestNoMatchBehaviorDescribesFallback(t *testing.T) {
+	cases := []struct {
+		policy     NoMatchPolicy
+		defaultRow bool
+		want       NoMatchDescription
+		text       string
+	}{
+		{NoMatchPolicyReturnDefault, true, NoMatchDescription{Policy: NoMatchPolicyReturnDefault, HasDefaultRow: true, DefaultRuleID: "fallback"}, "RETURN_DEFAULT: returns default row fallback"},
+		{NoMatchPolicyReturnDefault, false, NoMatchDescription{Policy: NoMatchPolicyReturnDefault, UsesDefaultReturn: true}, "RETURN_DEFAULT: returns the caller's default values"},
+		{NoMatchPolicyThrowError, true, NoMatchDescription{Policy: NoMatchPolicyThrowError, HasDefaultRow: true, DefaultRuleID: "fallback"}, "THROW_ERROR: returns default row fallback"},
+		{NoMatchPolicyThrowError, false, NoMatchDescription{Policy: NoMatchPolicyThrowError, Fails: true}, "THROW_ERROR: fails, no default row configured"},
+		{NoMatchPolicyReturnNil, false, NoMatchDescription{Policy: NoMatchPolicyReturnNil}, "RETURN_NIL: returns no results"},
+	}
+	for _, tc := range cases {
+		dt := buildOfferTable(t)
+		WithNoMatchPolicy(tc.policy)(dt)
+		if tc.defaultRow {
+			if err := dt.SetDefaultRow(Row{RuleID: "fallback", ReturnCells: []ReturnCell{{Column: "offer", Value: "none"}}}); err != nil {
+				t.Fatalf("set default row: %v", err)
+			}
+		}
+		got := dt.NoMatchBehavior()
+		if got != tc.want || got.String() != tc.text {
+			t.Fatalf("%s/default=%v: expected %+v (%q), got %+v (%q)", tc.policy, tc.defaultRow, tc.want, tc.text, got, got.String())
+		}
+	}
+}
+
+func buildRateTable(t testing.TB, opts ...Option) *DecisionTable {
+	t.Helper()
+	outputs := make([]Column, 8)
+	cells := make([]ReturnCell, len(outputs))
+	for i := range outputs {
+		name := fmt.Sprintf("rate%d", i)
+		outputs[i] = Column{Name: name, Type: ColumnTypeConclusion, DataType: DataTypeDecimal}
+		cells[i] = ReturnCell{Column: name, Value: fmt.Sprintf("0.%02d5", i)}
+	}
+	dt, err := NewDecisionTable("rates",
+		[]Column{{Name: "tier", Type: ColumnTypeCondition, DataType: DataTypeString}},
+		outputs,
+		append([]Option{WithMatchPolicy(MatchPolicyFirst)}, opts...)...,
+	)
+	if err != nil {
+		t.Fatalf("failed to build table: %v", err)
+	}
+	if err := dt.AddRow(Row{
+		RuleID:      "gold",
+		EvalCells:   []EvalCell{{Column: "tier", Operator: OperatorEqual, Value: "gold"}},
+		ReturnCells: cells,
+	}); err != nil {
+		t.Fatalf("failed to add row: %v", err)
+	}
+	return dt
+}
+
+func TestUnsafeSharedValuesSkipCopies(t *testing.T) {
+	input := map[string]any{"tier": "gold"}
+	for _, shared := range []bool{false, true} {
+		var opts []Option
+		if shared {
+			opts = append(opts, WithUnsafeSharedValues())
+		}
+		dt := buildRateTable(t, opts...)
+		first, err := dt.Evaluate(input, nil)
+		if err != nil {
+			t.Fatalf("evaluate: %v", err)
+		}
+		second, err := dt.Evaluate(input, nil)
+		if err != nil {
+			t.Fatalf("evaluate: %v", err)
+		}
+		a, b := first[0].Values["rate1"].(*big.Float), second[0].Values["rate1"].(*big.Float)
+		if a.Text('f', -1) != "0.015" {
+			t.Fatalf("unexpected rate %s", a.Text('f', -1))
+		}
+		if (a == b) != shared {
+			t.Fatalf("shared=%v: expected results to share values %v, got same pointer %v", shared, shared, a == b)
+		}
+	}
+}
+
+func BenchmarkEvaluateSharedValues(b *testing.B) {
+	input := map[string]any{"tier": "gold"}
+	for _, shared := range []bool{false, true} {
+		b.Run(fmt.Sprintf("shared=%v", shared), func(b *testing.B) {
+			var opts []Option
+			if shared {
+				opts = append(opts, WithUnsafeSharedValues())
+			}
+			dt := buildRateTable(b, opts...)
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, err := dt.Evaluate(input, nil); err != nil {
+					b.Fatalf("evaluate returned error: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestGuardedDefaultsApplyInOrder(t *testing.T) {
+	dt := buildOfferTable(t)
+	if err := dt.AddGuardedDefault(
+		[]EvalCell{{Column: "plan", Operator: OperatorEqual, Value: "premium"}},
+		[]ReturnCell{{Column: "offer", Value: "review"}},
+	); err != nil {
+		t.Fatalf("add review default: %v", err)
+	}
+	if err := dt.AddGuardedDefault(
+		[]EvalCell{{Column: "country", Operator: OperatorEqual, Value: "US"}},
+		[]ReturnCell{{Column: "offer", Value: "reject"}},
+	); err != nil {
+		t.Fatalf("add reject default: %v", err)
+	}
+	offer := func(input map[string]any) (string, error) {
+		matches, err := dt.Evaluate(input, nil)
+		if err != nil {
+			return "", err
+		}
+		if len(matches) != 1 || !matches[0].IsDefault {
+			t.Fatalf("expected one default match for %v, got %#v", input, matches)
+		}
+		return matches[0].Values["offer"].(string), nil
+	}
+
+	if got, err := offer(map[string]any{"age": 40, "country": "US", "plan": "premium"}); err != nil || got != "review" {
+		t.Fatalf("expected the first matching guarded default, got %q (err %v)", got, err)
+	}
+	if got, err := offer(map[string]any{"age": 40, "country": "US", "plan": "gold"}); err != nil || got != "reject" {
+		t.Fatalf("expected the second guarded default, got %q (err %v)", got, err)
+	}
+	matches, err := dt.Evaluate(map[string]any{"age": 20, "country": "US", "plan": "basic"}, nil)
+	if err != nil || len(matches) != 1 || matches[0].IsDefault || matches[0].RuleID != "student" {
+		t.Fatalf("expected a matching rule to win over guarded defaults, got %#v (err %v)", matches, err)
+	}
+	if _, err := offer(map[string]any{"age": 40, "country": "CA", "plan": "gold"}); err == nil {
+		t.Fatalf("expected THROW_ERROR when no guarded default matches and no default row is set")
+	}
+	if err := dt.SetDefaultRow(Row{ReturnCells: []ReturnCell{{Column: "offer", Value: "standard"}}}); err != nil {
+		t.Fatalf("set default row: %v", err)
+	}
+	if got, err := offer(map[string]any{"age": 40, "country": "CA", "plan": "gold"}); err != nil || got != "standard" {
+		t.Fatalf("expected the unconditional default, got %q (err %v)", got, err)
+	}
+	if got := dt.NoMatchBehavior().String(); got != "THROW_ERROR: returns default row "+dt.defaultRow.RuleID+", unless one of 2 guarded defaults matches" {
+		t.Fatalf("unexpected no-match description %q", got)
+	}
+
+	data, err := dt.MarshalBinary()
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	restored, err := LoadBinary(data)
+	if err != nil {
+		t.Fatalf("load binary: %v", err)
+	}
+	if err := dt.EqualErr(restored); err != nil {
+		t.Fatalf("binary round trip changed the table: %v", err)
+	}
+	if err := WriteCSV(dt, io.Discard); err == nil {
+		t.Fatalf("expected the csv layout to reject guarded defaults")
+	}
+	if err := dt.AddGuardedDefault(nil, []ReturnCell{{Column: "offer", Value: "x"}}); err == nil {
+		t.Fatalf("expected a guarded default without conditions to fail")
+	}
+}
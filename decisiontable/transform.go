@@ -0,0 +1,70 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+var transformRegistry = struct {
+	sync.RWMutex
+	funcs map[string]func(string) string
+}{funcs: map[string]func(string) string{
+	"TRIM":  strings.TrimSpace,
+	"UPPER": strings.ToUpper,
+	"LOWER": strings.ToLower,
+}}
+
+// RegisterTransform makes a named string transform available to EvalCell.Transform next to the
+// built-in trim, upper and lower. Names are matched case-insensitively.
+func RegisterTransform(name string, fn func(string) string) error {
+	key := normalizeKeyword(name)
+	if key == "" {
+		return fmt.Errorf("transform name must not be empty")
+	}
+	if fn == nil {
+		return fmt.Errorf("transform %s requires a function", name)
+	}
+	transformRegistry.Lock()
+	defer transformRegistry.Unlock()
+	transformRegistry.funcs[key] = fn
+	return nil
+}
+
+// resolveTransform turns a comma separated list of transform names into one function that applies
+// them in order, after base when base is non-nil.
+func resolveTransform(spec string, base func(string) string) (func(string) string, error) {
+	var chain []func(string) string
+	if base != nil {
+		chain = append(chain, base)
+	}
+	transformRegistry.RLock()
+	defer transformRegistry.RUnlock()
+	for _, name := range strings.Split(spec, ",") {
+		fn, ok := transformRegistry.funcs[normalizeKeyword(name)]
+		if !ok {
+			return nil, fmt.Errorf("unknown transform %q", strings.TrimSpace(name))
+		}
+		chain = append(chain, fn)
+	}
+	return func(s string) string {
+		for _, fn := range chain {
+			s = fn(s)
+		}
+		return s
+	}, nil
+}
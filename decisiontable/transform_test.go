@@ -0,0 +1,85 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCellTransforms(t *testing.T) {
+	const doc = `
+{
+  "decisionTable": {
+    "name": "shipping",
+    "policies": {"matchPolicy": "FIRST", "noMatchPolicy": "RETURN_DEFAULT"},
+    "columns": [
+      {"name": "country", "type": "CONDITION", "dataType": "STRING"},
+      {"name": "zone", "type": "CONCLUSION", "dataType": "STRING"}
+    ],
+    "rules": [
+      {"id": "us", "when": [{"operator": "equal", "value": "us ", "transform": "trim,upper"}], "then": ["domestic"]},
+      {"id": "ca", "when": [{"operator": "equal", "value": "CA"}], "then": ["neighbor"]}
+    ],
+    "defaultRule": {"then": ["international"]}
+  }
+}`
+	dt, err := LoadJSON([]byte(doc), "shipping.json")
+	if err != nil {
+		t.Fatalf("load json: %v", err)
+	}
+	for country, want := range map[string]string{" Us": "domestic", "CA": "neighbor", "ca": "international"} {
+		rows, err := dt.Evaluate(map[string]any{"country": country}, nil)
+		if err != nil || len(rows) != 1 || rows[0].Values["zone"] != want {
+			t.Fatalf("country %q: expected %s, got %#v %v", country, want, rows, err)
+		}
+	}
+}
+
+func TestCellTransformValidation(t *testing.T) {
+	dt := buildSampleTable(t)
+	cases := []struct {
+		cell EvalCell
+		want string
+	}{
+		{EvalCell{Column: "country", Operator: OperatorEqual, Value: "US", Transform: "reverse"}, `unknown transform "reverse"`},
+		{EvalCell{Column: "age", Operator: OperatorEqual, Value: 18, Transform: "trim"}, "only apply to built-in operators on string columns"},
+	}
+	for _, tc := range cases {
+		err := dt.AddRow(Row{EvalCells: []EvalCell{tc.cell}, ReturnCells: []ReturnCell{{Column: "tier", Value: "x"}}})
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("expected error containing %q, got %v", tc.want, err)
+		}
+	}
+
+	if err := RegisterTransform("digits", func(s string) string {
+		return strings.Map(func(r rune) rune {
+			if r >= '0' && r <= '9' {
+				return r
+			}
+			return -1
+		}, s)
+	}); err != nil {
+		t.Fatalf("register transform: %v", err)
+	}
+	cell := EvalCell{Column: "country", Operator: OperatorMatchesRegex, Value: "^[0-9]{3}$", Transform: "digits"}
+	if err := dt.AddRow(Row{EvalCells: []EvalCell{cell}, ReturnCells: []ReturnCell{{Column: "tier", Value: "coded"}}}); err != nil {
+		t.Fatalf("add row: %v", err)
+	}
+	prepared := dt.Rows()[dt.RowCount()-1].EvalCells[0]
+	if match, err := prepared.evaluate("+1-2-3"); err != nil || !match {
+		t.Fatalf("expected transformed input to match the pattern, got %v %v", match, err)
+	}
+}
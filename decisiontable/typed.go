@@ -0,0 +1,52 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+// TypedValue is an output value paired with its column's data type, so a nil can be told apart as a
+// null DECIMAL or a null STRING when serializing.
+type TypedValue struct {
+	Value    any
+	DataType DataType
+}
+
+// TypedMatchedRow is a MatchedRow whose outputs also carry their data types. Typed holds the same
+// keys as Values; keys of a caller-supplied default map that name no output column have an empty
+// DataType.
+type TypedMatchedRow struct {
+	MatchedRow
+	Typed map[string]TypedValue
+}
+
+// EvaluateTypedRows behaves like Evaluate and pairs every returned value with its output column's data type.
+// Use the generic EvaluateTyped to scan the outputs into a struct instead.
+func (dt *DecisionTable) EvaluateTypedRows(input map[string]any, defaultReturn map[string]any) ([]TypedMatchedRow, error) {
+	matches, err := dt.Evaluate(input, defaultReturn)
+	if err != nil {
+		return nil, err
+	}
+	typed := make([]TypedMatchedRow, len(matches))
+	for i, m := range matches {
+		typed[i] = dt.typedMatch(m)
+	}
+	return typed, nil
+}
+
+func (dt *DecisionTable) typedMatch(m MatchedRow) TypedMatchedRow {
+	values := make(map[string]TypedValue, len(m.Values))
+	for name, v := range m.Values {
+		values[name] = TypedValue{Value: v, DataType: dt.outputColumns[name].DataType}
+	}
+	return TypedMatchedRow{MatchedRow: m, Typed: values}
+}
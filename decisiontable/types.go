@@ -14,7 +14,10 @@
 
 package decisiontable
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 // ColumnType describes how a column participates in the decision table.
 type ColumnType string
@@ -25,20 +28,39 @@ const (
 	ColumnTypeMetadata   ColumnType = "METADATA"
 )
 
+// MissingPolicy decides how a condition column treats an input that lacks its key. An explicit null
+// is not missing and is always evaluated as null.
+type MissingPolicy string
+
+const (
+	// MissingPolicyNull evaluates a missing input as null. It is the default.
+	MissingPolicyNull MissingPolicy = "NULL"
+	// MissingPolicyError fails the evaluation with ErrMissingInput when a rule tests the column.
+	MissingPolicyError MissingPolicy = "ERROR"
+	// MissingPolicyDefault evaluates a missing input as the column's Default.
+	MissingPolicyDefault MissingPolicy = "DEFAULT"
+)
+
 type DataType string
 
 const (
-	DataTypeString      DataType = "STRING"
-	DataTypeInteger     DataType = "INTEGER"
-	DataTypeBoolean     DataType = "BOOLEAN"
-	DataTypeDecimal     DataType = "DECIMAL"
-	DataTypeDate        DataType = "DATE"
-	DataTypeDateTime    DataType = "DATETIME"
+	DataTypeString   DataType = "STRING"
+	DataTypeInteger  DataType = "INTEGER"
+	DataTypeBoolean  DataType = "BOOLEAN"
+	DataTypeDecimal  DataType = "DECIMAL"
+	DataTypeDate     DataType = "DATE"
+	DataTypeDateTime DataType = "DATETIME"
+	// DataTypeDuration holds a time.Duration. Values are written as Go duration strings such as "500ms"
+	// or "1h30m"; integers are read as nanoseconds.
+	DataTypeDuration    DataType = "DURATION"
 	DataTypeListString  DataType = "LIST_STRING"
 	DataTypeListInteger DataType = "LIST_INTEGER"
+	DataTypeListDecimal DataType = "LIST_DECIMAL"
 )
 
 // OperatorType controls how an evaluation cell compares its actual value with the expected value.
+// A null or absent input never matches EQ, IN or BETWEEN and always matches NOT_EQ, NOT_IN and
+// NOT_BETWEEN, since null is not among the values; WithThreeValuedNulls makes the negations fail too.
 type OperatorType string
 
 const (
@@ -60,6 +82,60 @@ const (
 	OperatorMatchesRegex    OperatorType = "MATCHES_REGEX"
 	OperatorIsNull          OperatorType = "IS_NULL"
 	OperatorIsNotNull       OperatorType = "IS_NOT_NULL"
+	// OperatorBetween matches values inside a [low, high] range given as a two-element list. Both ends
+	// are inclusive unless EvalCell.Interval or interval notation such as "[0..100)" opens one of them.
+	OperatorBetween OperatorType = "BETWEEN"
+	// OperatorNotBetween matches values outside the range; a null input matches, as with NOT_IN.
+	OperatorNotBetween OperatorType = "NOT_BETWEEN"
+	// OperatorTextEqual compares DECIMAL values by their normalized textual form, so 3.50 does not equal 3.5.
+	// Use EQ for numeric equality; use TEXT_EQ only when the written scale itself is significant.
+	OperatorTextEqual OperatorType = "TEXT_EQ"
+	// OperatorDefault matches an input equal to the column's Default; it takes no operand. A null input
+	// never matches it, so use IS_NULL for an absent value.
+	OperatorDefault OperatorType = "DEFAULT"
+	// OperatorSetEquals matches a list input holding exactly the expected values, ignoring order and
+	// duplicates; EQ on a list column also compares order and length. A null input never matches.
+	OperatorSetEquals OperatorType = "SET_EQ"
+	// OperatorMultisetContained is ALL_CONTAINED_IN counting duplicates: each expected value can match
+	// only one input element, so [a, a] is not contained in [a, b]. An empty or null input matches.
+	OperatorMultisetContained OperatorType = "MULTISET_CONTAINED_IN"
+)
+
+// Aggregate operators fold a LIST_INTEGER or LIST_DECIMAL input into its sum, element count, minimum or
+// maximum and compare the result with a value of the element type; counts are always integers. COUNT
+// also accepts LIST_STRING columns. The sum and count of an empty list are 0; the minimum and maximum of an empty list never match, nor does a null list.
+const (
+	OperatorSumEqual            OperatorType = "SUM_EQ"
+	OperatorSumGreater          OperatorType = "SUM_GT"
+	OperatorSumGreaterOrEqual   OperatorType = "SUM_GT_EQ"
+	OperatorSumLess             OperatorType = "SUM_LT"
+	OperatorSumLessOrEqual      OperatorType = "SUM_LT_EQ"
+	OperatorCountEqual          OperatorType = "COUNT_EQ"
+	OperatorCountGreater        OperatorType = "COUNT_GT"
+	OperatorCountGreaterOrEqual OperatorType = "COUNT_GT_EQ"
+	OperatorCountLess           OperatorType = "COUNT_LT"
+	OperatorCountLessOrEqual    OperatorType = "COUNT_LT_EQ"
+	OperatorMinEqual            OperatorType = "MIN_EQ"
+	OperatorMinGreater          OperatorType = "MIN_GT"
+	OperatorMinGreaterOrEqual   OperatorType = "MIN_GT_EQ"
+	OperatorMinLess             OperatorType = "MIN_LT"
+	OperatorMinLessOrEqual      OperatorType = "MIN_LT_EQ"
+	OperatorMaxEqual            OperatorType = "MAX_EQ"
+	OperatorMaxGreater          OperatorType = "MAX_GT"
+	OperatorMaxGreaterOrEqual   OperatorType = "MAX_GT_EQ"
+	OperatorMaxLess             OperatorType = "MAX_LT"
+	OperatorMaxLessOrEqual      OperatorType = "MAX_LT_EQ"
+)
+
+// String length operators compare the length of a STRING input with an integer value. The length
+// counts Unicode code points (runes), not bytes, so "José" has length 4 however it is encoded. A null
+// input never matches.
+const (
+	OperatorLengthEqual          OperatorType = "LENGTH_EQ"
+	OperatorLengthGreater        OperatorType = "LENGTH_GT"
+	OperatorLengthGreaterOrEqual OperatorType = "LENGTH_GT_EQ"
+	OperatorLengthLess           OperatorType = "LENGTH_LT"
+	OperatorLengthLessOrEqual    OperatorType = "LENGTH_LT_EQ"
 )
 
 // MatchPolicy describes how many rows should be returned after evaluation.
@@ -69,16 +145,73 @@ const (
 	MatchPolicyFirst MatchPolicy = iota
 	MatchPolicyAll
 	MatchPolicyUnique
+	// MatchPolicyCollect folds every matching row, in table order, into a single result.
+	// Plain return cells overwrite the accumulated value; modifier cells adjust it.
+	MatchPolicyCollect
+	// MatchPolicyPriority returns the single matching row with the highest Row.Priority; ties go to
+	// the row that comes first in the table.
+	MatchPolicyPriority
 )
 
-// NoMatchPolicy dictates what evaluate should return when no rows match.
+func (mp MatchPolicy) String() string {
+	switch mp {
+	case MatchPolicyFirst:
+		return "FIRST"
+	case MatchPolicyAll:
+		return "ALL"
+	case MatchPolicyUnique:
+		return "UNIQUE"
+	case MatchPolicyCollect:
+		return "COLLECT"
+	case MatchPolicyPriority:
+		return "PRIORITY"
+	default:
+		return fmt.Sprintf("MatchPolicy(%d)", int(mp))
+	}
+}
+
+// NoMatchPolicy dictates what evaluate should return when no rows match. A configured default row
+// takes precedence under both RETURN_DEFAULT and THROW_ERROR:
+//
+//	policy           default row   no default row
+//	RETURN_DEFAULT   default row   defaultReturn, or no results when it is nil
+//	THROW_ERROR      default row   error
+//	RETURN_NIL       (rejected)    no results
 type NoMatchPolicy int
 
 const (
+	// NoMatchPolicyReturnDefault returns the default row, else the caller's defaultReturn, else nothing.
 	NoMatchPolicyReturnDefault NoMatchPolicy = iota
+	// NoMatchPolicyThrowError fails the evaluation unless a default row is configured, which is returned.
 	NoMatchPolicyThrowError
+	// NoMatchPolicyReturnNil returns no results and no error. Tables with this policy take no default
+	// row and ignore defaultReturn.
+	NoMatchPolicyReturnNil
 )
 
+// ReturnModifier describes how a return cell combines with the accumulated value under MatchPolicyCollect.
+type ReturnModifier string
+
+const (
+	ModifierNone     ReturnModifier = ""
+	ModifierAdd      ReturnModifier = "+="
+	ModifierSubtract ReturnModifier = "-="
+	ModifierMultiply ReturnModifier = "*="
+)
+
+func (nmp NoMatchPolicy) String() string {
+	switch nmp {
+	case NoMatchPolicyReturnDefault:
+		return "RETURN_DEFAULT"
+	case NoMatchPolicyThrowError:
+		return "THROW_ERROR"
+	case NoMatchPolicyReturnNil:
+		return "RETURN_NIL"
+	default:
+		return fmt.Sprintf("NoMatchPolicy(%d)", int(nmp))
+	}
+}
+
 type RowValidationPolicy int
 
 const (
@@ -88,41 +221,204 @@ const (
 
 // Column defines metadata for a decision table column.
 type Column struct {
-	Name     string
+	Name string
+	// Label is an optional human-readable header used when rendering or exporting the table.
+	Label    string
 	Type     ColumnType
 	DataType DataType
+	// Group places an output column in a named output group reported through MatchedRow.Groups.
+	Group string
+	// Min and Max optionally bound an INTEGER or DECIMAL column (inclusive); rule values, return values
+	// and inputs outside the range are rejected.
+	Min any
+	Max any
+	// Template marks a STRING output column whose values are text/template sources rendered against the
+	// evaluation input when a result is returned, so "Hello {{.name}}" greets the input's name. Absent
+	// and null inputs of condition columns render as empty strings, other unknown keys fail the
+	// evaluation, and nothing is escaped.
+	Template bool
+	// Default is the default value of a condition column, which rule cells reference with the DEFAULT
+	// operator.
+	Default any
+	// Unit names the notation of an INTEGER or DECIMAL condition column's strings, PERCENT ("45%") or
+	// CURRENCY ("$1,200.50") or one added with RegisterUnit. String rule values and inputs are parsed
+	// with it before they are coerced, so both sides yield the same number.
+	Unit string
+	// Required marks a condition column that every rule must constrain; a rule without a cell for it is
+	// rejected.
+	Required bool
+	// Scale caps the fractional digits rule values of a DECIMAL column may be written with, so a
+	// monetary column with Scale 2 rejects "3.555"; zero leaves them unchecked.
+	Scale int
+	// Path reads a condition column's input from a nested map with a JSONPath subset instead of the
+	// column's own key: "$.customer.address.country" follows keys and "$.items[*].price" collects a
+	// list for a LIST column.
+	Path string
+	// MissingPolicy decides what a rule testing a condition column sees when the input lacks it.
+	MissingPolicy MissingPolicy
+	// Currency makes an INTEGER or DECIMAL condition column a money column whose rule values are in that
+	// currency, such as "USD". An input in any other currency fails the evaluation with
+	// ErrCurrencyMismatch instead of comparing.
+	Currency string
+	// CurrencyColumn names the sibling STRING condition column carrying each input's currency; it is set
+	// together with Currency.
+	CurrencyColumn string
 }
 
 // EvalCell configures a single evaluation condition inside a row.
+// Weight only affects EvaluateScored; a zero weight counts as 1. Flags holds RE2 flag characters
+// (i, m, s, U) applied to a MATCHES_REGEX pattern. Interval holds the brackets of a BETWEEN or
+// NOT_BETWEEN range: "[]" (the default), "[)", "(]" or "()". Transform names string transforms
+// (trim, upper, lower or one added with RegisterTransform, comma separated to chain) applied to both
+// the rule value and the input of a string column, after any WithStringNormalizer function.
+// ValueRef names another condition column of the same data type whose input value replaces Value,
+// so EQ, NEQ, GT, GT_EQ, LT and LT_EQ can compare two inputs; a null referenced input never matches.
 type EvalCell struct {
-	Column   string
-	Operator OperatorType
-	Value    any
-	dataType DataType
+	Column     string
+	Operator   OperatorType
+	Value      any
+	ValueRef   string
+	Weight     float64
+	Flags      string
+	Interval   string
+	Transform  string
+	dataType   DataType
+	normalize  func(string) string
+	valueRange *columnRange
+	maxLength  int
+	// vacuousAllEqual lets an empty input list satisfy ALL_EQUAL.
+	vacuousAllEqual bool
+	// path and refPath read the inputs of the column and of ValueRef when those have a Path.
+	path    *inputPath
+	refPath *inputPath
+	// missing and missingDefault apply the column's MissingPolicy to an absent input.
+	missing        MissingPolicy
+	missingDefault any
+	// currency, currencyColumn and currencyPath check a money column's input currency.
+	currency       string
+	currencyColumn string
+	currencyPath   *inputPath
+	// nullUnknown keeps a null input from matching a negated comparison.
+	nullUnknown bool
+	// defaultValue is the sanitized column default a DEFAULT cell compares the input with.
+	defaultValue any
+	// unit parses string inputs in the column's Unit notation.
+	unit func(string) string
 }
 
 // ReturnCell stores the payload that will be produced when a row matches.
+// A Modifier (or a string value such as "+= 0.05" on a numeric column) turns the cell into an
+// adjustment of the accumulated value, which is only valid under MatchPolicyCollect.
 type ReturnCell struct {
 	Column   string
 	Value    any
+	Modifier ReturnModifier
 	dataType DataType
 }
 
 // Row models a single decision table row.
+// Priority ranks the row under MatchPolicyPriority; higher values win and other policies ignore it.
+// Disabled rows stay in the table but are skipped by every evaluation; the zero value is enabled.
+// Metadata holds structured annotations such as an owner or ticket. The loaders fill it from METADATA
+// columns other than the reserved ruleId, description, comments, priority, enabled, validFrom,
+// validUntil and group columns.
+// ValidFrom and ValidUntil bound when the row is in effect, from ValidFrom inclusive until ValidUntil
+// exclusive; a zero bound is open. Evaluate checks them against the current time, EvaluateAsOf
+// against a given one.
+// Group names the rule group the row belongs to. WithUniquePerGroup and DetectGroupOverlaps treat
+// each group as its own UNIQUE decision; rows without a group form one group together.
 type Row struct {
 	EvalCells   []EvalCell
 	ReturnCells []ReturnCell
 	RuleID      string
 	Comments    string
 	Number      int
+	Priority    int
+	Disabled    bool
+	Metadata    map[string]string
+	ValidFrom   time.Time
+	ValidUntil  time.Time
+	Group       string
+	// autoID marks a RuleID generated by a loader rather than written in the source.
+	autoID bool
 }
 
 // MatchedRow represents the outcome for a matched rule.
+// IsDefault is true when the result came from the default row or the caller-supplied fallback.
 type MatchedRow struct {
 	Values    map[string]any
 	RuleID    string
 	Comments  string
 	RowNumber int
+	IsDefault bool
+	// RuleGroup is the Group of the matched row.
+	RuleGroup string
+	// MatchedRules lists the rule IDs folded into a MatchPolicyCollect result.
+	MatchedRules []string
+	// Groups holds the values of grouped output columns keyed by group name. It is nil when no
+	// output column has a Group; Values still carries every output.
+	Groups map[string]map[string]any
+	// Input is the evaluated input, coerced per condition column, when WithEchoInput is set.
+	Input map[string]any
+	// Conditions are copies of the evaluation cells of the matched rule, or of a guarded default;
+	// under WithUnsafeSharedValues they are the table's own cells and must not be modified.
+	// Collected results and unconditional defaults carry none.
+	Conditions []EvalCell
+	// Metadata is a copy of the Metadata of the matched row; under WithUnsafeSharedValues it is the
+	// table's own map and must not be modified. Collected results and caller-supplied defaults carry none.
+	Metadata map[string]string
+}
+
+// RowResult reports the outcome of evaluating a single row, regardless of match policy.
+// ConditionsMatched counts the row's conditions the input satisfied out of ConditionsTotal, so a
+// near miss shows as one fewer; disabled and inactive rows are not evaluated and match none.
+type RowResult struct {
+	Values            map[string]any
+	RuleID            string
+	RowNumber         int
+	Matched           bool
+	ConditionsMatched int
+	ConditionsTotal   int
+}
+
+// NoMatchDescription reports how a table handles an input no rule matches; see NoMatchPolicy.
+// UsesDefaultReturn means Evaluate returns the caller's defaultReturn map, or no results when it is nil.
+// Fails means Evaluate returns an error. GuardedDefaults counts the guarded defaults tried first; the
+// other fields describe what happens when none of them matches.
+type NoMatchDescription struct {
+	Policy            NoMatchPolicy
+	HasDefaultRow     bool
+	DefaultRuleID     string
+	UsesDefaultReturn bool
+	Fails             bool
+	GuardedDefaults   int
+}
+
+func (d NoMatchDescription) String() string {
+	var s string
+	switch {
+	case d.HasDefaultRow:
+		s = fmt.Sprintf("%s: returns default row %s", d.Policy, d.DefaultRuleID)
+	case d.UsesDefaultReturn:
+		s = fmt.Sprintf("%s: returns the caller's default values", d.Policy)
+	case d.Fails:
+		s = fmt.Sprintf("%s: fails, no default row configured", d.Policy)
+	default:
+		s = fmt.Sprintf("%s: returns no results", d.Policy)
+	}
+	if d.GuardedDefaults > 0 {
+		s += fmt.Sprintf(", unless one of %d guarded defaults matches", d.GuardedDefaults)
+	}
+	return s
+}
+
+// ScoredRow is a row whose weighted fraction of satisfied conditions reached the scoring threshold.
+// ConditionsMatched and ConditionsTotal are the unweighted counts behind the score.
+type ScoredRow struct {
+	MatchedRow
+	Score             float64
+	ConditionsMatched int
+	ConditionsTotal   int
 }
 
 // Option allows configuring a DecisionTable during construction.
@@ -149,6 +445,186 @@ func WithRowValidationPolicy(rvp RowValidationPolicy) Option {
 	}
 }
 
+// WithRuleIDGenerator customises the IDs the loaders assign to rules that do not declare one.
+// Generated IDs are still checked for uniqueness against explicit ones.
+func WithRuleIDGenerator(gen func(rowNumber int) string) Option {
+	return func(dt *DecisionTable) {
+		dt.ruleIDGenerator = gen
+	}
+}
+
+// WithAllowEmptyCollections accepts empty expected collections for collection operators.
+// An empty IN never matches while an empty CONTAINS_ALL always matches, so this must be opted into.
+func WithAllowEmptyCollections() Option {
+	return func(dt *DecisionTable) {
+		dt.allowEmptyCollections = true
+	}
+}
+
+// WithRejectUnknownInputKeys makes evaluation fail when the input contains keys that are not condition columns.
+// By default unknown keys are ignored so callers can pass a superset of data. Providers passed to
+// EvaluateProvider are only checked when they also implement Keys() []string, as MapInput does.
+func WithRejectUnknownInputKeys() Option {
+	return func(dt *DecisionTable) {
+		dt.rejectUnknownKeys = true
+	}
+}
+
+// WithAllowNoConditions permits tables without condition columns, such as configuration lookups
+// that only carry a default and metadata. Every rule of such a table matches unconditionally,
+// so the match policy alone decides what Evaluate returns.
+func WithAllowNoConditions() Option {
+	return func(dt *DecisionTable) {
+		dt.allowNoConditions = true
+	}
+}
+
+// WithStringNormalizer applies fn to STRING and LIST_STRING values, both rule values and inputs,
+// after coercion and before comparison. Use it for Unicode (NFC) or whitespace normalization.
+// Regex patterns are left untouched but the inputs they match against are normalized.
+func WithStringNormalizer(fn func(string) string) Option {
+	return func(dt *DecisionTable) {
+		dt.stringNormalizer = fn
+	}
+}
+
+// WithRepeatedConditionColumns lets a row hold more than one evaluation cell for the same condition
+// column, for example a lower and an upper bound. All cells must hold for the row to match.
+func WithRepeatedConditionColumns() Option {
+	return func(dt *DecisionTable) {
+		dt.allowRepeatedColumns = true
+	}
+}
+
+// WithVersion records the version of the rule set so consumers can check compatibility and log which
+// rules produced a decision. Loaders set it from the file when the file declares one.
+func WithVersion(version string) Option {
+	return func(dt *DecisionTable) {
+		dt.version = version
+	}
+}
+
+// Observer receives callbacks around each evaluation so callers can bridge timing and match counts
+// to metrics or tracing systems. OnRowMatch is called for every result yielded, including default
+// rows; under COLLECT it is called once per folded rule. Callbacks run synchronously.
+type Observer interface {
+	OnEvaluateStart()
+	OnRowMatch(ruleID string)
+	OnEvaluateEnd(duration time.Duration, matchCount int, err error)
+}
+
+// WithObserver installs an Observer on Evaluate, EvaluateProvider and EvaluateFunc. Without one no
+// timing is taken.
+func WithObserver(o Observer) Option {
+	return func(dt *DecisionTable) {
+		dt.observer = o
+	}
+}
+
+// WithEchoInput attaches to every MatchedRow a deep copy of the condition column values that were
+// evaluated, coerced to the columns' data types, so audit records hold exactly what the engine
+// compared. Each result gets its own copy, which costs one map per match per evaluation.
+func WithEchoInput() Option {
+	return func(dt *DecisionTable) {
+		dt.echoInput = true
+	}
+}
+
+// WithExplicitAnyCells makes the Excel and CSV loaders reject blank condition cells in rules of a
+// strict table, so a forgotten cell cannot silently widen a rule. Authors write ANY or * to match any
+// value; those markers are accepted with or without this option. Lenient tables ignore it, and the
+// CSV exporter writes * for unconstrained cells when it is in effect.
+func WithExplicitAnyCells() Option {
+	return func(dt *DecisionTable) {
+		dt.requireExplicitAny = true
+	}
+}
+
+// WithMaxStringLength rejects MATCHES_REGEX inputs longer than n bytes with an evaluation error instead
+// of matching them, bounding the work a single condition can do. Inputs are never truncated, since a
+// truncated value could match a pattern the full value does not. Zero, the default, disables the limit.
+func WithMaxStringLength(n int) Option {
+	return func(dt *DecisionTable) {
+		dt.maxStringLength = n
+	}
+}
+
+// WithVacuousAllEqual makes ALL_EQUAL match an empty input list, since every element of an empty list
+// equals the value. By default an empty list never matches ALL_EQUAL, so "all transactions approved"
+// fails for a customer without transactions. A null input never matches either way.
+func WithVacuousAllEqual() Option {
+	return func(dt *DecisionTable) {
+		dt.vacuousAllEqual = true
+	}
+}
+
+// WithThreeValuedNulls treats a null or absent input as unknown in the negated comparisons NOT_EQ,
+// NOT_IN and NOT_BETWEEN, as SQL does, so "country NOT_IN US,CA" no longer matches a record without a
+// country. By default a null input is simply not in the list: it never matches EQ, IN or BETWEEN and
+// always matches their negations.
+func WithThreeValuedNulls() Option {
+	return func(dt *DecisionTable) {
+		dt.threeValuedNulls = true
+	}
+}
+
+// WithUniquePerGroup makes MatchPolicyUnique, and WithValidateUniqueness, apply within each rule group
+// (Row.Group) instead of across the whole table, so one table can host several independent decisions.
+// Evaluate returns one match per group that has one and fails when any group matches more than once.
+func WithUniquePerGroup() Option {
+	return func(dt *DecisionTable) {
+		dt.uniquePerGroup = true
+	}
+}
+
+// WithValidateUniqueness makes AddRow, and therefore every loader, reject a rule of a UNIQUE table
+// that may match together with a rule already in the table, as reported by DetectOverlaps.
+// Other match policies ignore it.
+func WithValidateUniqueness() Option {
+	return func(dt *DecisionTable) {
+		dt.validateUniqueness = true
+	}
+}
+
+// WithCoercionCache makes EvaluateBatch remember up to size coerced input values per batch, keyed by
+// data type and raw value, so records that repeat a date string or decimal are parsed once. Zero, the
+// default, disables the cache.
+func WithCoercionCache(size int) Option {
+	return func(dt *DecisionTable) {
+		dt.coercionCacheSize = size
+	}
+}
+
+// WithUnsafeSharedValues makes results hand out the values, conditions and metadata stored in the table
+// instead of copies, so decimals, lists and other reference values are no longer allocated per match. Callers must treat
+// result values as read-only: mutating a returned *big.Float or slice changes the rule itself, for
+// every later evaluation and every goroutine. COLLECT results are still copied, as their values are
+// folded in place.
+func WithUnsafeSharedValues() Option {
+	return func(dt *DecisionTable) {
+		dt.sharedValues = true
+	}
+}
+
+// WithSparseOutput omits output columns whose value is null from result maps, so a column such as
+// rejectionReason only appears for the rules that set it. Null covers an absent key in the JSON object
+// form of "then", an explicit JSON null and a blank Excel or CSV output cell.
+func WithSparseOutput() Option {
+	return func(dt *DecisionTable) {
+		dt.sparseOutput = true
+	}
+}
+
+// WithDecimalOutputScale rounds DECIMAL return values to digits decimal places when they are
+// materialized into results. Rule values keep full precision, so COLLECT accumulation and
+// comparisons are unaffected.
+func WithDecimalOutputScale(digits int) Option {
+	return func(dt *DecisionTable) {
+		dt.decimalOutputScale = digits
+		dt.roundDecimalOutputs = true
+	}
+}
+
 func (c Column) validate() error {
 	if c.Name == "" {
 		return fmt.Errorf("column name must be provided")
@@ -159,6 +635,51 @@ func (c Column) validate() error {
 	default:
 		return fmt.Errorf("column %s has unsupported type %s", c.Name, c.Type)
 	}
+	if c.Group != "" && c.Type == ColumnTypeCondition {
+		return fmt.Errorf("column %s: only output columns can belong to a group", c.Name)
+	}
+	if c.Required && c.Type != ColumnTypeCondition {
+		return fmt.Errorf("column %s: only condition columns can be required", c.Name)
+	}
+	if c.Path != "" {
+		if c.Type != ColumnTypeCondition {
+			return fmt.Errorf("column %s: only condition columns can have a path", c.Name)
+		}
+		path, err := parseInputPath(c.Path)
+		if err != nil {
+			return fmt.Errorf("column %s: %w", c.Name, err)
+		}
+		if path.collects() && !isListDataType(c.DataType) {
+			return fmt.Errorf("column %s: path %s yields a list, so the column needs a LIST data type", c.Name, c.Path)
+		}
+	}
+	switch c.MissingPolicy {
+	case "", MissingPolicyNull, MissingPolicyError:
+	case MissingPolicyDefault:
+		if c.Default == nil {
+			return fmt.Errorf("column %s: missing policy DEFAULT requires a column default", c.Name)
+		}
+	default:
+		return fmt.Errorf("column %s has unsupported missing policy %s", c.Name, c.MissingPolicy)
+	}
+	if c.MissingPolicy != "" && c.Type != ColumnTypeCondition {
+		return fmt.Errorf("column %s: only condition columns can have a missing policy", c.Name)
+	}
+	if (c.Currency == "") != (c.CurrencyColumn == "") {
+		return fmt.Errorf("column %s: currency and currency column must be set together", c.Name)
+	}
+	if c.Currency != "" && (c.Type != ColumnTypeCondition || (c.DataType != DataTypeInteger && c.DataType != DataTypeDecimal)) {
+		return fmt.Errorf("column %s: only INTEGER and DECIMAL condition columns can have a currency", c.Name)
+	}
+	if c.Scale < 0 {
+		return fmt.Errorf("column %s: scale must not be negative", c.Name)
+	}
+	if c.Scale > 0 && c.DataType != DataTypeDecimal {
+		return fmt.Errorf("column %s: only DECIMAL columns can have a scale", c.Name)
+	}
+	if c.Template && (c.Type == ColumnTypeCondition || c.DataType != DataTypeString) {
+		return fmt.Errorf("column %s: only STRING output columns can hold templates", c.Name)
+	}
 	switch c.DataType {
 	case DataTypeString,
 		DataTypeInteger,
@@ -166,10 +687,20 @@ func (c Column) validate() error {
 		DataTypeDecimal,
 		DataTypeDate,
 		DataTypeDateTime,
+		DataTypeDuration,
 		DataTypeListString,
-		DataTypeListInteger:
-		return nil
+		DataTypeListInteger,
+		DataTypeListDecimal:
 	default:
 		return fmt.Errorf("column %s has unsupported data type %s", c.Name, c.DataType)
 	}
+	valueRange, err := c.valueRange()
+	if err != nil {
+		return err
+	}
+	if _, err := c.unitParser(); err != nil {
+		return err
+	}
+	_, err = c.defaultValue(valueRange)
+	return err
 }
@@ -0,0 +1,110 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+var unitRegistry = struct {
+	sync.RWMutex
+	funcs map[string]func(string) string
+}{funcs: map[string]func(string) string{
+	"PERCENT":  parsePercent,
+	"CURRENCY": parseCurrency,
+}}
+
+// RegisterUnit makes a named unit available to Column.Unit next to the built-in percent and currency
+// units. fn rewrites a string rule value or input into the plain number the column's data type
+// accepts; strings without the unit's markers should be returned unchanged. Names are matched
+// case-insensitively.
+func RegisterUnit(name string, fn func(string) string) error {
+	key := normalizeKeyword(name)
+	if key == "" {
+		return fmt.Errorf("unit name must not be empty")
+	}
+	if fn == nil {
+		return fmt.Errorf("unit %s requires a function", name)
+	}
+	unitRegistry.Lock()
+	defer unitRegistry.Unlock()
+	unitRegistry.funcs[key] = fn
+	return nil
+}
+
+// unitParser resolves the column's Unit. It returns nil when the column declares none.
+func (c Column) unitParser() (func(string) string, error) {
+	if c.Unit == "" {
+		return nil, nil
+	}
+	if c.Type != ColumnTypeCondition || (c.DataType != DataTypeInteger && c.DataType != DataTypeDecimal) {
+		return nil, fmt.Errorf("column %s: only INTEGER and DECIMAL condition columns can have a unit", c.Name)
+	}
+	unitRegistry.RLock()
+	defer unitRegistry.RUnlock()
+	fn, ok := unitRegistry.funcs[normalizeKeyword(c.Unit)]
+	if !ok {
+		return nil, fmt.Errorf("column %s: unknown unit %q", c.Name, c.Unit)
+	}
+	return fn, nil
+}
+
+// applyUnit rewrites the strings of a value, or of a list value, with the unit parser. Other values
+// are returned as they are.
+func applyUnit(parse func(string) string, v any) any {
+	switch val := v.(type) {
+	case string:
+		return parse(val)
+	case []string:
+		out := make([]any, len(val))
+		for i, s := range val {
+			out[i] = parse(s)
+		}
+		return out
+	case []any:
+		out := make([]any, len(val))
+		for i, item := range val {
+			out[i] = applyUnit(parse, item)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// parsePercent drops a trailing percent sign, keeping the value in percentage points: "45%" is 45.
+func parsePercent(s string) string {
+	s = strings.TrimSpace(s)
+	return strings.TrimSpace(strings.TrimSuffix(s, "%"))
+}
+
+// parseCurrency drops a leading currency symbol, after an optional sign, and thousands separators:
+// "$1,200.50" is 1200.50 and "-$5" is -5.
+func parseCurrency(s string) string {
+	s = strings.TrimSpace(s)
+	sign := ""
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		sign, s = s[:1], strings.TrimSpace(s[1:])
+	}
+	for _, symbol := range []string{"$", "€", "£", "¥"} {
+		if rest, ok := strings.CutPrefix(s, symbol); ok {
+			s = strings.TrimSpace(rest)
+			break
+		}
+	}
+	return sign + strings.ReplaceAll(s, ",", "")
+}
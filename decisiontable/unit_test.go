@@ -0,0 +1,100 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestUnitColumnsParseRulesAndInputsAlike(t *testing.T) {
+	const doc = `
+{
+  "decisionTable": {
+    "name": "review",
+    "policies": {"matchPolicy": "FIRST", "noMatchPolicy": "RETURN_DEFAULT"},
+    "columns": [
+      {"name": "utilization", "type": "CONDITION", "dataType": "DECIMAL", "unit": "percent"},
+      {"name": "amount", "type": "CONDITION", "dataType": "DECIMAL", "unit": "currency"},
+      {"name": "action", "type": "CONCLUSION", "dataType": "STRING"}
+    ],
+    "rules": [
+      {"id": "large", "when": [null, {"operator": "greaterThanOrEqual", "value": "$1,000.00"}], "then": ["review"]},
+      {"id": "maxed", "when": [{"operator": "greaterThan", "value": "80%"}, null], "then": ["limit"]}
+    ],
+    "defaultRule": {"then": ["approve"]}
+  }
+}`
+	dt, err := LoadJSON([]byte(doc), "review.json")
+	if err != nil {
+		t.Fatalf("load json: %v", err)
+	}
+	cases := []struct {
+		input map[string]any
+		want  string
+	}{
+		{map[string]any{"amount": "$1,200.50"}, "review"},
+		{map[string]any{"amount": "-$1,200.50"}, "approve"},
+		{map[string]any{"amount": "1,000"}, "review"},
+		{map[string]any{"amount": 999.99}, "approve"},
+		{map[string]any{"utilization": "85%"}, "limit"},
+		{map[string]any{"utilization": " 80 % "}, "approve"},
+		{map[string]any{"utilization": 81}, "limit"},
+	}
+	for _, tc := range cases {
+		rows, err := dt.Evaluate(tc.input, nil)
+		if err != nil {
+			t.Fatalf("evaluate %v returned error: %v", tc.input, err)
+		}
+		if len(rows) != 1 || rows[0].Values["action"] != tc.want {
+			t.Fatalf("input %v: expected %s, got %#v", tc.input, tc.want, rows)
+		}
+	}
+
+	coerced, err := dt.CoerceInput(map[string]any{"amount": "$1,200.50", "utilization": "45%"})
+	if err != nil {
+		t.Fatalf("coerce input: %v", err)
+	}
+	if coerced["amount"].(*big.Float).Cmp(big.NewFloat(1200.5)) != 0 || coerced["utilization"].(*big.Float).Cmp(big.NewFloat(45)) != 0 {
+		t.Fatalf("unexpected coerced values %v", coerced)
+	}
+	if _, err := dt.Evaluate(map[string]any{"amount": "1,200 USD"}, nil); err == nil {
+		t.Fatalf("expected an unparseable amount to fail")
+	}
+}
+
+func TestUnitValidation(t *testing.T) {
+	cases := []struct {
+		col  Column
+		want string
+	}{
+		{Column{Name: "rate", Type: ColumnTypeCondition, DataType: DataTypeDecimal, Unit: "basis points"}, `unknown unit "basis points"`},
+		{Column{Name: "name", Type: ColumnTypeCondition, DataType: DataTypeString, Unit: "percent"}, "only INTEGER and DECIMAL condition columns can have a unit"},
+		{Column{Name: "fee", Type: ColumnTypeConclusion, DataType: DataTypeDecimal, Unit: "currency"}, "only INTEGER and DECIMAL condition columns can have a unit"},
+	}
+	for _, tc := range cases {
+		if err := tc.col.validate(); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("column %s: expected %q, got %v", tc.col.Name, tc.want, err)
+		}
+	}
+
+	if err := RegisterUnit("basis points", func(s string) string { return strings.TrimSuffix(strings.TrimSpace(s), "bp") }); err != nil {
+		t.Fatalf("register unit: %v", err)
+	}
+	if err := cases[0].col.validate(); err != nil {
+		t.Fatalf("expected registered unit to be accepted, got %v", err)
+	}
+}
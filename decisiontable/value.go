@@ -19,6 +19,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"math/big"
 	"reflect"
 	"regexp"
 	"strconv"
@@ -31,9 +32,26 @@ var (
 	ErrUnknownColumn = errors.New("unknown column")
 	// ErrUnsupportedOperator is returned when an operator is not implemented yet.
 	ErrUnsupportedOperator = errors.New("unsupported operator")
+	// ErrUnknownInputKey is returned when strict input checking is enabled and the input has keys without a condition column.
+	ErrUnknownInputKey = errors.New("unknown input key")
+	// ErrMissingInput is returned when a rule tests a column whose MissingPolicy is ERROR and the input lacks it.
+	ErrMissingInput = errors.New("missing input")
+	// ErrCurrencyMismatch is returned when a money column's input is not in the column's currency.
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+	// ErrRuleNotFound is returned when a rule ID does not identify a row of the table.
+	ErrRuleNotFound = errors.New("rule not found")
 )
 
-func sanitizeExpectedValue(dt DataType, op OperatorType, raw any) (any, error) {
+func sanitizeExpectedValue(dt DataType, op OperatorType, raw any, regexFlags, interval string, allowEmpty bool) (any, error) {
+	if regexFlags != "" && op != OperatorMatchesRegex {
+		return nil, fmt.Errorf("regex flags only supported for operator MATCHES_REGEX, got %s", op)
+	}
+	if interval != "" && !isBetweenOperator(op) {
+		return nil, fmt.Errorf("interval only supported for operators BETWEEN and NOT_BETWEEN, got %s", op)
+	}
+	if _, ok := lookupOperator(op); ok {
+		return raw, nil
+	}
 	switch op {
 	case OperatorMatchesRegex:
 		if raw == nil {
@@ -47,11 +65,25 @@ func sanitizeExpectedValue(dt DataType, op OperatorType, raw any) (any, error) {
 		if !ok {
 			return nil, fmt.Errorf("operator MATCHES_REGEX requires string pattern, got %T", pattern)
 		}
+		if regexFlags != "" {
+			if err := validateRegexFlags(regexFlags); err != nil {
+				return nil, err
+			}
+			str = "(?" + regexFlags + ")" + str
+		}
 		re, err := regexp.Compile(str)
 		if err != nil {
 			return nil, err
 		}
 		return re, nil
+	case OperatorTextEqual:
+		if dt != DataTypeDecimal {
+			return nil, fmt.Errorf("operator TEXT_EQ only supported for DECIMAL columns")
+		}
+		if raw == nil {
+			return nil, fmt.Errorf("operator TEXT_EQ requires a value")
+		}
+		return decimalText(raw)
 	case OperatorIsNull, OperatorIsNotNull:
 		if raw == nil {
 			return true, nil
@@ -66,12 +98,106 @@ func sanitizeExpectedValue(dt DataType, op OperatorType, raw any) (any, error) {
 		return flag, nil
 	}
 
+	if isBetweenOperator(op) {
+		return sanitizeRange(dt, raw, interval)
+	}
+	if isAggregateOperator(op) {
+		return sanitizeAggregateValue(dt, op, raw)
+	}
 	if requiresCollectionValue(op) {
-		return sanitizeCollection(dt, raw)
+		values, err := sanitizeCollection(dt, raw)
+		if err != nil {
+			return nil, err
+		}
+		if len(values) == 0 && !allowEmpty {
+			return nil, fmt.Errorf("operator %s requires at least one value", op)
+		}
+		if values == nil {
+			values = []any{}
+		}
+		return values, nil
+	}
+	if op == OperatorAllEqual {
+		// the operand is one element compared with every item of the list input
+		return coercePrimitive(elementDataType(dt), raw)
 	}
 	return coercePrimitive(dt, raw)
 }
 
+func isBetweenOperator(op OperatorType) bool {
+	return op == OperatorBetween || op == OperatorNotBetween
+}
+
+// sanitizeRange validates the [low, high] operand of BETWEEN or NOT_BETWEEN on an orderable column.
+// interval holds the brackets, already normalized by splitInterval.
+func sanitizeRange(dt DataType, raw any, interval string) ([]any, error) {
+	if !isOrderedDataType(dt) {
+		return nil, fmt.Errorf("operator BETWEEN only supported for numeric, date and duration columns")
+	}
+	if err := validateInterval(interval); err != nil {
+		return nil, err
+	}
+	bounds, err := sanitizeCollection(dt, raw)
+	if err != nil {
+		return nil, err
+	}
+	if len(bounds) != 2 || bounds[0] == nil || bounds[1] == nil {
+		return nil, fmt.Errorf("operator BETWEEN requires exactly two bounds, got %d", len(bounds))
+	}
+	inverted, err := compare(dt, OperatorGreater, bounds[0], bounds[1])
+	if err != nil {
+		return nil, err
+	}
+	if inverted {
+		return nil, fmt.Errorf("operator BETWEEN lower bound %s exceeds upper bound %s", formatValue(dt, bounds[0]), formatValue(dt, bounds[1]))
+	}
+	if interval != "" && interval != "[]" {
+		same, err := equals(dt, bounds[0], bounds[1])
+		if err != nil {
+			return nil, err
+		}
+		if same {
+			return nil, fmt.Errorf("interval %c%s..%s%c is empty", interval[0], formatValue(dt, bounds[0]), formatValue(dt, bounds[1]), interval[1])
+		}
+	}
+	return bounds, nil
+}
+
+func validateInterval(interval string) error {
+	switch interval {
+	case "", "[]", "[)", "(]", "()":
+		return nil
+	default:
+		return fmt.Errorf("unknown interval %q (supported: [], [), (], ())", interval)
+	}
+}
+
+func isIntervalNotation(s string) bool {
+	s = strings.TrimSpace(s)
+	return len(s) >= 4 && strings.ContainsRune("[(", rune(s[0])) && strings.ContainsRune("])", rune(s[len(s)-1])) && strings.Contains(s, "..")
+}
+
+// splitInterval turns interval notation such as "[0..100)" into its two bounds and brackets. Other raw
+// values are returned unchanged with interval. Closed ranges normalize to an empty interval.
+func splitInterval(raw any, interval string) (any, string, error) {
+	if s, ok := raw.(string); ok && isIntervalNotation(s) {
+		s = strings.TrimSpace(s)
+		if interval != "" {
+			return nil, "", fmt.Errorf("interval notation %q must not also set interval %q", s, interval)
+		}
+		parts := strings.Split(s[1:len(s)-1], "..")
+		if len(parts) != 2 {
+			return nil, "", fmt.Errorf("invalid interval %q", s)
+		}
+		raw = []any{strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])}
+		interval = string(s[0]) + string(s[len(s)-1])
+	}
+	if interval == "[]" {
+		interval = ""
+	}
+	return raw, interval, nil
+}
+
 func sanitizeReturnValue(dt DataType, raw any) (any, error) {
 	return coercePrimitive(dt, raw)
 }
@@ -93,7 +219,9 @@ func requiresCollectionValue(op OperatorType) bool {
 		OperatorAllContained,
 		OperatorNotAllContained,
 		OperatorContainsAll,
-		OperatorNotContainsAll:
+		OperatorNotContainsAll,
+		OperatorSetEquals,
+		OperatorMultisetContained:
 		return true
 	default:
 		return false
@@ -108,10 +236,12 @@ func expectsActualCollection(op OperatorType) bool {
 		OperatorNotAllContained,
 		OperatorContainsAll,
 		OperatorNotContainsAll,
+		OperatorSetEquals,
+		OperatorMultisetContained,
 		OperatorAllEqual:
 		return true
 	default:
-		return false
+		return isAggregateOperator(op) && !isStringLengthOperator(op)
 	}
 }
 
@@ -126,17 +256,33 @@ func coercePrimitive(dt DataType, raw any) (any, error) {
 	case DataTypeInteger:
 		return toInt64(raw)
 	case DataTypeDecimal:
-		return toBigFloat(raw)
+		// a nil *big.Float must surface as an untyped nil so IS_NULL sees it
+		f, err := toBigFloat(raw)
+		if err != nil || f == nil {
+			return nil, err
+		}
+		return f, nil
 	case DataTypeBoolean:
+		// a nil *bool is the usual Go encoding of an unknown tri-state flag, so it stays null
+		if p, ok := raw.(*bool); ok {
+			if p == nil {
+				return nil, nil
+			}
+			return *p, nil
+		}
 		return toBool(raw)
 	case DataTypeDate:
 		return parseISODate(raw)
 	case DataTypeDateTime:
 		return parseISODateTime(raw)
+	case DataTypeDuration:
+		return toDuration(raw)
 	case DataTypeListString:
 		return coerceList(raw, DataTypeString)
 	case DataTypeListInteger:
 		return coerceList(raw, DataTypeInteger)
+	case DataTypeListDecimal:
+		return coerceList(raw, DataTypeDecimal)
 	default:
 		return nil, fmt.Errorf("unsupported data type %s", dt)
 	}
@@ -178,31 +324,46 @@ func coerceList(raw any, elemType DataType) ([]any, error) {
 	return result, nil
 }
 
+// parseISODate parses a DATE value. Timestamps (time.Time or RFC 3339 strings) are accepted and
+// truncated to their calendar date in their own offset, so 2024-01-01T23:30:00-05:00 becomes 2024-01-01.
+// Dates are always represented as midnight UTC.
 func parseISODate(raw any) (time.Time, error) {
+	if ts, ok := raw.(time.Time); ok {
+		return truncateToDate(ts), nil
+	}
 	str, err := toTrimmedString(raw)
 	if err != nil {
 		return time.Time{}, err
 	}
 	parsed, err := time.Parse("2006-01-02", str)
-	if err != nil {
-		return time.Time{}, fmt.Errorf("invalid date %q: %w", str, err)
+	if err == nil {
+		return parsed, nil
+	}
+	if ts, tsErr := parseISODateTime(str); tsErr == nil {
+		return truncateToDate(ts), nil
 	}
-	return parsed, nil
+	return time.Time{}, fmt.Errorf("invalid date %q: %w", str, err)
 }
 
+// parseISODateTime parses a DATETIME value. Date-only strings are accepted as midnight UTC.
 func parseISODateTime(raw any) (time.Time, error) {
+	if ts, ok := raw.(time.Time); ok {
+		return ts, nil
+	}
 	str, err := toTrimmedString(raw)
 	if err != nil {
 		return time.Time{}, err
 	}
-	layouts := []string{time.RFC3339Nano, time.RFC3339}
+	layouts := []string{time.RFC3339Nano, time.RFC3339, "2006-01-02"}
 	var lastErr error
 	for _, layout := range layouts {
 		parsed, parseErr := time.Parse(layout, str)
 		if parseErr == nil {
 			return parsed, nil
 		}
-		lastErr = parseErr
+		if lastErr == nil {
+			lastErr = parseErr
+		}
 	}
 	if lastErr == nil {
 		lastErr = fmt.Errorf("unable to parse datetime")
@@ -210,6 +371,39 @@ func parseISODateTime(raw any) (time.Time, error) {
 	return time.Time{}, fmt.Errorf("invalid datetime %q: %w", str, lastErr)
 }
 
+// toDuration accepts time.Duration values, Go duration strings ("500ms", "0.2s") and integers,
+// which are read as nanoseconds.
+func toDuration(raw any) (time.Duration, error) {
+	switch v := raw.(type) {
+	case time.Duration:
+		return v, nil
+	case string:
+		trimmed := strings.TrimSpace(v)
+		if trimmed == "" {
+			return 0, fmt.Errorf("cannot convert empty string to duration")
+		}
+		d, err := time.ParseDuration(trimmed)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q: %w", v, err)
+		}
+		return d, nil
+	case json.Number:
+		// a JSON number is a count of nanoseconds, as when it is decoded into a float64
+	case fmt.Stringer:
+		return toDuration(v.String())
+	}
+	ns, err := toInt64(raw)
+	if err != nil {
+		return 0, fmt.Errorf("cannot convert %T to duration", raw)
+	}
+	return time.Duration(ns), nil
+}
+
+func truncateToDate(ts time.Time) time.Time {
+	y, m, d := ts.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
 func toTrimmedString(raw any) (string, error) {
 	if raw == nil {
 		return "", fmt.Errorf("cannot convert nil to string")
@@ -232,12 +426,50 @@ func toTrimmedString(raw any) (string, error) {
 	}
 }
 
+func isStringDataType(dt DataType) bool {
+	return dt == DataTypeString || dt == DataTypeListString
+}
+
+// normalizeStrings applies fn to a sanitized string or to every string inside a sanitized list.
+func normalizeStrings(v any, fn func(string) string) any {
+	switch val := v.(type) {
+	case string:
+		return fn(val)
+	case []any:
+		out := make([]any, len(val))
+		for i, item := range val {
+			out[i] = normalizeStrings(item, fn)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// normalizeActualStrings coerces the actual value the same way evaluateCell would and normalizes the result.
+func normalizeActualStrings(dt DataType, op OperatorType, actual any, fn func(string) string) (any, error) {
+	if expectsActualCollection(op) {
+		values, err := sanitizeActualCollection(dt, actual)
+		if err != nil {
+			return nil, err
+		}
+		return normalizeStrings(values, fn), nil
+	}
+	value, err := sanitizeActualValue(dt, actual)
+	if err != nil {
+		return nil, err
+	}
+	return normalizeStrings(value, fn), nil
+}
+
 func elementDataType(dt DataType) DataType {
 	switch dt {
 	case DataTypeListString:
 		return DataTypeString
 	case DataTypeListInteger:
 		return DataTypeInteger
+	case DataTypeListDecimal:
+		return DataTypeDecimal
 	default:
 		return dt
 	}
@@ -333,6 +565,10 @@ func toInt64(raw any) (int64, error) {
 			return 0, fmt.Errorf("cannot convert empty string to int64")
 		}
 		return strconv.ParseInt(trimmed, 10, 64)
+	case *big.Float:
+		return decimalToInt64(v)
+	case big.Float:
+		return decimalToInt64(&v)
 	case json.Number:
 		if i, err := v.Int64(); err == nil {
 			return i, nil
@@ -350,6 +586,20 @@ func toInt64(raw any) (int64, error) {
 	}
 }
 
+func decimalToInt64(v *big.Float) (int64, error) {
+	if v == nil {
+		return 0, fmt.Errorf("cannot convert nil decimal to int64")
+	}
+	if !v.IsInt() {
+		return 0, fmt.Errorf("value %s is not an integer", v.Text('g', -1))
+	}
+	i, acc := v.Int64()
+	if acc != big.Exact {
+		return 0, fmt.Errorf("value %s overflows int64", v.Text('g', -1))
+	}
+	return i, nil
+}
+
 func toBool(raw any) (bool, error) {
 	switch v := raw.(type) {
 	case bool:
@@ -377,3 +627,15 @@ func toBool(raw any) (bool, error) {
 		return false, fmt.Errorf("cannot convert %T to bool", raw)
 	}
 }
+
+// validateRegexFlags accepts the RE2 flag characters that can be applied as a (?flags) group.
+func validateRegexFlags(flags string) error {
+	for _, r := range flags {
+		switch r {
+		case 'i', 'm', 's', 'U':
+		default:
+			return fmt.Errorf("unknown regex flag %q (supported: i, m, s, U)", r)
+		}
+	}
+	return nil
+}
@@ -22,7 +22,7 @@ func cloneValueForType(v any, dt DataType) any {
 			return cloneDecimal(dec)
 		}
 	}
-	if dt == DataTypeListString || dt == DataTypeListInteger {
+	if isListDataType(dt) {
 		if list, ok := v.([]any); ok {
 			return cloneAnySlice(list)
 		}
@@ -40,6 +40,20 @@ func cloneArbitraryValue(v any) any {
 	return v
 }
 
+// cloneEvalCells copies cells and their operand values, so a result's conditions can be modified
+// without changing the rule they came from.
+func cloneEvalCells(cells []EvalCell) []EvalCell {
+	if cells == nil {
+		return nil
+	}
+	dup := make([]EvalCell, len(cells))
+	for i, cell := range cells {
+		cell.Value = cloneArbitraryValue(cell.Value)
+		dup[i] = cell
+	}
+	return dup
+}
+
 func cloneAnySlice(src []any) []any {
 	if src == nil {
 		return nil
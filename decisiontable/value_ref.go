@@ -0,0 +1,49 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import (
+	"fmt"
+	"strings"
+)
+
+// checkValueRef validates a cell that compares its column with another input column.
+func (dt *DecisionTable) checkValueRef(col Column, cell EvalCell) error {
+	switch cell.Operator {
+	case OperatorEqual, OperatorNotEqual, OperatorGreater, OperatorGreaterOrEqual, OperatorLess, OperatorLessOrEqual:
+	default:
+		return fmt.Errorf("operator %s does not support value references", cell.Operator)
+	}
+	if cell.Value != nil {
+		return fmt.Errorf("value and value reference %s are mutually exclusive", cell.ValueRef)
+	}
+	if cell.Flags != "" {
+		return fmt.Errorf("flags are not supported with value reference %s", cell.ValueRef)
+	}
+	ref, ok := dt.conditionColumns[cell.ValueRef]
+	if !ok {
+		return fmt.Errorf("value reference %q is not a condition column", cell.ValueRef)
+	}
+	if ref.Name == col.Name {
+		return fmt.Errorf("value reference %s refers to its own column", ref.Name)
+	}
+	if ref.DataType != col.DataType {
+		return fmt.Errorf("value reference %s has data type %s, want %s", ref.Name, ref.DataType, col.DataType)
+	}
+	if !strings.EqualFold(ref.Currency, col.Currency) || ref.CurrencyColumn != col.CurrencyColumn {
+		return fmt.Errorf("value reference %s is not in the same currency as %s", ref.Name, col.Name)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValueRefComparesTwoInputs(t *testing.T) {
+	const doc = `
+{
+  "decisionTable": {
+    "name": "credit",
+    "policies": {"matchPolicy": "FIRST", "noMatchPolicy": "RETURN_DEFAULT"},
+    "columns": [
+      {"name": "requestedAmount", "type": "CONDITION", "dataType": "DECIMAL"},
+      {"name": "approvedLimit", "type": "CONDITION", "dataType": "DECIMAL"},
+      {"name": "decision", "type": "CONCLUSION", "dataType": "STRING"}
+    ],
+    "rules": [
+      {"id": "within", "when": [{"operator": "lessThanOrEqual", "valueRef": "approvedLimit"}, null], "then": ["approve"]}
+    ],
+    "defaultRule": {"then": ["refer"]}
+  }
+}`
+	dt, err := LoadJSON([]byte(doc), "credit.json")
+	if err != nil {
+		t.Fatalf("load json: %v", err)
+	}
+
+	cases := []struct {
+		input map[string]any
+		want  string
+	}{
+		{map[string]any{"requestedAmount": "900.50", "approvedLimit": 1000}, "approve"},
+		{map[string]any{"requestedAmount": 1000, "approvedLimit": "1000.00"}, "approve"},
+		{map[string]any{"requestedAmount": 1200, "approvedLimit": 1000}, "refer"},
+		{map[string]any{"requestedAmount": 100}, "refer"},
+	}
+	for _, tc := range cases {
+		matches, err := dt.Evaluate(tc.input, nil)
+		if err != nil {
+			t.Fatalf("evaluate %v returned error: %v", tc.input, err)
+		}
+		if len(matches) != 1 || matches[0].Values["decision"] != tc.want {
+			t.Fatalf("input %v: expected %s, got %#v", tc.input, tc.want, matches)
+		}
+	}
+
+	matches, err := dt.Evaluate(map[string]any{"requestedAmount": 1, "approvedLimit": 2}, nil)
+	if err != nil {
+		t.Fatalf("evaluate returned error: %v", err)
+	}
+	if got := matches[0].Explain(); !strings.Contains(got, "requestedAmount <= approvedLimit") {
+		t.Fatalf("unexpected explanation %q", got)
+	}
+
+	if _, err := dt.Evaluate(map[string]any{"requestedAmount": 1, "approvedLimit": "lots"}, nil); err == nil || !strings.Contains(err.Error(), "value reference approvedLimit") {
+		t.Fatalf("expected invalid referenced input to fail, got %v", err)
+	}
+}
+
+func TestValueRefValidation(t *testing.T) {
+	dt, err := NewDecisionTable("refs",
+		[]Column{
+			{Name: "a", Type: ColumnTypeCondition, DataType: DataTypeInteger},
+			{Name: "b", Type: ColumnTypeCondition, DataType: DataTypeInteger},
+			{Name: "name", Type: ColumnTypeCondition, DataType: DataTypeString},
+		},
+		[]Column{{Name: "out", Type: ColumnTypeConclusion, DataType: DataTypeString}},
+	)
+	if err != nil {
+		t.Fatalf("failed to build table: %v", err)
+	}
+	cases := []struct {
+		cell EvalCell
+		want string
+	}{
+		{EvalCell{Column: "a", Operator: OperatorIn, ValueRef: "b"}, "does not support value references"},
+		{EvalCell{Column: "a", Operator: OperatorEqual, ValueRef: "b", Value: 1}, "mutually exclusive"},
+		{EvalCell{Column: "a", Operator: OperatorEqual, ValueRef: "missing"}, "not a condition column"},
+		{EvalCell{Column: "a", Operator: OperatorEqual, ValueRef: "a"}, "its own column"},
+		{EvalCell{Column: "a", Operator: OperatorEqual, ValueRef: "name"}, "has data type STRING, want INTEGER"},
+	}
+	for _, tc := range cases {
+		err := dt.AddRow(Row{EvalCells: []EvalCell{tc.cell}, ReturnCells: []ReturnCell{{Column: "out", Value: "x"}}})
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("cell %+v: expected error containing %q, got %v", tc.cell, tc.want, err)
+		}
+	}
+
+	if err := dt.AddRow(Row{
+		EvalCells:   []EvalCell{{Column: "a", Operator: OperatorGreater, ValueRef: "b"}},
+		ReturnCells: []ReturnCell{{Column: "out", Value: "greater"}},
+	}); err != nil {
+		t.Fatalf("failed to add row: %v", err)
+	}
+	matches, err := dt.Evaluate(map[string]any{"a": 3, "b": 2}, nil)
+	if err != nil || len(matches) != 1 || matches[0].Values["out"] != "greater" {
+		t.Fatalf("expected greater, got %#v (err %v)", matches, err)
+	}
+	if reports := dt.DetectDeadRules(); len(reports) != 0 {
+		t.Fatalf("expected no dead rules, got %#v", reports)
+	}
+	data, err := dt.MarshalBinary()
+	if err != nil {
+		t.Fatalf("marshal binary: %v", err)
+	}
+	loaded, err := LoadBinary(data)
+	if err != nil {
+		t.Fatalf("load binary: %v", err)
+	}
+	if err := dt.EqualErr(loaded); err != nil {
+		t.Fatalf("binary round trip changed the table: %v", err)
+	}
+}
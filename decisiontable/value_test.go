@@ -0,0 +1,465 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import (
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+func TestDecimalColumnMixedIntegerSources(t *testing.T) {
+	evalCols := []Column{
+		{Name: "amount", Type: ColumnTypeCondition, DataType: DataTypeDecimal},
+	}
+	retCols := []Column{
+		{Name: "tier", Type: ColumnTypeConclusion, DataType: DataTypeString},
+	}
+	dt, err := NewDecisionTable("mixed", evalCols, retCols, WithMatchPolicy(MatchPolicyFirst))
+	if err != nil {
+		t.Fatalf("failed to build table: %v", err)
+	}
+	rows := []Row{
+		{
+			RuleID:      "exact",
+			EvalCells:   []EvalCell{{Column: "amount", Operator: OperatorEqual, Value: 100}},
+			ReturnCells: []ReturnCell{{Column: "tier", Value: "exact"}},
+		},
+		{
+			RuleID:      "above",
+			EvalCells:   []EvalCell{{Column: "amount", Operator: OperatorGreater, Value: int64(100)}},
+			ReturnCells: []ReturnCell{{Column: "tier", Value: "above"}},
+		},
+		{
+			RuleID:      "below",
+			EvalCells:   []EvalCell{{Column: "amount", Operator: OperatorLess, Value: "100.0"}},
+			ReturnCells: []ReturnCell{{Column: "tier", Value: "below"}},
+		},
+	}
+	for _, row := range rows {
+		if err := dt.AddRow(row); err != nil {
+			t.Fatalf("failed to add row %s: %v", row.RuleID, err)
+		}
+	}
+
+	cases := []struct {
+		input any
+		want  string
+	}{
+		{input: int64(100), want: "exact"},
+		{input: "100.00", want: "exact"},
+		{input: uint32(100), want: "exact"},
+		{input: 100.5, want: "above"},
+		{input: 101, want: "above"},
+		{input: "99.999", want: "below"},
+		{input: int8(-3), want: "below"},
+	}
+	for _, tc := range cases {
+		matches, err := dt.Evaluate(map[string]any{"amount": tc.input}, nil)
+		if err != nil {
+			t.Fatalf("evaluate %v returned error: %v", tc.input, err)
+		}
+		if len(matches) != 1 || matches[0].RuleID != tc.want {
+			t.Fatalf("input %#v: expected %s, got %#v", tc.input, tc.want, matches)
+		}
+	}
+}
+
+func TestIntegerColumnAcceptsIntegralDecimals(t *testing.T) {
+	evalCols := []Column{
+		{Name: "count", Type: ColumnTypeCondition, DataType: DataTypeInteger},
+	}
+	retCols := []Column{
+		{Name: "bucket", Type: ColumnTypeConclusion, DataType: DataTypeString},
+	}
+	dt, err := NewDecisionTable("counts", evalCols, retCols)
+	if err != nil {
+		t.Fatalf("failed to build table: %v", err)
+	}
+	err = dt.AddRow(Row{
+		RuleID:      "many",
+		EvalCells:   []EvalCell{{Column: "count", Operator: OperatorGreaterOrEqual, Value: big.NewFloat(10)}},
+		ReturnCells: []ReturnCell{{Column: "bucket", Value: "many"}},
+	})
+	if err != nil {
+		t.Fatalf("failed to add row: %v", err)
+	}
+
+	matches, err := dt.Evaluate(map[string]any{"count": big.NewFloat(12)}, nil)
+	if err != nil {
+		t.Fatalf("evaluate returned error: %v", err)
+	}
+	if len(matches) != 1 {
+		t.Fatalf("expected match, got %#v", matches)
+	}
+
+	if _, err := dt.Evaluate(map[string]any{"count": big.NewFloat(12.5)}, nil); err == nil {
+		t.Fatalf("expected error for fractional decimal on INTEGER column")
+	}
+}
+
+func TestIntegerCompareKeepsInt64Precision(t *testing.T) {
+	evalCols := []Column{
+		{Name: "id", Type: ColumnTypeCondition, DataType: DataTypeInteger},
+	}
+	retCols := []Column{
+		{Name: "label", Type: ColumnTypeConclusion, DataType: DataTypeString},
+	}
+	dt, err := NewDecisionTable("ids", evalCols, retCols)
+	if err != nil {
+		t.Fatalf("failed to build table: %v", err)
+	}
+	err = dt.AddRow(Row{
+		RuleID:      "large",
+		EvalCells:   []EvalCell{{Column: "id", Operator: OperatorGreater, Value: int64(9007199254740992)}},
+		ReturnCells: []ReturnCell{{Column: "label", Value: "large"}},
+	})
+	if err != nil {
+		t.Fatalf("failed to add row: %v", err)
+	}
+	matches, err := dt.Evaluate(map[string]any{"id": int64(9007199254740993)}, nil)
+	if err != nil {
+		t.Fatalf("evaluate returned error: %v", err)
+	}
+	if len(matches) != 1 {
+		t.Fatalf("expected large id to compare greater, got %#v", matches)
+	}
+}
+
+func TestDecimalRejectsNaNAndInf(t *testing.T) {
+	evalCols := []Column{
+		{Name: "amount", Type: ColumnTypeCondition, DataType: DataTypeDecimal},
+	}
+	retCols := []Column{
+		{Name: "tier", Type: ColumnTypeConclusion, DataType: DataTypeString},
+	}
+	dt, err := NewDecisionTable("finite", evalCols, retCols)
+	if err != nil {
+		t.Fatalf("failed to build table: %v", err)
+	}
+
+	invalid := []any{math.NaN(), math.Inf(1), math.Inf(-1), float32(math.Inf(1)), "Inf", "-inf"}
+	for _, v := range invalid {
+		err := dt.AddRow(Row{
+			EvalCells:   []EvalCell{{Column: "amount", Operator: OperatorGreater, Value: v}},
+			ReturnCells: []ReturnCell{{Column: "tier", Value: "bad"}},
+		})
+		if err == nil || !strings.Contains(err.Error(), "column amount") {
+			t.Fatalf("rule value %v: expected error naming column, got %v", v, err)
+		}
+	}
+
+	if err := dt.AddRow(Row{
+		EvalCells:   []EvalCell{{Column: "amount", Operator: OperatorGreater, Value: 10}},
+		ReturnCells: []ReturnCell{{Column: "tier", Value: "ok"}},
+	}); err != nil {
+		t.Fatalf("failed to add row: %v", err)
+	}
+	for _, v := range invalid {
+		_, err := dt.Evaluate(map[string]any{"amount": v}, nil)
+		if err == nil || !strings.Contains(err.Error(), "column amount") {
+			t.Fatalf("input %v: expected error naming column, got %v", v, err)
+		}
+	}
+}
+
+func TestDateColumnTruncatesTimestamps(t *testing.T) {
+	evalCols := []Column{
+		{Name: "eventDate", Type: ColumnTypeCondition, DataType: DataTypeDate},
+	}
+	retCols := []Column{
+		{Name: "period", Type: ColumnTypeConclusion, DataType: DataTypeString},
+	}
+	dt, err := NewDecisionTable("dates", evalCols, retCols, WithMatchPolicy(MatchPolicyFirst))
+	if err != nil {
+		t.Fatalf("failed to build table: %v", err)
+	}
+	rows := []Row{
+		{
+			RuleID:      "new-year",
+			EvalCells:   []EvalCell{{Column: "eventDate", Operator: OperatorEqual, Value: "2024-01-01"}},
+			ReturnCells: []ReturnCell{{Column: "period", Value: "new-year"}},
+		},
+		{
+			RuleID:      "after",
+			EvalCells:   []EvalCell{{Column: "eventDate", Operator: OperatorGreaterOrEqual, Value: "2024-01-01"}},
+			ReturnCells: []ReturnCell{{Column: "period", Value: "after"}},
+		},
+	}
+	for _, row := range rows {
+		if err := dt.AddRow(row); err != nil {
+			t.Fatalf("failed to add row: %v", err)
+		}
+	}
+
+	est := time.FixedZone("EST", -5*3600)
+	cases := []struct {
+		input any
+		want  string
+	}{
+		{input: "2024-01-01T23:30:00-05:00", want: "new-year"},
+		{input: time.Date(2024, 1, 1, 23, 30, 0, 0, est), want: "new-year"},
+		{input: "2024-01-02T00:00:01Z", want: "after"},
+	}
+	for _, tc := range cases {
+		matches, err := dt.Evaluate(map[string]any{"eventDate": tc.input}, nil)
+		if err != nil {
+			t.Fatalf("evaluate %v returned error: %v", tc.input, err)
+		}
+		if len(matches) != 1 || matches[0].RuleID != tc.want {
+			t.Fatalf("input %v: expected %s, got %#v", tc.input, tc.want, matches)
+		}
+	}
+}
+
+func TestDateTimeColumnAcceptsDates(t *testing.T) {
+	evalCols := []Column{
+		{Name: "at", Type: ColumnTypeCondition, DataType: DataTypeDateTime},
+	}
+	retCols := []Column{
+		{Name: "phase", Type: ColumnTypeConclusion, DataType: DataTypeString},
+	}
+	dt, err := NewDecisionTable("datetimes", evalCols, retCols)
+	if err != nil {
+		t.Fatalf("failed to build table: %v", err)
+	}
+	err = dt.AddRow(Row{
+		RuleID:      "before-noon",
+		EvalCells:   []EvalCell{{Column: "at", Operator: OperatorLess, Value: "2024-01-01T12:00:00Z"}},
+		ReturnCells: []ReturnCell{{Column: "phase", Value: "morning"}},
+	})
+	if err != nil {
+		t.Fatalf("failed to add row: %v", err)
+	}
+	for _, input := range []any{"2024-01-01", time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC)} {
+		matches, err := dt.Evaluate(map[string]any{"at": input}, nil)
+		if err != nil {
+			t.Fatalf("evaluate %v returned error: %v", input, err)
+		}
+		if len(matches) != 1 {
+			t.Fatalf("input %v: expected match, got %#v", input, matches)
+		}
+	}
+}
+
+func TestDecimalNumericVersusTextualEquality(t *testing.T) {
+	evalCols := []Column{
+		{Name: "rate", Type: ColumnTypeCondition, DataType: DataTypeDecimal},
+	}
+	retCols := []Column{
+		{Name: "mode", Type: ColumnTypeConclusion, DataType: DataTypeString},
+	}
+	numeric, err := NewDecisionTable("numeric", evalCols, retCols)
+	if err != nil {
+		t.Fatalf("failed to build table: %v", err)
+	}
+	textual, err := NewDecisionTable("textual", evalCols, retCols)
+	if err != nil {
+		t.Fatalf("failed to build table: %v", err)
+	}
+	if err := numeric.AddRow(Row{
+		EvalCells:   []EvalCell{{Column: "rate", Operator: OperatorEqual, Value: "3.50"}},
+		ReturnCells: []ReturnCell{{Column: "mode", Value: "numeric"}},
+	}); err != nil {
+		t.Fatalf("failed to add row: %v", err)
+	}
+	if err := textual.AddRow(Row{
+		EvalCells:   []EvalCell{{Column: "rate", Operator: OperatorTextEqual, Value: " +3.50"}},
+		ReturnCells: []ReturnCell{{Column: "mode", Value: "textual"}},
+	}); err != nil {
+		t.Fatalf("failed to add row: %v", err)
+	}
+
+	cases := []struct {
+		input       any
+		wantNumeric bool
+		wantTextual bool
+	}{
+		{input: "3.5", wantNumeric: true, wantTextual: false},
+		{input: 3.5, wantNumeric: true, wantTextual: false},
+		{input: "3.50", wantNumeric: true, wantTextual: true},
+		{input: "03.50", wantNumeric: true, wantTextual: true},
+		{input: "3.500", wantNumeric: true, wantTextual: false},
+	}
+	for _, tc := range cases {
+		rows, _ := numeric.Evaluate(map[string]any{"rate": tc.input}, nil)
+		if (len(rows) == 1) != tc.wantNumeric {
+			t.Fatalf("numeric %v: expected match %v, got %#v", tc.input, tc.wantNumeric, rows)
+		}
+		rows, _ = textual.Evaluate(map[string]any{"rate": tc.input}, nil)
+		if (len(rows) == 1) != tc.wantTextual {
+			t.Fatalf("textual %v: expected match %v, got %#v", tc.input, tc.wantTextual, rows)
+		}
+	}
+}
+
+func TestTypedNilDecimalInputIsNull(t *testing.T) {
+	dt, err := NewDecisionTable("rates",
+		[]Column{{Name: "rate", Type: ColumnTypeCondition, DataType: DataTypeDecimal}},
+		[]Column{{Name: "state", Type: ColumnTypeConclusion, DataType: DataTypeString}},
+		WithMatchPolicy(MatchPolicyFirst))
+	if err != nil {
+		t.Fatalf("failed to build table: %v", err)
+	}
+	if err := dt.AddRow(Row{
+		EvalCells:   []EvalCell{{Column: "rate", Operator: OperatorIsNull}},
+		ReturnCells: []ReturnCell{{Column: "state", Value: "unset"}},
+	}); err != nil {
+		t.Fatalf("failed to add row: %v", err)
+	}
+	rows, err := dt.Evaluate(map[string]any{"rate": (*big.Float)(nil)}, nil)
+	if err != nil {
+		t.Fatalf("evaluate returned error: %v", err)
+	}
+	if len(rows) != 1 || rows[0].Values["state"] != "unset" {
+		t.Fatalf("expected a nil *big.Float to match IS_NULL, got %#v", rows)
+	}
+}
+
+func TestDecimalTextKeepsEveryDigitOfBigFloat(t *testing.T) {
+	v, ok := new(big.Float).SetPrec(decimalPrecision).SetString("1234567.891234")
+	if !ok {
+		t.Fatalf("failed to parse decimal")
+	}
+	text, err := decimalText(v)
+	if err != nil {
+		t.Fatalf("decimal text: %v", err)
+	}
+	if text != "1234567.891234" {
+		t.Fatalf("expected every digit to be kept, got %q", text)
+	}
+	if text, err := decimalText(*v); err != nil || text != "1234567.891234" {
+		t.Fatalf("expected a big.Float value to keep every digit, got %q (%v)", text, err)
+	}
+}
+
+func TestTextEqualRequiresDecimalColumn(t *testing.T) {
+	dt := buildSampleTable(t)
+	err := dt.AddRow(Row{
+		EvalCells:   []EvalCell{{Column: "country", Operator: OperatorTextEqual, Value: "US"}},
+		ReturnCells: []ReturnCell{{Column: "tier", Value: "x"}},
+	})
+	if err == nil {
+		t.Fatalf("expected TEXT_EQ to be rejected on STRING column")
+	}
+}
+
+func TestStringNormalizerComposedAndDecomposed(t *testing.T) {
+	evalCols := []Column{
+		{Name: "city", Type: ColumnTypeCondition, DataType: DataTypeString},
+		{Name: "tags", Type: ColumnTypeCondition, DataType: DataTypeListString},
+	}
+	retCols := []Column{
+		{Name: "matched", Type: ColumnTypeConclusion, DataType: DataTypeString},
+	}
+	composed := "caf\u00e9"
+	decomposed := "cafe\u0301"
+
+	build := func(opts ...Option) *DecisionTable {
+		dt, err := NewDecisionTable("cities", evalCols, retCols, opts...)
+		if err != nil {
+			t.Fatalf("failed to build table: %v", err)
+		}
+		rows := []Row{
+			{
+				RuleID:      "eq",
+				EvalCells:   []EvalCell{{Column: "city", Operator: OperatorEqual, Value: composed}},
+				ReturnCells: []ReturnCell{{Column: "matched", Value: "eq"}},
+			},
+			{
+				RuleID:      "in",
+				EvalCells:   []EvalCell{{Column: "city", Operator: OperatorIn, Value: []string{composed}}},
+				ReturnCells: []ReturnCell{{Column: "matched", Value: "in"}},
+			},
+			{
+				RuleID:      "regex",
+				EvalCells:   []EvalCell{{Column: "city", Operator: OperatorMatchesRegex, Value: "^caf\u00e9$"}},
+				ReturnCells: []ReturnCell{{Column: "matched", Value: "regex"}},
+			},
+			{
+				RuleID:      "tags",
+				EvalCells:   []EvalCell{{Column: "tags", Operator: OperatorContainsAll, Value: []string{decomposed}}},
+				ReturnCells: []ReturnCell{{Column: "matched", Value: "tags"}},
+			},
+		}
+		for _, row := range rows {
+			if err := dt.AddRow(row); err != nil {
+				t.Fatalf("failed to add row %s: %v", row.RuleID, err)
+			}
+		}
+		return dt
+	}
+
+	input := map[string]any{"city": decomposed, "tags": []string{composed}}
+	if _, err := build().Evaluate(input, nil); err == nil {
+		t.Fatalf("expected no match without normalizer")
+	}
+
+	rows, err := build(WithStringNormalizer(norm.NFC.String)).Evaluate(input, nil)
+	if err != nil {
+		t.Fatalf("evaluate returned error: %v", err)
+	}
+	if len(rows) != 4 {
+		t.Fatalf("expected all rules to match after normalization, got %#v", rows)
+	}
+}
+
+func TestBooleanTriState(t *testing.T) {
+	dt, err := NewDecisionTableWithRows("flags",
+		[]Column{{Name: "verified", Type: ColumnTypeCondition, DataType: DataTypeBoolean}},
+		[]Column{{Name: "state", Type: ColumnTypeConclusion, DataType: DataTypeString}},
+		[]Row{
+			{RuleID: "unknown", EvalCells: []EvalCell{{Column: "verified", Operator: OperatorIsNull}}, ReturnCells: []ReturnCell{{Column: "state", Value: "unknown"}}},
+			{RuleID: "no", EvalCells: []EvalCell{{Column: "verified", Operator: OperatorEqual, Value: false}}, ReturnCells: []ReturnCell{{Column: "state", Value: "no"}}},
+			{RuleID: "yes", EvalCells: []EvalCell{{Column: "verified", Operator: OperatorEqual, Value: "true"}}, ReturnCells: []ReturnCell{{Column: "state", Value: "yes"}}},
+		},
+		WithMatchPolicy(MatchPolicyAll),
+	)
+	if err != nil {
+		t.Fatalf("failed to build table: %v", err)
+	}
+
+	yes, no := true, false
+	cases := []struct {
+		name  string
+		input any
+		want  string
+	}{
+		{"nil", nil, "unknown"},
+		{"nil pointer", (*bool)(nil), "unknown"},
+		{"true", true, "yes"},
+		{"false", false, "no"},
+		{"true pointer", &yes, "yes"},
+		{"false pointer", &no, "no"},
+		{"false string", "false", "no"},
+	}
+	for _, tc := range cases {
+		rows, err := dt.Evaluate(map[string]any{"verified": tc.input}, nil)
+		if err != nil {
+			t.Fatalf("%s: evaluate returned error: %v", tc.name, err)
+		}
+		if len(rows) != 1 || rows[0].RuleID != tc.want {
+			t.Fatalf("%s: expected only %s to match, got %#v", tc.name, tc.want, rows)
+		}
+	}
+
+	if _, err := dt.Evaluate(map[string]any{"verified": "unknown"}, nil); err == nil {
+		t.Fatalf("expected unrecognized boolean string to be rejected")
+	}
+}
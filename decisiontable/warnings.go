@@ -0,0 +1,139 @@
+// Copyright 2025 Nhat-Nguyen Nguyen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisiontable
+
+import (
+	"fmt"
+	"io"
+)
+
+// WarningKind classifies a load warning.
+type WarningKind string
+
+const (
+	// WarningAutoRuleID marks a rule whose ID was generated because the source left it blank.
+	WarningAutoRuleID WarningKind = "AUTO_RULE_ID"
+	// WarningEmptyOutputColumn marks an output column that no rule, nor the default row, sets.
+	WarningEmptyOutputColumn WarningKind = "EMPTY_OUTPUT_COLUMN"
+	// WarningCatchAllRule marks a rule without conditions, which matches every input.
+	WarningCatchAllRule WarningKind = "CATCH_ALL_RULE"
+	// WarningDeadRule marks a rule whose conditions can never be satisfied together.
+	WarningDeadRule WarningKind = "DEAD_RULE"
+)
+
+// Warning is a problem found while loading a table that does not stop the load. RuleID and RowNumber
+// name the rule it concerns and Column the column, when there is one.
+type Warning struct {
+	Kind      WarningKind
+	RuleID    string
+	RowNumber int
+	Column    string
+	Message   string
+}
+
+func (w Warning) String() string {
+	return fmt.Sprintf("%s: %s", w.Kind, w.Message)
+}
+
+// LoadResult is the outcome of a verbose load. Table is nil when Errors is not empty; Warnings are
+// reported either way for whatever could be checked.
+type LoadResult struct {
+	Table    *DecisionTable
+	Warnings []Warning
+	Errors   []error
+}
+
+// LoadJSONVerbose loads a decision table like LoadJSON but reports every error it finds, as
+// ValidateJSON does but under the same options, along with the warnings for a table that did load.
+func LoadJSONVerbose(data []byte, name string, opts ...Option) LoadResult {
+	dt, errs := loadJSON(data, name, opts, true)
+	if len(errs) > 0 {
+		return LoadResult{Errors: errs}
+	}
+	return LoadResult{Table: dt, Warnings: dt.loadWarnings()}
+}
+
+// LoadExcelVerbose loads a decision table like LoadExcel and reports the warnings for it. The Excel
+// loader stops at its first error, so Errors holds at most one.
+func LoadExcelVerbose(name string, r io.Reader, opts ...Option) LoadResult {
+	dt, err := LoadExcel(name, r, opts...)
+	if err != nil {
+		return LoadResult{Errors: []error{err}}
+	}
+	return LoadResult{Table: dt, Warnings: dt.loadWarnings()}
+}
+
+// loadWarnings lists the warnings for a loaded table, in rule order, followed by the output columns
+// that are never set.
+func (dt *DecisionTable) loadWarnings() []Warning {
+	var warnings []Warning
+	set := make(map[string]bool, len(dt.outputOrder))
+	noteOutputs := func(row Row) {
+		for _, cell := range row.ReturnCells {
+			if cell.Value != nil {
+				set[cell.Column] = true
+			}
+		}
+	}
+	for _, row := range dt.rows {
+		if row.autoID {
+			warnings = append(warnings, Warning{
+				Kind:      WarningAutoRuleID,
+				RuleID:    row.RuleID,
+				RowNumber: row.Number,
+				Message:   fmt.Sprintf("rule %d has no id; assigned %q", row.Number, row.RuleID),
+			})
+		}
+		if len(row.EvalCells) == 0 {
+			warnings = append(warnings, Warning{
+				Kind:      WarningCatchAllRule,
+				RuleID:    row.RuleID,
+				RowNumber: row.Number,
+				Message:   fmt.Sprintf("rule %q has no conditions and matches every input", row.RuleID),
+			})
+		}
+		if report, dead := deadRuleReport(row); dead {
+			warnings = append(warnings, Warning{
+				Kind:      WarningDeadRule,
+				RuleID:    row.RuleID,
+				RowNumber: row.Number,
+				Column:    report.Column,
+				Message:   fmt.Sprintf("rule %q can never match: %s", row.RuleID, report.Reason),
+			})
+		}
+		noteOutputs(row)
+	}
+	if dt.defaultRow != nil {
+		if dt.defaultRow.autoID {
+			warnings = append(warnings, Warning{
+				Kind:      WarningAutoRuleID,
+				RuleID:    dt.defaultRow.RuleID,
+				RowNumber: dt.defaultRow.Number,
+				Message:   fmt.Sprintf("default rule has no id; assigned %q", dt.defaultRow.RuleID),
+			})
+		}
+		noteOutputs(*dt.defaultRow)
+	}
+	for _, col := range dt.outputOrder {
+		if col.Type == ColumnTypeConclusion && !set[col.Name] {
+			warnings = append(warnings, Warning{
+				Kind:    WarningEmptyOutputColumn,
+				Column:  col.Name,
+				Message: fmt.Sprintf("output column %q is never set", col.Name),
+			})
+		}
+	}
+	return warnings
+}
@@ -2,7 +2,10 @@ module github.com/ngnhng/go-decision-table
 
 go 1.24.4
 
-require github.com/xuri/excelize/v2 v2.10.0
+require (
+	github.com/xuri/excelize/v2 v2.10.0
+	golang.org/x/text v0.30.0
+)
 
 require (
 	github.com/richardlehane/mscfb v1.0.4 // indirect
@@ -12,5 +15,4 @@ require (
 	github.com/xuri/nfp v0.0.2-0.20250530014748-2ddeb826f9a9 // indirect
 	golang.org/x/crypto v0.43.0 // indirect
 	golang.org/x/net v0.46.0 // indirect
-	golang.org/x/text v0.30.0 // indirect
 )